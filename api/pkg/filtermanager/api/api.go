@@ -20,6 +20,7 @@ import (
 	"sync/atomic"
 
 	"github.com/envoyproxy/envoy/contrib/golang/common/go/api"
+	"github.com/go-logr/logr"
 	"google.golang.org/protobuf/reflect/protoreflect"
 )
 
@@ -63,7 +64,7 @@ type Filter interface {
 	// EncodeData might be called multiple times during handling the response body.
 	// The endStream is true when handling the last piece of the body.
 	EncodeData(data BufferInstance, endStream bool) ResultAction
-	// TODO, just a placeholder. EncodeTrailers is not called yet
+	// EncodeTrailers processes response trailers. It is called after the last piece of the body
 	EncodeTrailers(trailers ResponseTrailerMap) ResultAction
 	EncodeWholeResponseFilter
 
@@ -225,6 +226,9 @@ type FilterCallbackHandler interface {
 
 	// PluginState returns the PluginState associated to this request.
 	PluginState() PluginState
+	// Logger returns a logger which writes JSON records to Envoy's log. Each record carries the
+	// plugin name, namespace, route name and `x-request-id` of this request.
+	Logger() logr.Logger
 }
 
 // FilterFactory returns a per-request Filter which has configuration bound to it.
@@ -234,6 +238,23 @@ type FilterFactory func(config interface{}, callbacks FilterCallbackHandler) Fil
 // DynamicMetadata operates the Envoy's dynamic metadata
 type DynamicMetadata = api.DynamicMetadata
 
+// DynamicMetadataNamespace is the namespace of the dynamic metadata published by HTNN, which can be
+// referred in Envoy like `%DYNAMIC_METADATA(htnn:consumer)%`. The plugins can publish their
+// decisions in it, with the plugin name as the key.
+const DynamicMetadataNamespace = "htnn"
+
+const (
+	// RequestIDStateNamespace is the namespace of the PluginState used to store the request ID
+	// guaranteed by the requestId plugin. When it exists, the request ID is added to the local
+	// replies and the logs from the Logger.
+	RequestIDStateNamespace = "requestId"
+	// RequestIDStateKeyID is the key of the request ID. The value is a string.
+	RequestIDStateKeyID = "id"
+	// RequestIDStateKeyHeader is the key of the response header which carries the request ID.
+	// The value is a string.
+	RequestIDStateKeyHeader = "header"
+)
+
 // FilterState operates the Envoy's filter state
 type FilterState = api.FilterState
 
@@ -32,6 +32,7 @@ var (
 	Continue ResultAction = &isResultAction{typeid: 0}
 	// WaitAllData controls if the request/response body needs to be fully buffered during processing by Go plugin.
 	// If this action is returned, DecodeData/EncodeData will be called by DecodeRequest/EncodeResponse.
+	// The size of the buffered request body can be limited, see plugins.RequestBodyLimiter.
 	WaitAllData ResultAction = &isResultAction{typeid: 1}
 	// LocalResponse controls if a local reply should be returned from Envoy instead of using the
 	// upstream response. See comments below for how to use it.
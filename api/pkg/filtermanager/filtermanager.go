@@ -29,6 +29,8 @@ import (
 
 	xds "github.com/cncf/xds/go/xds/type/v3"
 	capi "github.com/envoyproxy/envoy/contrib/golang/common/go/api"
+	"github.com/go-logr/logr"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/protobuf/types/known/anypb"
 
 	"mosn.io/htnn/api/internal/consumer"
@@ -37,6 +39,7 @@ import (
 	"mosn.io/htnn/api/internal/reflectx"
 	"mosn.io/htnn/api/pkg/filtermanager/api"
 	"mosn.io/htnn/api/pkg/filtermanager/model"
+	"mosn.io/htnn/api/pkg/log"
 	pkgPlugins "mosn.io/htnn/api/pkg/plugins"
 )
 
@@ -69,6 +72,11 @@ type filterManagerConfig struct {
 	namespace string
 
 	enableDebugMode bool
+	pluginTracer    pkgPlugins.PluginTracer
+
+	// the default request body limit for plugins which process the whole body
+	maxRequestBytes     uint32
+	allowPartialMessage bool
 }
 
 func initFilterManagerConfig(namespace string) *filterManagerConfig {
@@ -115,6 +123,18 @@ func (conf *filterManagerConfig) Merge(another *filterManagerConfig) *filterMana
 		cp.enableDebugMode = true
 	}
 
+	cp.pluginTracer = conf.pluginTracer
+	if cp.pluginTracer == nil {
+		cp.pluginTracer = another.pluginTracer
+	}
+
+	cp.maxRequestBytes = conf.maxRequestBytes
+	cp.allowPartialMessage = conf.allowPartialMessage
+	if cp.maxRequestBytes == 0 {
+		cp.maxRequestBytes = another.maxRequestBytes
+		cp.allowPartialMessage = another.allowPartialMessage
+	}
+
 	cp.parsed = make([]*model.ParsedFilterConfig, 0, len(conf.parsed)+len(another.parsed))
 	// For now, we don't deepcopy the config. The config may contain connection to the external
 	// service, for example, a Redis cluster. Not sure if it is safe to deepcopy them. So far,
@@ -260,6 +280,20 @@ func (p *FilterManagerConfigParser) Parse(any *anypb.Any, callbacks capi.ConfigC
 					// executing this plugin.
 					conf.enableDebugMode = true
 				}
+
+				if name == "pluginTracing" {
+					// Like debugMode, this plugin wraps the other plugins to trace their execution.
+					if tracer, ok := config.(pkgPlugins.PluginTracer); ok {
+						conf.pluginTracer = tracer
+					}
+				}
+
+				if name == "bufferLimit" {
+					// Like debugMode, this plugin changes the behavior of the filtermanager.
+					if limiter, ok := config.(pkgPlugins.RequestBodyLimiter); ok {
+						conf.maxRequestBytes, conf.allowPartialMessage = limiter.RequestBodyLimit()
+					}
+				}
 			}
 			i++
 
@@ -292,18 +326,24 @@ type filterManager struct {
 	decodeRequestNeeded bool
 	decodeIdx           int
 	reqHdr              api.RequestHeaderMap
+	// the body chunks kept in Envoy when the request body is limited
+	reqBodyChunks  []capi.BufferInstance
+	reqBodyLen     int
+	reqBodyLimit   int
+	reqBodyPartial bool
 
 	encodeResponseNeeded bool
 	encodeIdx            int
 	rspHdr               api.ResponseHeaderMap
 
 	// use a group of bools instead of map to avoid lookup
-	canSkipDecodeHeaders bool
-	canSkipDecodeData    bool
-	canSkipEncodeHeaders bool
-	canSkipEncodeData    bool
-	canSkipOnLog         bool
-	canSkipMethod        map[string]bool
+	canSkipDecodeHeaders  bool
+	canSkipDecodeData     bool
+	canSkipEncodeHeaders  bool
+	canSkipEncodeData     bool
+	canSkipEncodeTrailers bool
+	canSkipOnLog          bool
+	canSkipMethod         map[string]bool
 
 	callbacks *filterManagerCallbackHandler
 	config    *filterManagerConfig
@@ -317,6 +357,10 @@ func (m *filterManager) Reset() {
 	m.decodeRequestNeeded = false
 	m.decodeIdx = -1
 	m.reqHdr = nil
+	m.reqBodyChunks = nil
+	m.reqBodyLen = 0
+	m.reqBodyLimit = 0
+	m.reqBodyPartial = false
 
 	m.encodeResponseNeeded = false
 	m.encodeIdx = -1
@@ -326,6 +370,7 @@ func (m *filterManager) Reset() {
 	m.canSkipDecodeData = false
 	m.canSkipEncodeHeaders = false
 	m.canSkipEncodeData = false
+	m.canSkipEncodeTrailers = false
 	m.canSkipOnLog = false
 
 	m.callbacks.Reset()
@@ -335,6 +380,13 @@ func (m *filterManager) DebugModeEnabled() bool {
 	return m.config.enableDebugMode
 }
 
+func (m *filterManager) tracer() trace.Tracer {
+	if m.config.pluginTracer == nil {
+		return nil
+	}
+	return m.config.pluginTracer.Tracer()
+}
+
 type filterManagerRequestHeaderMap struct {
 	capi.RequestHeaderMap
 
@@ -434,6 +486,7 @@ type filterManagerCallbackHandler struct {
 	pluginState api.PluginState
 
 	streamInfo *filterManagerStreamInfo
+	logValues  []any
 }
 
 func (cb *filterManagerCallbackHandler) Reset() {
@@ -441,7 +494,9 @@ func (cb *filterManagerCallbackHandler) Reset() {
 	// We don't reset namespace, as filterManager will only be reused in the same route,
 	// which must have the same namespace.
 	cb.consumer = nil
+	cb.pluginState = nil
 	cb.streamInfo = nil
+	cb.logValues = nil
 }
 
 func (cb *filterManagerCallbackHandler) StreamInfo() api.StreamInfo {
@@ -477,6 +532,59 @@ func (cb *filterManagerCallbackHandler) PluginState() api.PluginState {
 	return cb.pluginState
 }
 
+// requestID returns the request ID set by the requestId plugin
+func (cb *filterManagerCallbackHandler) requestID() string {
+	if cb.pluginState == nil {
+		return ""
+	}
+	id, _ := cb.pluginState.Get(api.RequestIDStateNamespace, api.RequestIDStateKeyID).(string)
+	return id
+}
+
+func (cb *filterManagerCallbackHandler) requestLogValues() []any {
+	if cb.logValues == nil {
+		requestID := cb.requestID()
+		if requestID == "" {
+			// The request ID is generated by Envoy and set to the `x-request-id` header
+			requestID, _ = cb.GetProperty("request.id")
+		}
+		cb.logValues = []any{
+			"namespace", cb.namespace,
+			"route", cb.StreamInfo().GetRouteName(),
+			"request_id", requestID,
+		}
+	}
+	return cb.logValues
+}
+
+func (cb *filterManagerCallbackHandler) Logger() logr.Logger {
+	return log.NewPluginLogger("", cb.requestLogValues()...)
+}
+
+// pluginCallbackHandler is the FilterCallbackHandler given to each plugin, so that the
+// plugin name can be added to its logger.
+type pluginCallbackHandler struct {
+	*filterManagerCallbackHandler
+
+	name   string
+	logger *logr.Logger
+}
+
+func newPluginCallbackHandler(name string, cb *filterManagerCallbackHandler) *pluginCallbackHandler {
+	return &pluginCallbackHandler{
+		filterManagerCallbackHandler: cb,
+		name:                         name,
+	}
+}
+
+func (cb *pluginCallbackHandler) Logger() logr.Logger {
+	if cb.logger == nil {
+		logger := log.NewPluginLogger(cb.name, cb.requestLogValues()...)
+		cb.logger = &logger
+	}
+	return *cb.logger
+}
+
 type phase int
 
 const (
@@ -498,6 +606,7 @@ func newSkipMethodsMap() map[string]bool {
 		"EncodeHeaders":  true,
 		"EncodeData":     true,
 		"EncodeResponse": true,
+		"EncodeTrailers": true,
 		"OnLog":          true,
 	}
 }
@@ -529,10 +638,11 @@ func FilterManagerFactory(c interface{}) capi.StreamFilterFactory {
 
 		filters := make([]*model.FilterWrapper, len(parsedConfig))
 		logExecution := needLogExecution()
+		tracer := fm.tracer()
 		for i, fc := range parsedConfig {
 			factory := fc.Factory
 			config := fc.ParsedConfig
-			f := factory(config, fm.callbacks)
+			f := factory(config, newPluginCallbackHandler(fc.Name, fm.callbacks))
 			// Technically, the factory might create different f for different calls. We don't support this edge case for now.
 			if fm.canSkipMethod == nil {
 				definedMethod := make(map[string]bool, len(canSkipMethod))
@@ -567,6 +677,10 @@ func FilterManagerFactory(c interface{}) capi.StreamFilterFactory {
 				}
 			}
 
+			if tracer != nil {
+				f = NewTracingFilter(fc.Name, f, fm.callbacks, tracer)
+			}
+
 			if logExecution {
 				filters[i] = model.NewFilterWrapper(fc.Name, NewLogExecutionFilter(fc.Name, f, fm.callbacks))
 			} else {
@@ -576,6 +690,8 @@ func FilterManagerFactory(c interface{}) capi.StreamFilterFactory {
 			if fm.DebugModeEnabled() {
 				filters[i] = model.NewFilterWrapper(fc.Name, NewDebugFilter(fc.Name, filters[i].Filter, fm.callbacks))
 			}
+
+			setRequestBodyLimit(filters[i], config)
 		}
 
 		if fm.canSkipMethod == nil {
@@ -591,13 +707,14 @@ func FilterManagerFactory(c interface{}) capi.StreamFilterFactory {
 		fm.canSkipDecodeData = fm.canSkipMethod["DecodeData"] && fm.canSkipMethod["DecodeRequest"]
 		fm.canSkipEncodeHeaders = fm.canSkipMethod["EncodeHeaders"]
 		fm.canSkipEncodeData = fm.canSkipMethod["EncodeData"] && fm.canSkipMethod["EncodeResponse"]
+		fm.canSkipEncodeTrailers = fm.canSkipMethod["EncodeTrailers"]
 		fm.canSkipOnLog = fm.canSkipMethod["OnLog"]
 
 		return fm
 	}
 }
 
-func (m *filterManager) handleAction(res api.ResultAction, phase phase) (needReturn bool) {
+func (m *filterManager) handleAction(res api.ResultAction, phase phase, f *model.FilterWrapper) (needReturn bool) {
 	if res == api.Continue {
 		return false
 	}
@@ -614,7 +731,7 @@ func (m *filterManager) handleAction(res api.ResultAction, phase phase) (needRet
 
 	switch v := res.(type) {
 	case *api.LocalResponse:
-		m.localReply(v)
+		m.localReply(v, f.Name)
 		return true
 	default:
 		api.LogErrorf("unknown result action: %+v", v)
@@ -626,7 +743,23 @@ type jsonReply struct {
 	Msg string `json:"msg"`
 }
 
-func (m *filterManager) localReply(v *api.LocalResponse) {
+// publishLocalReply records which plugin sends the local reply and why in the dynamic metadata
+func (m *filterManager) publishLocalReply(v *api.LocalResponse, plugin string) {
+	reply := map[string]interface{}{
+		"code": v.Code,
+	}
+	if plugin != "" {
+		reply["plugin"] = plugin
+	}
+	if v.Msg != "" {
+		reply["msg"] = v.Msg
+	}
+	m.callbacks.StreamInfo().DynamicMetadata().Set(api.DynamicMetadataNamespace, "local_reply", reply)
+}
+
+// localReply sends the local reply. The plugin is the name of the plugin which causes the reply,
+// and can be empty if the reply is not caused by a plugin.
+func (m *filterManager) localReply(v *api.LocalResponse, plugin string) {
 	var hdr map[string][]string
 	if v.Header != nil {
 		hdr = map[string][]string(v.Header)
@@ -634,6 +767,17 @@ func (m *filterManager) localReply(v *api.LocalResponse) {
 	if v.Code == 0 {
 		v.Code = 200
 	}
+	m.publishLocalReply(v, plugin)
+
+	if id := m.callbacks.requestID(); id != "" {
+		name, _ := m.callbacks.pluginState.Get(api.RequestIDStateNamespace, api.RequestIDStateKeyHeader).(string)
+		if name != "" && len(v.Header.Values(name)) == 0 {
+			if hdr == nil {
+				hdr = map[string][]string{}
+			}
+			hdr[name] = []string{id}
+		}
+	}
 
 	msg := v.Msg
 	// TODO: we can also add custom template response
@@ -697,7 +841,7 @@ func (m *filterManager) DecodeHeaders(headers capi.RequestHeaderMap, endStream b
 			api.LogErrorf("error in plugin %s: %s", m.config.initFailedPluginName, m.config.initFailure)
 			m.localReply(&api.LocalResponse{
 				Code: 500,
-			})
+			}, m.config.initFailedPluginName)
 			return
 		}
 
@@ -705,12 +849,15 @@ func (m *filterManager) DecodeHeaders(headers capi.RequestHeaderMap, endStream b
 			RequestHeaderMap: headers,
 		}
 		m.reqHdr = headers
+		if m.DebugModeEnabled() {
+			m.recordPlugins()
+		}
 		if m.config.consumerFiltersEndAt != 0 {
 			for i := 0; i < m.config.consumerFiltersEndAt; i++ {
 				f := m.filters[i]
 				// We don't support DecodeRequest for now
 				res = f.DecodeHeaders(headers, endStream)
-				if m.handleAction(res, phaseDecodeHeaders) {
+				if m.handleAction(res, phaseDecodeHeaders, f) {
 					return
 				}
 			}
@@ -723,9 +870,10 @@ func (m *filterManager) DecodeHeaders(headers capi.RequestHeaderMap, endStream b
 				m.localReply(&api.LocalResponse{
 					Code: 401,
 					Msg:  "consumer not found",
-				})
+				}, "")
 				return
 			}
+			m.callbacks.StreamInfo().DynamicMetadata().Set(api.DynamicMetadataNamespace, "consumer", c.Name())
 
 			if len(c.FilterConfigs) > 0 {
 				api.LogDebugf("merge filters from consumer: %s", c.Name())
@@ -753,8 +901,9 @@ func (m *filterManager) DecodeHeaders(headers capi.RequestHeaderMap, endStream b
 					fc := c.FilterConfigs[name]
 					factory := fc.Factory
 					config := fc.ParsedConfig
-					f := factory(config, m.callbacks)
+					f := factory(config, newPluginCallbackHandler(name, m.callbacks))
 					filterWrappers[i] = model.NewFilterWrapper(name, f)
+					setRequestBodyLimit(filterWrappers[i], config)
 				}
 
 				c.CanSkipMethodOnce.Do(func() {
@@ -773,6 +922,13 @@ func (m *filterManager) DecodeHeaders(headers capi.RequestHeaderMap, endStream b
 					c.CanSkipMethod = canSkipMethod
 				})
 
+				if tracer := m.tracer(); tracer != nil {
+					for _, fw := range filterWrappers {
+						f := fw.Filter
+						fw.Filter = NewTracingFilter(fw.Name, f, m.callbacks, tracer)
+					}
+				}
+
 				if needLogExecution() {
 					for _, fw := range filterWrappers {
 						f := fw.Filter
@@ -791,6 +947,7 @@ func (m *filterManager) DecodeHeaders(headers capi.RequestHeaderMap, endStream b
 				m.canSkipDecodeData = m.canSkipDecodeData && canSkipMethod["DecodeData"] && canSkipMethod["DecodeRequest"]
 				m.canSkipEncodeHeaders = m.canSkipEncodeData && canSkipMethod["EncodeHeaders"]
 				m.canSkipEncodeData = m.canSkipEncodeData && canSkipMethod["EncodeData"] && canSkipMethod["EncodeResponse"]
+				m.canSkipEncodeTrailers = m.canSkipEncodeTrailers && canSkipMethod["EncodeTrailers"]
 				m.canSkipOnLog = m.canSkipOnLog && canSkipMethod["OnLog"]
 
 				// TODO: add field to control if merging is allowed
@@ -805,6 +962,9 @@ func (m *filterManager) DecodeHeaders(headers capi.RequestHeaderMap, endStream b
 				sort.Slice(m.filters, func(i, j int) bool {
 					return pkgPlugins.ComparePluginOrder(m.filters[i].Name, m.filters[j].Name)
 				})
+				if m.DebugModeEnabled() {
+					m.recordPlugins()
+				}
 
 				if api.GetLogLevel() <= api.LogLevelDebug {
 					for _, f := range m.filters {
@@ -827,14 +987,27 @@ func (m *filterManager) DecodeHeaders(headers capi.RequestHeaderMap, endStream b
 		for i := m.config.consumerFiltersEndAt; i < len(m.filters); i++ {
 			f := m.filters[i]
 			res = f.DecodeHeaders(headers, endStream)
-			if m.handleAction(res, phaseDecodeHeaders) {
+			if m.handleAction(res, phaseDecodeHeaders, f) {
 				return
 			}
 
 			if m.decodeRequestNeeded {
 				m.decodeRequestNeeded = false
 				if !endStream {
+					if m.exceedRequestBodyLimit(f, headers) {
+						m.localReply(&api.LocalResponse{Code: 413}, f.Name)
+						return
+					}
+
 					m.decodeIdx = i
+					if maxBytes, allowPartial := m.requestBodyLimit(f); maxBytes != 0 {
+						// Let Envoy pass each chunk to us, so that we can stop buffering once
+						// the body exceeds the limit, even if there is no Content-Length.
+						m.reqBodyLimit = int(maxBytes)
+						m.reqBodyPartial = allowPartial
+						m.callbacks.Continue(capi.StopAndBufferWatermark)
+						return
+					}
 					// some filters, like authorization with request body, need to
 					// have a whole body before passing to the next filter
 					m.callbacks.Continue(capi.StopAndBuffer)
@@ -843,7 +1016,7 @@ func (m *filterManager) DecodeHeaders(headers capi.RequestHeaderMap, endStream b
 
 				// no body
 				res = f.DecodeRequest(headers, nil, nil)
-				if m.handleAction(res, phaseDecodeRequest) {
+				if m.handleAction(res, phaseDecodeRequest, f) {
 					return
 				}
 			}
@@ -855,6 +1028,114 @@ func (m *filterManager) DecodeHeaders(headers capi.RequestHeaderMap, endStream b
 	return capi.Running
 }
 
+// recordPlugins stores the names of the plugins run in this request, including the ones from
+// consumer, for the debugMode plugin. This is a private API and we don't guarantee its stability.
+func (m *filterManager) recordPlugins() {
+	names := make([]string, len(m.filters))
+	for i, f := range m.filters {
+		names[i] = f.Name
+	}
+	m.callbacks.PluginState().Set("debugMode", "plugins", names)
+}
+
+func setRequestBodyLimit(fw *model.FilterWrapper, config interface{}) {
+	if limiter, ok := config.(pkgPlugins.RequestBodyLimiter); ok {
+		fw.MaxRequestBytes, fw.AllowPartialMessage = limiter.RequestBodyLimit()
+	}
+}
+
+func (m *filterManager) requestBodyLimit(f *model.FilterWrapper) (uint32, bool) {
+	if f.MaxRequestBytes != 0 {
+		return f.MaxRequestBytes, f.AllowPartialMessage
+	}
+	return m.config.maxRequestBytes, m.config.allowPartialMessage
+}
+
+// exceedRequestBodyLimit checks the Content-Length before buffering the body, so that we can
+// reject the oversized request as early as possible.
+func (m *filterManager) exceedRequestBodyLimit(f *model.FilterWrapper, headers api.RequestHeaderMap) bool {
+	maxBytes, allowPartial := m.requestBodyLimit(f)
+	if maxBytes == 0 || allowPartial {
+		return false
+	}
+	cl, ok := headers.Get("content-length")
+	if !ok {
+		return false
+	}
+	n, err := strconv.ParseUint(cl, 10, 64)
+	if err != nil || n <= uint64(maxBytes) {
+		return false
+	}
+	api.LogInfof("request body exceeds the limit of plugin %s: %d > %d", f.Name, n, maxBytes)
+	return true
+}
+
+// partialBuffer only exposes the first part of the body to the reader. The write operations
+// still apply to the whole body.
+type partialBuffer struct {
+	capi.BufferInstance
+
+	size int
+}
+
+func (b *partialBuffer) Bytes() []byte {
+	return b.BufferInstance.Bytes()[:b.size]
+}
+
+func (b *partialBuffer) Len() int {
+	return b.size
+}
+
+func (b *partialBuffer) String() string {
+	return string(b.Bytes())
+}
+
+// bufferLimitedRequestBody counts the request body chunk by chunk. When the body exceeds the limit,
+// it replies 413, or stops buffering in partial mode. It returns true when the buffered body is
+// moved into the buf and ready to be processed.
+func (m *filterManager) bufferLimitedRequestBody(buf capi.BufferInstance, endStream bool) bool {
+	m.reqBodyLen += buf.Len()
+	if m.reqBodyLen > m.reqBodyLimit && !m.reqBodyPartial {
+		f := m.filters[m.decodeIdx]
+		api.LogInfof("request body exceeds the limit of plugin %s: %d > %d", f.Name, m.reqBodyLen, m.reqBodyLimit)
+		m.localReply(&api.LocalResponse{Code: 413}, f.Name)
+		return false
+	}
+
+	if !endStream && m.reqBodyLen < m.reqBodyLimit {
+		// Envoy keeps the chunk until we continue
+		m.reqBodyChunks = append(m.reqBodyChunks, buf)
+		m.callbacks.Continue(capi.StopAndBufferWatermark)
+		return false
+	}
+
+	if len(m.reqBodyChunks) > 0 {
+		body := make([]byte, 0, m.reqBodyLen-buf.Len())
+		for _, chunk := range m.reqBodyChunks {
+			body = append(body, chunk.Bytes()...)
+			chunk.Reset()
+		}
+		buf.Prepend(body)
+		m.reqBodyChunks = nil
+	}
+	return true
+}
+
+func (m *filterManager) decodeRequest(f *model.FilterWrapper, buf capi.BufferInstance) api.ResultAction {
+	maxBytes, allowPartial := m.requestBodyLimit(f)
+	if maxBytes != 0 && buf.Len() > int(maxBytes) {
+		if !allowPartial {
+			api.LogInfof("request body exceeds the limit of plugin %s: %d > %d", f.Name, buf.Len(), maxBytes)
+			return &api.LocalResponse{Code: 413}
+		}
+		buf = &partialBuffer{
+			BufferInstance: buf,
+			size:           int(maxBytes),
+		}
+	}
+	return f.DecodeRequest(m.reqHdr, buf, nil)
+}
+
 func (m *filterManager) DecodeData(buf capi.BufferInstance, endStream bool) capi.StatusType {
 	if m.canSkipDecodeData {
 		return capi.Continue
@@ -883,7 +1164,7 @@ func (m *filterManager) DecodeData(buf capi.BufferInstance, endStream bool) capi
 			for i := 0; i < n; i++ {
 				f := m.filters[i]
 				res = f.DecodeData(buf, endStream)
-				if m.handleAction(res, phaseDecodeData) {
+				if m.handleAction(res, phaseDecodeData, f) {
 					return
 				}
 			}
@@ -893,14 +1174,18 @@ func (m *filterManager) DecodeData(buf capi.BufferInstance, endStream bool) capi
 			for i := 0; i < m.decodeIdx; i++ {
 				f := m.filters[i]
 				res = f.DecodeData(buf, endStream)
-				if m.handleAction(res, phaseDecodeData) {
+				if m.handleAction(res, phaseDecodeData, f) {
 					return
 				}
 			}
 
+			if m.reqBodyLimit != 0 && !m.bufferLimitedRequestBody(buf, endStream) {
+				return
+			}
+
 			f := m.filters[m.decodeIdx]
-			res = f.DecodeRequest(m.reqHdr, buf, nil)
-			if m.handleAction(res, phaseDecodeRequest) {
+			res = m.decodeRequest(f, buf)
+			if m.handleAction(res, phaseDecodeRequest, f) {
 				return
 			}
 
@@ -911,7 +1196,7 @@ func (m *filterManager) DecodeData(buf capi.BufferInstance, endStream bool) capi
 					// The endStream in DecodeHeaders indicates whether there is a body.
 					// The body always exists when we hit this path.
 					res = f.DecodeHeaders(m.reqHdr, false)
-					if m.handleAction(res, phaseDecodeHeaders) {
+					if m.handleAction(res, phaseDecodeHeaders, f) {
 						return
 					}
 					if m.decodeRequestNeeded {
@@ -925,7 +1210,7 @@ func (m *filterManager) DecodeData(buf capi.BufferInstance, endStream bool) capi
 				for j := m.decodeIdx + 1; j < i; j++ {
 					f := m.filters[j]
 					res = f.DecodeData(buf, endStream)
-					if m.handleAction(res, phaseDecodeData) {
+					if m.handleAction(res, phaseDecodeData, f) {
 						return
 					}
 				}
@@ -934,14 +1219,19 @@ func (m *filterManager) DecodeData(buf capi.BufferInstance, endStream bool) capi
 					m.decodeRequestNeeded = false
 					m.decodeIdx = i
 					f := m.filters[m.decodeIdx]
-					res = f.DecodeRequest(m.reqHdr, buf, nil)
-					if m.handleAction(res, phaseDecodeRequest) {
+					res = m.decodeRequest(f, buf)
+					if m.handleAction(res, phaseDecodeRequest, f) {
 						return
 					}
 					i++
 				}
 			}
 
+			if !endStream {
+				// The partial body is processed, let the rest of the body stream through the filters.
+				m.decodeIdx = -1
+				m.reqBodyLimit = 0
+			}
 			m.callbacks.Continue(capi.Continue)
 		}
 	}()
@@ -970,7 +1260,7 @@ func (m *filterManager) EncodeHeaders(headers capi.ResponseHeaderMap, endStream
 		for i := n - 1; i >= 0; i-- {
 			f := m.filters[i]
 			res = f.EncodeHeaders(headers, endStream)
-			if m.handleAction(res, phaseEncodeHeaders) {
+			if m.handleAction(res, phaseEncodeHeaders, f) {
 				return
 			}
 
@@ -984,7 +1274,7 @@ func (m *filterManager) EncodeHeaders(headers capi.ResponseHeaderMap, endStream
 
 				// no body
 				res = f.EncodeResponse(headers, nil, nil)
-				if m.handleAction(res, phaseEncodeResponse) {
+				if m.handleAction(res, phaseEncodeResponse, f) {
 					return
 				}
 			}
@@ -1011,7 +1301,7 @@ func (m *filterManager) EncodeData(buf capi.BufferInstance, endStream bool) capi
 			for i := n - 1; i >= 0; i-- {
 				f := m.filters[i]
 				res = f.EncodeData(buf, endStream)
-				if m.handleAction(res, phaseEncodeData) {
+				if m.handleAction(res, phaseEncodeData, f) {
 					return
 				}
 			}
@@ -1021,14 +1311,14 @@ func (m *filterManager) EncodeData(buf capi.BufferInstance, endStream bool) capi
 			for i := n - 1; i > m.encodeIdx; i-- {
 				f := m.filters[i]
 				res = f.EncodeData(buf, endStream)
-				if m.handleAction(res, phaseEncodeData) {
+				if m.handleAction(res, phaseEncodeData, f) {
 					return
 				}
 			}
 
 			f := m.filters[m.encodeIdx]
 			res = f.EncodeResponse(m.rspHdr, buf, nil)
-			if m.handleAction(res, phaseEncodeResponse) {
+			if m.handleAction(res, phaseEncodeResponse, f) {
 				return
 			}
 
@@ -1037,7 +1327,7 @@ func (m *filterManager) EncodeData(buf capi.BufferInstance, endStream bool) capi
 				for ; i >= 0; i-- {
 					f := m.filters[i]
 					res = f.EncodeHeaders(m.rspHdr, false)
-					if m.handleAction(res, phaseEncodeHeaders) {
+					if m.handleAction(res, phaseEncodeHeaders, f) {
 						return
 					}
 					if m.encodeResponseNeeded {
@@ -1049,7 +1339,7 @@ func (m *filterManager) EncodeData(buf capi.BufferInstance, endStream bool) capi
 				for j := m.encodeIdx - 1; j > i; j-- {
 					f := m.filters[j]
 					res = f.EncodeData(buf, endStream)
-					if m.handleAction(res, phaseEncodeData) {
+					if m.handleAction(res, phaseEncodeData, f) {
 						return
 					}
 				}
@@ -1059,7 +1349,7 @@ func (m *filterManager) EncodeData(buf capi.BufferInstance, endStream bool) capi
 					m.encodeIdx = i
 					f := m.filters[m.encodeIdx]
 					res = f.EncodeResponse(m.rspHdr, buf, nil)
-					if m.handleAction(res, phaseEncodeResponse) {
+					if m.handleAction(res, phaseEncodeResponse, f) {
 						return
 					}
 					i--
@@ -1073,7 +1363,32 @@ func (m *filterManager) EncodeData(buf capi.BufferInstance, endStream bool) capi
 	return capi.Running
 }
 
-// TODO: handle trailers
+func (m *filterManager) EncodeTrailers(trailers capi.ResponseTrailerMap) capi.StatusType {
+	if m.canSkipEncodeTrailers {
+		return capi.Continue
+	}
+
+	go func() {
+		defer m.callbacks.RecoverPanic()
+		var res api.ResultAction
+
+		// The buffered body, if any, is already processed in EncodeData
+		n := len(m.filters)
+		for i := n - 1; i >= 0; i-- {
+			f := m.filters[i]
+			res = f.EncodeTrailers(trailers)
+			if m.handleAction(res, phaseEncodeTrailers, f) {
+				return
+			}
+		}
+
+		m.callbacks.Continue(capi.Continue)
+	}()
+
+	return capi.Running
+}
+
+// TODO: handle request trailers
 
 func (m *filterManager) OnLog() {
 	if m.canSkipOnLog {
@@ -15,16 +15,25 @@
 package filtermanager
 
 import (
+	"bytes"
+	"context"
 	"errors"
 	"fmt"
+	"log"
 	"net/http"
+	"os"
 	"strconv"
 	"sync"
 	"testing"
 
 	"github.com/agiledragon/gomonkey/v2"
+	capi "github.com/envoyproxy/envoy/contrib/golang/common/go/api"
 	xds "github.com/cncf/xds/go/xds/type/v3"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/protobuf/types/known/anypb"
 	"google.golang.org/protobuf/types/known/structpb"
 
@@ -527,6 +536,115 @@ func TestFiltersFromConsumer(t *testing.T) {
 	wg.Wait()
 }
 
+func TestDebugModeRecordPlugins(t *testing.T) {
+	config := initFilterManagerConfig("ns")
+	config.consumerFiltersEndAt = 1
+	config.enableDebugMode = true
+
+	consumers := map[string]*internalConsumer.Consumer{
+		"0": {
+			FilterConfigs: map[string]*model.ParsedFilterConfig{
+				"3_on_log": {
+					Name:    "3_on_log",
+					Factory: onLogFactory,
+				},
+			},
+		},
+	}
+	config.parsed = []*model.ParsedFilterConfig{
+		{
+			Name:    "1_set_consumer",
+			Factory: setConsumerFactory,
+			ParsedConfig: setConsumerConf{
+				Consumers: consumers,
+			},
+		},
+		{
+			Name:    "2_add_req",
+			Factory: addReqFactory,
+			ParsedConfig: addReqConf{
+				hdrName: "x-htnn-route",
+			},
+		},
+	}
+
+	cb := envoy.NewCAPIFilterCallbackHandler()
+	m := FilterManagerFactory(config)(cb).(*filterManager)
+	h := http.Header{}
+	h.Add("consumer", "0")
+	m.DecodeHeaders(envoy.NewRequestHeaderMap(h), true)
+	cb.WaitContinued()
+
+	plugins := m.callbacks.PluginState().Get("debugMode", "plugins")
+	assert.Equal(t, []string{"1_set_consumer", "2_add_req", "3_on_log"}, plugins)
+}
+
+func TestPublishDynamicMetadata(t *testing.T) {
+	cb := envoy.NewCAPIFilterCallbackHandler()
+	config := initFilterManagerConfig("ns")
+	config.parsed = []*model.ParsedFilterConfig{
+		{
+			Name:    "test",
+			Factory: PassThroughFactory,
+		},
+	}
+	m := FilterManagerFactory(config)(cb).(*filterManager)
+	patches := gomonkey.ApplyMethodReturn(m.filters[0].Filter, "DecodeHeaders", &api.LocalResponse{
+		Code: 403,
+		Msg:  "denied",
+	})
+	defer patches.Reset()
+
+	m.DecodeHeaders(envoy.NewRequestHeaderMap(http.Header{}), true)
+	cb.WaitContinued()
+	md := cb.StreamInfo().DynamicMetadata().Get(api.DynamicMetadataNamespace)
+	assert.Equal(t, map[string]interface{}{
+		"code":   403,
+		"plugin": "test",
+		"msg":    "denied",
+	}, md["local_reply"])
+	patches.Reset()
+
+	// consumer
+	cb = envoy.NewCAPIFilterCallbackHandler()
+	config = initFilterManagerConfig("ns")
+	config.consumerFiltersEndAt = 1
+	c := &internalConsumer.Consumer{}
+	config.parsed = []*model.ParsedFilterConfig{
+		{
+			Name:    "set_consumer",
+			Factory: setConsumerFactory,
+			ParsedConfig: setConsumerConf{
+				Consumers: map[string]*internalConsumer.Consumer{
+					"rick": c,
+				},
+			},
+		},
+	}
+	patches = gomonkey.ApplyMethodReturn(c, "Name", "rick")
+	defer patches.Reset()
+	m = FilterManagerFactory(config)(cb).(*filterManager)
+	h := http.Header{}
+	h.Add("consumer", "rick")
+	m.DecodeHeaders(envoy.NewRequestHeaderMap(h), true)
+	cb.WaitContinued()
+	md = cb.StreamInfo().DynamicMetadata().Get(api.DynamicMetadataNamespace)
+	assert.Equal(t, "rick", md["consumer"])
+	assert.Nil(t, md["local_reply"])
+
+	// consumer not found
+	cb = envoy.NewCAPIFilterCallbackHandler()
+	config.parsed[0].Factory = PassThroughFactory
+	m = FilterManagerFactory(config)(cb).(*filterManager)
+	m.DecodeHeaders(envoy.NewRequestHeaderMap(http.Header{}), true)
+	cb.WaitContinued()
+	md = cb.StreamInfo().DynamicMetadata().Get(api.DynamicMetadataNamespace)
+	assert.Equal(t, map[string]interface{}{
+		"code": 401,
+		"msg":  "consumer not found",
+	}, md["local_reply"])
+}
+
 func setPluginStateFilterFactory(c interface{}, callbacks api.FilterCallbackHandler) api.Filter {
 	return &setPluginStateFilter{
 		callbacks: callbacks,
@@ -582,6 +700,99 @@ func TestPluginState(t *testing.T) {
 	assert.Equal(t, "value", v)
 }
 
+type logFilter struct {
+	api.PassThroughFilter
+	callbacks api.FilterCallbackHandler
+}
+
+func (f *logFilter) DecodeHeaders(headers api.RequestHeaderMap, endStream bool) api.ResultAction {
+	f.callbacks.Logger().Info("run", "method", "DecodeHeaders")
+	return api.Continue
+}
+
+func TestPluginLogger(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	cb := envoy.NewCAPIFilterCallbackHandler()
+	patches := gomonkey.ApplyMethodReturn(cb, "GetProperty", "2e3a4c1b", nil)
+	defer patches.Reset()
+
+	config := initFilterManagerConfig("ns")
+	config.parsed = []*model.ParsedFilterConfig{
+		{
+			Name: "alice",
+			Factory: func(c interface{}, callbacks api.FilterCallbackHandler) api.Filter {
+				return &logFilter{callbacks: callbacks}
+			},
+		},
+	}
+	m := FilterManagerFactory(config)(cb).(*filterManager)
+	m.DecodeHeaders(envoy.NewRequestHeaderMap(http.Header{}), true)
+	cb.WaitContinued()
+
+	assert.Contains(t, buf.String(),
+		`"msg":"run","plugin":"alice","namespace":"ns","route":"","request_id":"2e3a4c1b","level":"info","method":"DecodeHeaders"`)
+}
+
+type setRequestIDFilter struct {
+	api.PassThroughFilter
+	callbacks api.FilterCallbackHandler
+}
+
+func (f *setRequestIDFilter) DecodeHeaders(headers api.RequestHeaderMap, endStream bool) api.ResultAction {
+	state := f.callbacks.PluginState()
+	state.Set(api.RequestIDStateNamespace, api.RequestIDStateKeyID, "01J2Z3T7XKQ8Y4N5M6P7R8S9T0")
+	state.Set(api.RequestIDStateNamespace, api.RequestIDStateKeyHeader, "x-request-id")
+	return api.Continue
+}
+
+type rejectFilter struct {
+	api.PassThroughFilter
+	callbacks api.FilterCallbackHandler
+}
+
+func (f *rejectFilter) DecodeHeaders(headers api.RequestHeaderMap, endStream bool) api.ResultAction {
+	f.callbacks.Logger().Info("reject")
+	return &api.LocalResponse{Code: 403}
+}
+
+func TestRequestID(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	cb := envoy.NewCAPIFilterCallbackHandler()
+	config := initFilterManagerConfig("ns")
+	config.parsed = []*model.ParsedFilterConfig{
+		{
+			Name: "requestId",
+			Factory: func(c interface{}, callbacks api.FilterCallbackHandler) api.Filter {
+				return &setRequestIDFilter{callbacks: callbacks}
+			},
+		},
+		{
+			Name: "alice",
+			Factory: func(c interface{}, callbacks api.FilterCallbackHandler) api.Filter {
+				return &rejectFilter{callbacks: callbacks}
+			},
+		},
+	}
+	m := FilterManagerFactory(config)(cb).(*filterManager)
+	m.DecodeHeaders(envoy.NewRequestHeaderMap(http.Header{}), true)
+	cb.WaitContinued()
+
+	lr := cb.LocalResponse()
+	assert.Equal(t, 403, lr.Code)
+	assert.Equal(t, []string{"01J2Z3T7XKQ8Y4N5M6P7R8S9T0"}, lr.Headers["x-request-id"])
+	assert.Contains(t, buf.String(), `"request_id":"01J2Z3T7XKQ8Y4N5M6P7R8S9T0"`)
+
+	// the state is not leaked to the next request which reuses the filter manager
+	m.Reset()
+	assert.Nil(t, m.callbacks.pluginState)
+}
+
 func TestMergeDebugFlag(t *testing.T) {
 	parent := initFilterManagerConfig("")
 	child := initFilterManagerConfig("")
@@ -595,3 +806,312 @@ func TestMergeDebugFlag(t *testing.T) {
 	merged = parent.Merge(child)
 	assert.Equal(t, true, merged.enableDebugMode)
 }
+
+type tracerConfig struct {
+	tracer trace.Tracer
+}
+
+func (c *tracerConfig) Tracer() trace.Tracer {
+	return c.tracer
+}
+
+func TestMergePluginTracer(t *testing.T) {
+	tracer := &tracerConfig{}
+	parent := initFilterManagerConfig("")
+	child := initFilterManagerConfig("")
+	parent.pluginTracer = tracer
+	merged := parent.Merge(child)
+	assert.Equal(t, tracer, merged.pluginTracer)
+
+	another := &tracerConfig{}
+	child.pluginTracer = another
+	merged = parent.Merge(child)
+	assert.Equal(t, another, merged.pluginTracer)
+}
+
+func TestPluginTracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	defer provider.Shutdown(context.Background())
+
+	cb := envoy.NewCAPIFilterCallbackHandler()
+	config := initFilterManagerConfig("ns")
+	config.pluginTracer = &tracerConfig{tracer: provider.Tracer("htnn")}
+	config.parsed = []*model.ParsedFilterConfig{
+		{
+			Name:    "alice",
+			Factory: setPluginStateFilterFactory,
+		},
+		{
+			Name:    "bob",
+			Factory: getPluginStateFilterFactory,
+		},
+	}
+	m := FilterManagerFactory(config)(cb).(*filterManager)
+	h := http.Header{
+		"Traceparent": []string{"00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01"},
+	}
+	hdr := envoy.NewRequestHeaderMap(h)
+	m.DecodeHeaders(hdr, true)
+	cb.WaitContinued()
+
+	spans := recorder.Ended()
+	require.Equal(t, 2, len(spans))
+	assert.Equal(t, "alice DecodeHeaders", spans[0].Name())
+	assert.Equal(t, "bob DecodeHeaders", spans[1].Name())
+	for _, span := range spans {
+		assert.Equal(t, "0af7651916cd43dd8448eb211c80319c", span.SpanContext().TraceID().String())
+		assert.Equal(t, "b7ad6b7169203331", span.Parent().SpanID().String())
+	}
+}
+
+type bodyLimitConfig struct {
+	maxBytes     uint32
+	allowPartial bool
+}
+
+func (c *bodyLimitConfig) RequestBodyLimit() (uint32, bool) {
+	return c.maxBytes, c.allowPartial
+}
+
+func decodeRequestFactory(c interface{}, callbacks api.FilterCallbackHandler) api.Filter {
+	return &decodeRequestFilter{}
+}
+
+type decodeRequestFilter struct {
+	api.PassThroughFilter
+}
+
+func (f *decodeRequestFilter) DecodeHeaders(headers api.RequestHeaderMap, endStream bool) api.ResultAction {
+	return api.WaitAllData
+}
+
+func (f *decodeRequestFilter) DecodeRequest(headers api.RequestHeaderMap, data api.BufferInstance, trailers api.RequestTrailerMap) api.ResultAction {
+	headers.Set("x-body", data.String())
+	return api.Continue
+}
+
+func TestRequestBodyLimit(t *testing.T) {
+	tests := []struct {
+		name          string
+		pluginLimit   *bodyLimitConfig
+		policyLimit   *bodyLimitConfig
+		contentLength string
+		code          int
+		body          string
+	}{
+		{
+			name: "no limit",
+			body: "0123456789",
+		},
+		{
+			name:        "within the limit",
+			pluginLimit: &bodyLimitConfig{maxBytes: 10},
+			body:        "0123456789",
+		},
+		{
+			name:        "exceed the limit",
+			pluginLimit: &bodyLimitConfig{maxBytes: 4},
+			code:        413,
+		},
+		{
+			name:          "exceed the limit, checked by content-length",
+			pluginLimit:   &bodyLimitConfig{maxBytes: 4},
+			contentLength: "10",
+			code:          413,
+		},
+		{
+			name:        "partial",
+			pluginLimit: &bodyLimitConfig{maxBytes: 4, allowPartial: true},
+			body:        "0123",
+		},
+		{
+			name:        "policy limit",
+			policyLimit: &bodyLimitConfig{maxBytes: 4},
+			code:        413,
+		},
+		{
+			name:        "plugin limit overrides policy limit",
+			pluginLimit: &bodyLimitConfig{maxBytes: 6, allowPartial: true},
+			policyLimit: &bodyLimitConfig{maxBytes: 4},
+			body:        "012345",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cb := envoy.NewCAPIFilterCallbackHandler()
+			config := initFilterManagerConfig("ns")
+			fc := &model.ParsedFilterConfig{
+				Name:    "decodeRequest",
+				Factory: decodeRequestFactory,
+			}
+			if tt.pluginLimit != nil {
+				fc.ParsedConfig = tt.pluginLimit
+			}
+			config.parsed = []*model.ParsedFilterConfig{fc}
+			if tt.policyLimit != nil {
+				config.maxRequestBytes = tt.policyLimit.maxBytes
+				config.allowPartialMessage = tt.policyLimit.allowPartial
+			}
+
+			m := FilterManagerFactory(config)(cb).(*filterManager)
+			h := http.Header{}
+			if tt.contentLength != "" {
+				h.Set("content-length", tt.contentLength)
+			}
+			hdr := envoy.NewRequestHeaderMap(h)
+			m.DecodeHeaders(hdr, false)
+			cb.WaitContinued()
+			if tt.contentLength != "" {
+				assert.Equal(t, tt.code, cb.LocalResponse().Code)
+				return
+			}
+
+			buf := envoy.NewBufferInstance([]byte("0123456789"))
+			m.DecodeData(buf, true)
+			cb.WaitContinued()
+			if tt.code != 0 {
+				assert.Equal(t, tt.code, cb.LocalResponse().Code)
+				return
+			}
+			assert.Equal(t, 0, cb.LocalResponse().Code)
+			v, _ := hdr.Get("x-body")
+			assert.Equal(t, tt.body, v)
+			// the upstream still receives the whole body
+			assert.Equal(t, "0123456789", buf.String())
+		})
+	}
+}
+
+func TestRequestBodyLimitWithChunkedBody(t *testing.T) {
+	tests := []struct {
+		name       string
+		limit      *bodyLimitConfig
+		code       int
+		body       string
+		sentChunks int
+		upstream   []string
+	}{
+		{
+			name:       "within the limit",
+			limit:      &bodyLimitConfig{maxBytes: 10},
+			body:       "0123456789",
+			sentChunks: 3,
+			upstream:   []string{"", "", "0123456789"},
+		},
+		{
+			name:       "exceed the limit",
+			limit:      &bodyLimitConfig{maxBytes: 6},
+			code:       413,
+			sentChunks: 2,
+		},
+		{
+			name:       "partial",
+			limit:      &bodyLimitConfig{maxBytes: 6, allowPartial: true},
+			body:       "012345",
+			sentChunks: 3,
+			upstream:   []string{"", "01234567", "89"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cb := envoy.NewCAPIFilterCallbackHandler()
+			config := initFilterManagerConfig("ns")
+			config.parsed = []*model.ParsedFilterConfig{
+				{
+					Name:         "decodeRequest",
+					Factory:      decodeRequestFactory,
+					ParsedConfig: tt.limit,
+				},
+			}
+
+			m := FilterManagerFactory(config)(cb).(*filterManager)
+			// no Content-Length
+			hdr := envoy.NewRequestHeaderMap(http.Header{})
+			m.DecodeHeaders(hdr, false)
+			cb.WaitContinued()
+
+			chunks := []*envoy.BufferInstance{
+				envoy.NewBufferInstance([]byte("0123")),
+				envoy.NewBufferInstance([]byte("4567")),
+				envoy.NewBufferInstance([]byte("89")),
+			}
+			for i := 0; i < tt.sentChunks; i++ {
+				m.DecodeData(chunks[i], i == len(chunks)-1)
+				cb.WaitContinued()
+			}
+
+			if tt.code != 0 {
+				assert.Equal(t, tt.code, cb.LocalResponse().Code)
+				return
+			}
+			assert.Equal(t, 0, cb.LocalResponse().Code)
+			v, _ := hdr.Get("x-body")
+			assert.Equal(t, tt.body, v)
+			for i, chunk := range chunks {
+				assert.Equal(t, tt.upstream[i], chunk.String())
+			}
+		})
+	}
+}
+
+func TestMergeRequestBodyLimit(t *testing.T) {
+	parent := initFilterManagerConfig("")
+	parent.maxRequestBytes = 10
+	parent.allowPartialMessage = true
+	child := initFilterManagerConfig("")
+	merged := child.Merge(parent)
+	assert.Equal(t, uint32(10), merged.maxRequestBytes)
+	assert.Equal(t, true, merged.allowPartialMessage)
+
+	child.maxRequestBytes = 5
+	merged = child.Merge(parent)
+	assert.Equal(t, uint32(5), merged.maxRequestBytes)
+	assert.Equal(t, false, merged.allowPartialMessage)
+}
+
+type addTrailerFilter struct {
+	api.PassThroughFilter
+
+	name string
+}
+
+func (f *addTrailerFilter) EncodeTrailers(trailers api.ResponseTrailerMap) api.ResultAction {
+	trailers.Add("x-htnn-filters", f.name)
+	return api.Continue
+}
+
+func TestEncodeTrailers(t *testing.T) {
+	cb := envoy.NewCAPIFilterCallbackHandler()
+	config := initFilterManagerConfig("ns")
+	config.parsed = []*model.ParsedFilterConfig{
+		{
+			Name:    "on_log",
+			Factory: onLogFactory,
+		},
+	}
+	m := FilterManagerFactory(config)(cb).(*filterManager)
+	assert.Equal(t, true, m.canSkipEncodeTrailers)
+	assert.Equal(t, capi.Continue, m.EncodeTrailers(envoy.NewResponseTrailerMap(http.Header{})))
+
+	config = initFilterManagerConfig("ns")
+	for _, name := range []string{"alice", "bob"} {
+		name := name
+		config.parsed = append(config.parsed, &model.ParsedFilterConfig{
+			Name: name,
+			Factory: func(c interface{}, callbacks api.FilterCallbackHandler) api.Filter {
+				return &addTrailerFilter{name: name}
+			},
+		})
+	}
+	m = FilterManagerFactory(config)(cb).(*filterManager)
+	assert.Equal(t, false, m.canSkipEncodeTrailers)
+
+	trailers := envoy.NewResponseTrailerMap(http.Header{})
+	assert.Equal(t, capi.Running, m.EncodeTrailers(trailers))
+	cb.WaitContinued()
+	// the filters run in the reverse order in the response path
+	assert.Equal(t, []string{"bob", "alice"}, trailers.Values("x-htnn-filters"))
+}
@@ -40,6 +40,10 @@ type ParsedFilterConfig struct {
 type FilterWrapper struct {
 	api.Filter
 	Name string
+
+	// The request body limit specified by the plugin. See plugins.RequestBodyLimiter.
+	MaxRequestBytes     uint32
+	AllowPartialMessage bool
 }
 
 func NewFilterWrapper(name string, f api.Filter) *FilterWrapper {
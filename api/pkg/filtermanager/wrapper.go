@@ -15,10 +15,18 @@
 package filtermanager
 
 import (
+	"context"
+	"reflect"
+	"sync"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"mosn.io/htnn/api/internal/reflectx"
 	"mosn.io/htnn/api/pkg/filtermanager/api"
 	"mosn.io/htnn/api/pkg/filtermanager/model"
+	"mosn.io/htnn/api/pkg/tracing"
 )
 
 type logExecutionFilter struct {
@@ -129,6 +137,11 @@ func NewDebugFilter(name string, internal api.Filter, callbacks api.FilterCallba
 
 func (f *debugFilter) recordExecution(start time.Time, method string) {
 	duration := time.Since(start)
+	// The debugMode plugin turns off the debug mode for the requests not matching its trigger
+	if enabled, ok := f.callbacks.PluginState().Get("debugMode", "enabled").(bool); ok && !enabled {
+		return
+	}
+
 	executionRecords := f.callbacks.PluginState().Get("debugMode", "executionRecords")
 	if executionRecords == nil {
 		executionRecords = []model.ExecutionRecord{}
@@ -194,3 +207,191 @@ func (f *debugFilter) EncodeResponse(headers api.ResponseHeaderMap, data api.Buf
 	defer f.recordExecution(time.Now(), "EncodeResponse")
 	return f.internal.EncodeResponse(headers, data, trailers)
 }
+
+var (
+	// cache the overridden methods of each filter type, so we don't need to check them per request
+	overriddenMethodsCache sync.Map
+)
+
+func overriddenMethods(f api.Filter) map[string]bool {
+	typ := reflect.TypeOf(f)
+	if v, ok := overriddenMethodsCache.Load(typ); ok {
+		return v.(map[string]bool)
+	}
+
+	methods := map[string]bool{}
+	for _, meth := range []string{
+		"DecodeHeaders", "DecodeData", "DecodeTrailers", "DecodeRequest",
+		"EncodeHeaders", "EncodeData", "EncodeTrailers", "EncodeResponse", "OnLog",
+	} {
+		overridden, err := reflectx.IsMethodOverridden(f, meth)
+		// trace the method if we are not sure
+		methods[meth] = overridden || err != nil
+	}
+	overriddenMethodsCache.Store(typ, methods)
+	return methods
+}
+
+type tracingFilter struct {
+	// Don't inherit the PassThroughFilter
+	name      string
+	internal  api.Filter
+	callbacks api.FilterCallbackHandler
+	tracer    trace.Tracer
+	// only the methods implemented by the plugin are traced, to avoid empty spans
+	traced map[string]bool
+}
+
+// NewTracingFilter creates a span per phase when running the filter. The spans are the children of
+// the span from the W3C `traceparent` header of the request.
+func NewTracingFilter(name string, internal api.Filter, callbacks api.FilterCallbackHandler, tracer trace.Tracer) api.Filter {
+	return &tracingFilter{
+		name:      name,
+		internal:  internal,
+		callbacks: callbacks,
+		tracer:    tracer,
+		traced:    overriddenMethods(internal),
+	}
+}
+
+func (f *tracingFilter) parent() context.Context {
+	ctx := f.callbacks.PluginState().Get(tracing.StateNamespace, tracing.StateKeyParent)
+	if ctx == nil {
+		return context.Background()
+	}
+	return ctx.(context.Context)
+}
+
+func (f *tracingFilter) extract(headers api.RequestHeaderMap) {
+	state := f.callbacks.PluginState()
+	if state.Get(tracing.StateNamespace, tracing.StateKeyParent) != nil {
+		return
+	}
+	ctx := tracing.Extract(context.Background(), headers)
+	state.Set(tracing.StateNamespace, tracing.StateKeyParent, ctx)
+}
+
+func (f *tracingFilter) start(method string) trace.Span {
+	ctx, span := f.tracer.Start(f.parent(), f.name+" "+method,
+		trace.WithSpanKind(trace.SpanKindInternal),
+		trace.WithAttributes(
+			attribute.String("htnn.plugin", f.name),
+			attribute.String("htnn.phase", method),
+			attribute.String("htnn.route", f.callbacks.StreamInfo().GetRouteName()),
+		),
+	)
+	// so that the outbound calls made by the plugin are the children of this span
+	f.callbacks.PluginState().Set(tracing.StateNamespace, tracing.StateKeyCurrent, ctx)
+	return span
+}
+
+func (f *tracingFilter) finish(span trace.Span) {
+	f.callbacks.PluginState().Set(tracing.StateNamespace, tracing.StateKeyCurrent, nil)
+	span.End()
+}
+
+func (f *tracingFilter) end(span trace.Span, res api.ResultAction) {
+	switch res {
+	case api.Continue:
+		span.SetAttributes(attribute.String("htnn.result", "continue"))
+	case api.WaitAllData:
+		span.SetAttributes(attribute.String("htnn.result", "wait_all_data"))
+	default:
+		if lr, ok := res.(*api.LocalResponse); ok {
+			code := lr.Code
+			if code == 0 {
+				code = 200
+			}
+			span.SetAttributes(
+				attribute.String("htnn.result", "local_reply"),
+				attribute.Int("http.status_code", code),
+			)
+		}
+	}
+	f.finish(span)
+}
+
+func (f *tracingFilter) DecodeHeaders(headers api.RequestHeaderMap, endStream bool) (res api.ResultAction) {
+	f.extract(headers)
+	if !f.traced["DecodeHeaders"] {
+		return f.internal.DecodeHeaders(headers, endStream)
+	}
+	span := f.start("DecodeHeaders")
+	defer func() { f.end(span, res) }()
+	return f.internal.DecodeHeaders(headers, endStream)
+}
+
+func (f *tracingFilter) DecodeData(data api.BufferInstance, endStream bool) (res api.ResultAction) {
+	if !f.traced["DecodeData"] {
+		return f.internal.DecodeData(data, endStream)
+	}
+	span := f.start("DecodeData")
+	defer func() { f.end(span, res) }()
+	return f.internal.DecodeData(data, endStream)
+}
+
+func (f *tracingFilter) DecodeTrailers(trailers api.RequestTrailerMap) (res api.ResultAction) {
+	if !f.traced["DecodeTrailers"] {
+		return f.internal.DecodeTrailers(trailers)
+	}
+	span := f.start("DecodeTrailers")
+	defer func() { f.end(span, res) }()
+	return f.internal.DecodeTrailers(trailers)
+}
+
+func (f *tracingFilter) EncodeHeaders(headers api.ResponseHeaderMap, endStream bool) (res api.ResultAction) {
+	if !f.traced["EncodeHeaders"] {
+		return f.internal.EncodeHeaders(headers, endStream)
+	}
+	span := f.start("EncodeHeaders")
+	defer func() { f.end(span, res) }()
+	return f.internal.EncodeHeaders(headers, endStream)
+}
+
+func (f *tracingFilter) EncodeData(data api.BufferInstance, endStream bool) (res api.ResultAction) {
+	if !f.traced["EncodeData"] {
+		return f.internal.EncodeData(data, endStream)
+	}
+	span := f.start("EncodeData")
+	defer func() { f.end(span, res) }()
+	return f.internal.EncodeData(data, endStream)
+}
+
+func (f *tracingFilter) EncodeTrailers(trailers api.ResponseTrailerMap) (res api.ResultAction) {
+	if !f.traced["EncodeTrailers"] {
+		return f.internal.EncodeTrailers(trailers)
+	}
+	span := f.start("EncodeTrailers")
+	defer func() { f.end(span, res) }()
+	return f.internal.EncodeTrailers(trailers)
+}
+
+func (f *tracingFilter) OnLog(reqHeaders api.RequestHeaderMap, reqTrailers api.RequestTrailerMap,
+	respHeaders api.ResponseHeaderMap, respTrailers api.ResponseTrailerMap) {
+
+	if !f.traced["OnLog"] {
+		f.internal.OnLog(reqHeaders, reqTrailers, respHeaders, respTrailers)
+		return
+	}
+	span := f.start("OnLog")
+	defer f.finish(span)
+	f.internal.OnLog(reqHeaders, reqTrailers, respHeaders, respTrailers)
+}
+
+func (f *tracingFilter) DecodeRequest(headers api.RequestHeaderMap, data api.BufferInstance, trailers api.RequestTrailerMap) (res api.ResultAction) {
+	if !f.traced["DecodeRequest"] {
+		return f.internal.DecodeRequest(headers, data, trailers)
+	}
+	span := f.start("DecodeRequest")
+	defer func() { f.end(span, res) }()
+	return f.internal.DecodeRequest(headers, data, trailers)
+}
+
+func (f *tracingFilter) EncodeResponse(headers api.ResponseHeaderMap, data api.BufferInstance, trailers api.ResponseTrailerMap) (res api.ResultAction) {
+	if !f.traced["EncodeResponse"] {
+		return f.internal.EncodeResponse(headers, data, trailers)
+	}
+	span := f.start("EncodeResponse")
+	defer func() { f.end(span, res) }()
+	return f.internal.EncodeResponse(headers, data, trailers)
+}
@@ -15,14 +15,22 @@
 package filtermanager
 
 import (
+	"context"
+	"net/http"
 	"testing"
 	"time"
 
 	"github.com/agiledragon/gomonkey/v2"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
 
 	"mosn.io/htnn/api/pkg/filtermanager/api"
 	"mosn.io/htnn/api/pkg/filtermanager/model"
+	"mosn.io/htnn/api/pkg/tracing"
 	"mosn.io/htnn/api/plugins/tests/pkg/envoy"
 )
 
@@ -60,4 +68,87 @@ func TestDebugFilter(t *testing.T) {
 	delta := 10 * time.Millisecond
 	rec := records[1].Record["DecodeData"]
 	assert.True(t, 200*time.Millisecond-delta < rec && rec < 200*time.Millisecond+delta)
+
+	// the debug mode is turned off for this request
+	cb.PluginState().Set("debugMode", "enabled", false)
+	f2.DecodeData(nil, true)
+	records = cb.PluginState().Get("debugMode", "executionRecords").([]model.ExecutionRecord)
+	_, ok := records[0].Record["DecodeData"]
+	assert.False(t, ok)
+}
+
+type tracedFilter struct {
+	api.PassThroughFilter
+
+	callbacks api.FilterCallbackHandler
+	// the trace context seen by the plugin
+	current any
+}
+
+func (f *tracedFilter) DecodeHeaders(headers api.RequestHeaderMap, endStream bool) api.ResultAction {
+	f.current = f.callbacks.PluginState().Get(tracing.StateNamespace, tracing.StateKeyCurrent)
+	return &api.LocalResponse{Code: 403}
+}
+
+func (f *tracedFilter) EncodeHeaders(headers api.ResponseHeaderMap, endStream bool) api.ResultAction {
+	return api.Continue
+}
+
+func spanAttrs(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	attrs := map[attribute.Key]attribute.Value{}
+	for _, kv := range span.Attributes() {
+		attrs[kv.Key] = kv.Value
+	}
+	return attrs
+}
+
+func TestTracingFilter(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	defer provider.Shutdown(context.Background())
+	tracer := provider.Tracer("htnn")
+
+	cb := envoy.NewFilterCallbackHandler()
+	raw := &tracedFilter{callbacks: cb}
+	f := NewTracingFilter("one", raw, cb, tracer)
+	hdr := envoy.NewRequestHeaderMap(http.Header{
+		"Traceparent": []string{"00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01"},
+	})
+	f.DecodeHeaders(hdr, false)
+	// DecodeData is not implemented by the plugin, so it's not traced
+	f.DecodeData(nil, true)
+	f.EncodeHeaders(nil, true)
+
+	spans := recorder.Ended()
+	require.Equal(t, 2, len(spans))
+
+	span := spans[0]
+	assert.Equal(t, "one DecodeHeaders", span.Name())
+	assert.Equal(t, "0af7651916cd43dd8448eb211c80319c", span.SpanContext().TraceID().String())
+	assert.Equal(t, "b7ad6b7169203331", span.Parent().SpanID().String())
+	attrs := spanAttrs(span)
+	assert.Equal(t, "one", attrs["htnn.plugin"].AsString())
+	assert.Equal(t, "DecodeHeaders", attrs["htnn.phase"].AsString())
+	assert.Equal(t, "local_reply", attrs["htnn.result"].AsString())
+	assert.Equal(t, int64(403), attrs["http.status_code"].AsInt64())
+	// the trace context of the running plugin is available to its outbound calls
+	require.NotNil(t, raw.current)
+	assert.Equal(t, span.SpanContext().SpanID(), trace.SpanContextFromContext(raw.current.(context.Context)).SpanID())
+	assert.Nil(t, cb.PluginState().Get(tracing.StateNamespace, tracing.StateKeyCurrent))
+
+	span = spans[1]
+	assert.Equal(t, "one EncodeHeaders", span.Name())
+	assert.Equal(t, "0af7651916cd43dd8448eb211c80319c", span.SpanContext().TraceID().String())
+	assert.Equal(t, "continue", spanAttrs(span)["htnn.result"].AsString())
+
+	// without traceparent
+	recorder = tracetest.NewSpanRecorder()
+	provider = sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	defer provider.Shutdown(context.Background())
+	cb = envoy.NewFilterCallbackHandler()
+	f = NewTracingFilter("one", &tracedFilter{callbacks: cb}, cb, provider.Tracer("htnn"))
+	f.DecodeHeaders(envoy.NewRequestHeaderMap(http.Header{}), true)
+	spans = recorder.Ended()
+	require.Equal(t, 1, len(spans))
+	assert.False(t, spans[0].Parent().IsValid())
 }
@@ -38,3 +38,7 @@ func initDefaultLogger() logr.Logger {
 func SetLogger(logger logr.Logger) {
 	DefaultLogger = logger
 }
+
+func ptrstr(s string) *string {
+	return &s
+}
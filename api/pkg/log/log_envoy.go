@@ -24,10 +24,6 @@ import (
 	"mosn.io/htnn/api/pkg/filtermanager/api"
 )
 
-func ptrstr(s string) *string {
-	return &s
-}
-
 func init() {
 	// Name of this file guarantees that SetLogger runs after DefaultLogger init.
 	SetLogger(DefaultLogger.WithSink(&EnvoyLogSink{
@@ -0,0 +1,206 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/go-logr/logr"
+	"github.com/go-logr/logr/funcr"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+)
+
+const (
+	// EnvPluginLogLevel configures the initial per-plugin log level, like `limitReq=debug,opa=error`
+	EnvPluginLogLevel = "HTNN_PLUGIN_LOG_LEVEL"
+)
+
+var (
+	pluginLogLevels sync.Map
+
+	logLevelNames = map[api.LogType]string{
+		api.LogLevelTrace:    "trace",
+		api.LogLevelDebug:    "debug",
+		api.LogLevelInfo:     "info",
+		api.LogLevelWarn:     "warn",
+		api.LogLevelError:    "error",
+		api.LogLevelCritical: "critical",
+	}
+)
+
+func init() {
+	if err := parsePluginLogLevels(os.Getenv(EnvPluginLogLevel)); err != nil {
+		DefaultLogger.Error(err, "invalid env var", "name", EnvPluginLogLevel)
+	}
+}
+
+func parsePluginLogLevels(s string) error {
+	if s == "" {
+		return nil
+	}
+	for _, item := range strings.Split(s, ",") {
+		plugin, name, ok := strings.Cut(strings.TrimSpace(item), "=")
+		if !ok || plugin == "" {
+			return fmt.Errorf("bad plugin log level %q, expected format: $plugin=$level", item)
+		}
+		level, err := ParseLogLevel(name)
+		if err != nil {
+			return err
+		}
+		SetPluginLogLevel(plugin, level)
+	}
+	return nil
+}
+
+// ParseLogLevel converts the level name like `debug` to api.LogType.
+func ParseLogLevel(name string) (api.LogType, error) {
+	for level, n := range logLevelNames {
+		if strings.EqualFold(n, name) {
+			return level, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown log level %q", name)
+}
+
+// SetPluginLogLevel overrides the log level of the given plugin at runtime.
+// It takes effect on the logger returned from the FilterCallbackHandler.
+func SetPluginLogLevel(plugin string, level api.LogType) {
+	pluginLogLevels.Store(plugin, level)
+}
+
+// ResetPluginLogLevel removes the log level override of the given plugin, so the plugin
+// uses the same log level as the `golang` logger in Envoy.
+func ResetPluginLogLevel(plugin string) {
+	pluginLogLevels.Delete(plugin)
+}
+
+func pluginLogLevel(plugin string) api.LogType {
+	if v, ok := pluginLogLevels.Load(plugin); ok {
+		return v.(api.LogType)
+	}
+	return api.GetLogLevel()
+}
+
+// NewPluginLogger returns a logger that writes JSON records to Envoy's log. The plugin name is
+// added as the `plugin` field when it is not empty, and its log level can be overridden via
+// SetPluginLogLevel. The V-level is mapped to Envoy's log level: V(0) is info, V(1) is debug
+// and V(2) or above is trace.
+func NewPluginLogger(plugin string, keysAndValues ...any) logr.Logger {
+	sink := &pluginLogSink{
+		Formatter: funcr.NewFormatterJSON(funcr.Options{
+			LogInfoLevel:       ptrstr(""),
+			RenderBuiltinsHook: omitEmptyLoggerName,
+		}),
+		plugin: plugin,
+	}
+	if plugin != "" {
+		sink.Formatter.AddValues([]any{"plugin", plugin})
+	}
+	sink.Formatter.AddValues(keysAndValues)
+	return logr.New(sink)
+}
+
+// omitEmptyLoggerName drops the `logger` field which is always added in JSON format, unless
+// the logger is named via WithName.
+func omitEmptyLoggerName(kvList []any) []any {
+	if len(kvList) >= 2 && kvList[0] == "logger" && kvList[1] == "" {
+		return kvList[2:]
+	}
+	return kvList
+}
+
+type pluginLogSink struct {
+	funcr.Formatter
+
+	plugin string
+}
+
+func (l *pluginLogSink) Init(info logr.RuntimeInfo) {
+	l.Formatter.Init(info)
+}
+
+func toLogType(vLevel int) api.LogType {
+	level := int(api.LogLevelInfo) - vLevel
+	if level < int(api.LogLevelTrace) {
+		return api.LogLevelTrace
+	}
+	return api.LogType(level)
+}
+
+func (l *pluginLogSink) Enabled(vLevel int) bool {
+	return toLogType(vLevel) >= pluginLogLevel(l.plugin)
+}
+
+func (l *pluginLogSink) Info(vLevel int, msg string, keysAndValues ...any) {
+	level := toLogType(vLevel)
+	kv := append([]any{"level", logLevelNames[level]}, keysAndValues...)
+	_, s := l.Formatter.FormatInfo(vLevel, msg, kv)
+	emit(level, s)
+}
+
+func (l *pluginLogSink) Error(err error, msg string, keysAndValues ...any) {
+	if api.LogLevelError < pluginLogLevel(l.plugin) {
+		return
+	}
+	kv := append([]any{"level", logLevelNames[api.LogLevelError]}, keysAndValues...)
+	_, s := l.Formatter.FormatError(err, msg, kv)
+	emit(api.LogLevelError, s)
+}
+
+func emit(level api.LogType, s string) {
+	// The record lower than Envoy's log level is dropped by Envoy. As the plugin log level
+	// override allows it, we write it with Envoy's log level. The `level` field keeps the
+	// original level.
+	if curr := api.GetLogLevel(); level < curr {
+		level = curr
+	}
+
+	switch level {
+	case api.LogLevelTrace:
+		api.LogTrace(s)
+	case api.LogLevelDebug:
+		api.LogDebug(s)
+	case api.LogLevelInfo:
+		api.LogInfo(s)
+	case api.LogLevelWarn:
+		api.LogWarn(s)
+	case api.LogLevelError:
+		api.LogError(s)
+	default:
+		api.LogCritical(s)
+	}
+}
+
+func (l *pluginLogSink) WithValues(keysAndValues ...any) logr.LogSink {
+	nl := &pluginLogSink{
+		Formatter: l.Formatter, // copy of Formatter
+		plugin:    l.plugin,
+	}
+	nl.Formatter.AddValues(keysAndValues)
+	return nl
+}
+
+func (l *pluginLogSink) WithName(name string) logr.LogSink {
+	nl := &pluginLogSink{
+		Formatter: l.Formatter,
+		plugin:    l.plugin,
+	}
+	nl.Formatter.AddName(name)
+	return nl
+}
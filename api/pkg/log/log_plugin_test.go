@@ -0,0 +1,121 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"testing"
+
+	capi "github.com/envoyproxy/envoy/contrib/golang/common/go/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+)
+
+type fakeCapi struct {
+	capi.CommonCAPI
+
+	lock sync.Mutex
+	out  io.Writer
+}
+
+func (a *fakeCapi) Log(level capi.LogType, message string) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	fmt.Fprintf(a.out, "[%s] %s\n", level, message)
+}
+
+// setOutput replaces the output of the records and returns the previous one
+func (a *fakeCapi) setOutput(out io.Writer) io.Writer {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	prev := a.out
+	a.out = out
+	return prev
+}
+
+// The capi package doesn't provide a way to get the current CommonCAPI, so we install the fake
+// once for the whole package and only replace its output in each test.
+var testCapi = &fakeCapi{out: io.Discard}
+
+func init() {
+	capi.SetCommonCAPI(testCapi)
+}
+
+func TestPluginLogger(t *testing.T) {
+	var buf bytes.Buffer
+	prev := testCapi.setOutput(&buf)
+	defer testCapi.setOutput(prev)
+	api.SetLogLevel(api.LogLevelInfo)
+	defer api.SetLogLevel(api.LogLevelTrace)
+
+	logger := NewPluginLogger("alice", "route", "r")
+	logger.Info("hello", "k", "v")
+	assert.Contains(t, buf.String(), `[info] {"msg":"hello","plugin":"alice","route":"r","level":"info","k":"v"}`)
+
+	buf.Reset()
+	logger.V(1).Info("debug")
+	assert.Empty(t, buf.String())
+
+	// raise the log level of the plugin
+	SetPluginLogLevel("alice", api.LogLevelDebug)
+	logger.V(1).Info("debug")
+	assert.Contains(t, buf.String(), `[info] {"msg":"debug","plugin":"alice","route":"r","level":"debug"}`)
+	// other plugins are not affected
+	buf.Reset()
+	NewPluginLogger("bob").V(1).Info("debug")
+	assert.Empty(t, buf.String())
+
+	// lower the log level of the plugin
+	SetPluginLogLevel("alice", api.LogLevelCritical)
+	logger.Info("hello")
+	logger.Error(errors.New("ouch"), "failed")
+	assert.Empty(t, buf.String())
+
+	ResetPluginLogLevel("alice")
+	logger.WithValues("method", "DecodeHeaders").Error(errors.New("ouch"), "failed")
+	assert.Contains(t, buf.String(), `[error] {"msg":"failed","error":"ouch","plugin":"alice","route":"r","method":"DecodeHeaders","level":"error"}`)
+
+	// the logger field is kept when the logger is named
+	buf.Reset()
+	logger.WithName("cache").Info("hello")
+	assert.Contains(t, buf.String(), `[info] {"logger":"cache","msg":"hello","plugin":"alice","route":"r","level":"info"}`)
+}
+
+func TestParseLogLevel(t *testing.T) {
+	level, err := ParseLogLevel("Debug")
+	require.Nil(t, err)
+	assert.Equal(t, api.LogLevelDebug, level)
+
+	_, err = ParseLogLevel("verbose")
+	assert.ErrorContains(t, err, `unknown log level "verbose"`)
+}
+
+func TestParsePluginLogLevels(t *testing.T) {
+	defer ResetPluginLogLevel("limitReq")
+	defer ResetPluginLogLevel("opa")
+
+	require.Nil(t, parsePluginLogLevels("limitReq=debug, opa=error"))
+	assert.Equal(t, api.LogLevelDebug, pluginLogLevel("limitReq"))
+	assert.Equal(t, api.LogLevelError, pluginLogLevel("opa"))
+
+	assert.ErrorContains(t, parsePluginLogLevels("limitReq"), "expected format: $plugin=$level")
+	assert.ErrorContains(t, parsePluginLogLevels("opa=loud"), `unknown log level "loud"`)
+}
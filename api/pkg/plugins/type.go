@@ -15,6 +15,8 @@
 package plugins
 
 import (
+	"go.opentelemetry.io/otel/trace"
+
 	"mosn.io/htnn/api/pkg/filtermanager/api"
 )
 
@@ -137,6 +139,23 @@ type Initer interface {
 	Init(cb api.ConfigCallbackHandler) error
 }
 
+// RequestBodyLimiter can be implemented by the configuration of the plugin which processes
+// the whole request body, to limit the size of the body buffered for it.
+type RequestBodyLimiter interface {
+	// RequestBodyLimit returns the max size of the request body in bytes, and whether to pass
+	// only the first maxBytes of the body to the plugin instead of rejecting the request with 413.
+	// Returning zero maxBytes means using the limit configured in the policy.
+	RequestBodyLimit() (maxBytes uint32, allowPartialMessage bool)
+}
+
+// PluginTracer can be implemented by the configuration of the plugin which traces the execution
+// of the other plugins. The filtermanager creates a span per plugin per phase with the returned tracer.
+type PluginTracer interface {
+	// Tracer returns the tracer. It is called per request, so the tracer should be cached.
+	// Returning nil means the tracing is not available.
+	Tracer() trace.Tracer
+}
+
 type NativePlugin interface {
 	Plugin
 
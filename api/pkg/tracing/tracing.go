@@ -0,0 +1,205 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tracing propagates the trace context of the downstream request to the outbound calls
+// made by the plugins, like the requests sent to the authorization service.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync/atomic"
+
+	"go.opentelemetry.io/contrib/propagators/b3"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+)
+
+const (
+	// EnvPropagators is the environment variable which enables the propagation. It's a comma-separated
+	// list of the propagators, like `tracecontext,b3`. The supported propagators are:
+	// * tracecontext: the W3C `traceparent` and `tracestate` headers
+	// * b3: the single `b3` header
+	// * b3multi: the `x-b3-*` headers
+	// The propagation is disabled if the environment variable is empty or set to `none`.
+	EnvPropagators = "HTNN_TRACE_PROPAGATORS"
+
+	// StateNamespace is the namespace of the PluginState used to store the trace context of the request
+	StateNamespace = "pluginTracing"
+	// StateKeyParent is the key of the trace context extracted from the downstream request
+	StateKeyParent = "parent"
+	// StateKeyCurrent is the key of the trace context of the span of the running plugin
+	StateKeyCurrent = "current"
+
+	instrumentationName = "mosn.io/htnn"
+)
+
+var (
+	// propagator is nil when the propagation is disabled
+	propagator atomic.Pointer[propagation.TextMapPropagator]
+)
+
+func init() {
+	err := SetPropagators(os.Getenv(EnvPropagators))
+	if err != nil {
+		api.LogErrorf("invalid env var %s: %v", EnvPropagators, err)
+	}
+}
+
+// SetPropagators sets the propagators used to inject the trace context into the outbound calls.
+// The format is the same as the environment variable `HTNN_TRACE_PROPAGATORS`. An empty string
+// disables the propagation.
+func SetPropagators(names string) error {
+	var propagators []propagation.TextMapPropagator
+	for _, name := range strings.Split(names, ",") {
+		name = strings.TrimSpace(name)
+		switch name {
+		case "", "none":
+		case "tracecontext":
+			propagators = append(propagators, propagation.TraceContext{})
+		case "b3":
+			propagators = append(propagators, b3.New(b3.WithInjectEncoding(b3.B3SingleHeader)))
+		case "b3multi":
+			propagators = append(propagators, b3.New(b3.WithInjectEncoding(b3.B3MultipleHeader)))
+		default:
+			return fmt.Errorf("unknown propagator: %s", name)
+		}
+	}
+
+	if len(propagators) == 0 {
+		propagator.Store(nil)
+		return nil
+	}
+	p := propagation.NewCompositeTextMapPropagator(propagators...)
+	propagator.Store(&p)
+	return nil
+}
+
+// Enabled returns whether the trace context is propagated to the outbound calls
+func Enabled() bool {
+	return propagator.Load() != nil
+}
+
+type headerCarrier struct {
+	api.RequestHeaderMap
+}
+
+func (c headerCarrier) Keys() []string {
+	keys := []string{}
+	c.Range(func(key, value string) bool {
+		keys = append(keys, key)
+		return true
+	})
+	return keys
+}
+
+func (c headerCarrier) Get(key string) string {
+	v, _ := c.RequestHeaderMap.Get(key)
+	return v
+}
+
+// Extract returns a context carrying the trace context from the request headers. The W3C
+// `traceparent` header is always recognized, and so are the headers of the enabled propagators.
+func Extract(ctx context.Context, headers api.RequestHeaderMap) context.Context {
+	ctx = propagation.TraceContext{}.Extract(ctx, headerCarrier{headers})
+	if p := propagator.Load(); p != nil {
+		ctx = (*p).Extract(ctx, headerCarrier{headers})
+	}
+	return ctx
+}
+
+// ContextWithRequest returns a context carrying the trace context of the request, which should be
+// used in the outbound calls. When the `pluginTracing` plugin is enabled, the outbound calls are the
+// children of the span of the running plugin. Otherwise, they are the children of the span from the
+// request headers. The returned context doesn't carry any trace context if the propagation is disabled.
+func ContextWithRequest(ctx context.Context, callbacks api.FilterCallbackHandler, headers api.RequestHeaderMap) context.Context {
+	if !Enabled() {
+		return ctx
+	}
+
+	state := callbacks.PluginState()
+	for _, key := range []string{StateKeyCurrent, StateKeyParent} {
+		if v, ok := state.Get(StateNamespace, key).(context.Context); ok {
+			// keep the span instead of its span context, so the outbound calls are recorded
+			// by the same TracerProvider
+			return trace.ContextWithSpan(ctx, trace.SpanFromContext(v))
+		}
+	}
+	return Extract(ctx, headers)
+}
+
+// StartSpan starts a span as the child of the span in the context. The span is only recorded when
+// the span in the context is recorded by the `pluginTracing` plugin.
+func StartSpan(ctx context.Context, name string, kind trace.SpanKind, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	if !Enabled() {
+		return ctx, trace.SpanFromContext(ctx)
+	}
+	tracer := trace.SpanFromContext(ctx).TracerProvider().Tracer(instrumentationName)
+	return tracer.Start(ctx, name, trace.WithSpanKind(kind), trace.WithAttributes(attrs...))
+}
+
+// Inject injects the trace context into the headers of the outbound HTTP request
+func Inject(ctx context.Context, header http.Header) {
+	if p := propagator.Load(); p != nil {
+		(*p).Inject(ctx, propagation.HeaderCarrier(header))
+	}
+}
+
+type transport struct {
+	base http.RoundTripper
+}
+
+// NewTransport wraps the given RoundTripper, so that a client span is started and the trace context
+// is injected into the outbound request. The trace context is read from the context of the request.
+// The http.DefaultTransport is used if the given RoundTripper is nil.
+func NewTransport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &transport{base: base}
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !Enabled() || !trace.SpanContextFromContext(req.Context()).IsValid() {
+		return t.base.RoundTrip(req)
+	}
+
+	ctx, span := StartSpan(req.Context(), "HTTP "+req.Method, trace.SpanKindClient,
+		attribute.String("http.method", req.Method),
+		attribute.String("http.url", req.URL.Redacted()),
+	)
+	defer span.End()
+
+	// RoundTripper should not modify the request
+	req = req.Clone(ctx)
+	Inject(ctx, req.Header)
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	span.SetAttributes(attribute.Int("http.status_code", resp.StatusCode))
+	if resp.StatusCode >= 500 {
+		span.SetStatus(codes.Error, resp.Status)
+	}
+	return resp, nil
+}
@@ -0,0 +1,133 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"mosn.io/htnn/api/plugins/tests/pkg/envoy"
+)
+
+const (
+	traceID     = "0af7651916cd43dd8448eb211c80319c"
+	spanID      = "b7ad6b7169203331"
+	traceparent = "00-" + traceID + "-" + spanID + "-01"
+)
+
+func TestSetPropagators(t *testing.T) {
+	defer SetPropagators("")
+
+	assert.False(t, Enabled())
+	assert.Nil(t, SetPropagators("tracecontext, b3,b3multi"))
+	assert.True(t, Enabled())
+	assert.Nil(t, SetPropagators("none"))
+	assert.False(t, Enabled())
+	assert.ErrorContains(t, SetPropagators("tracecontext,jaeger"), "unknown propagator: jaeger")
+}
+
+func TestContextWithRequest(t *testing.T) {
+	defer SetPropagators("")
+
+	hdr := envoy.NewRequestHeaderMap(http.Header{
+		"Traceparent": []string{traceparent},
+	})
+	cb := envoy.NewFilterCallbackHandler()
+	ctx := ContextWithRequest(context.Background(), cb, hdr)
+	assert.False(t, trace.SpanContextFromContext(ctx).IsValid(), "disabled")
+
+	require.Nil(t, SetPropagators("tracecontext"))
+	ctx = ContextWithRequest(context.Background(), cb, hdr)
+	sc := trace.SpanContextFromContext(ctx)
+	assert.Equal(t, traceID, sc.TraceID().String())
+	assert.Equal(t, spanID, sc.SpanID().String())
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	defer provider.Shutdown(context.Background())
+	pluginCtx, span := provider.Tracer("test").Start(ctx, "plugin")
+	cb.PluginState().Set(StateNamespace, StateKeyCurrent, pluginCtx)
+	ctx = ContextWithRequest(context.Background(), cb, hdr)
+	sc = trace.SpanContextFromContext(ctx)
+	assert.Equal(t, traceID, sc.TraceID().String())
+	assert.Equal(t, span.SpanContext().SpanID(), sc.SpanID())
+
+	// the outbound span is recorded by the provider of the plugin span
+	_, child := StartSpan(ctx, "child", trace.SpanKindClient)
+	assert.True(t, child.IsRecording())
+	child.End()
+	span.End()
+	ended := recorder.Ended()
+	require.Equal(t, 2, len(ended))
+	assert.Equal(t, "child", ended[0].Name())
+	assert.Equal(t, span.SpanContext().SpanID(), ended[0].Parent().SpanID())
+}
+
+func TestTransport(t *testing.T) {
+	defer SetPropagators("")
+
+	var header http.Header
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header
+	}))
+	defer srv.Close()
+	client := &http.Client{Transport: NewTransport(nil)}
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	defer provider.Shutdown(context.Background())
+	hdr := envoy.NewRequestHeaderMap(http.Header{
+		"Traceparent": []string{traceparent},
+	})
+	pluginCtx, span := provider.Tracer("test").Start(Extract(context.Background(), hdr), "plugin")
+	defer span.End()
+
+	send := func(ctx context.Context) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+		require.Nil(t, err)
+		resp, err := client.Do(req)
+		require.Nil(t, err)
+		resp.Body.Close()
+	}
+
+	send(pluginCtx)
+	assert.Empty(t, header.Get("Traceparent"), "disabled")
+
+	require.Nil(t, SetPropagators("tracecontext,b3"))
+	send(context.Background())
+	assert.Empty(t, header.Get("Traceparent"), "no trace context")
+
+	send(pluginCtx)
+	spans := recorder.Ended()
+	require.Equal(t, 1, len(spans))
+	clientSpan := spans[0]
+	assert.Equal(t, "HTTP GET", clientSpan.Name())
+	assert.Equal(t, span.SpanContext().SpanID(), clientSpan.Parent().SpanID())
+	assert.Equal(t, "00-"+traceID+"-"+clientSpan.SpanContext().SpanID().String()+"-01", header.Get("Traceparent"))
+	assert.Equal(t, traceID+"-"+clientSpan.SpanContext().SpanID().String()+"-1", header.Get("B3"))
+
+	// propagate the trace context from the request headers when the pluginTracing is disabled
+	send(trace.ContextWithSpanContext(context.Background(), trace.SpanContextFromContext(pluginCtx)))
+	assert.Equal(t, "00-"+traceID+"-"+span.SpanContext().SpanID().String()+"-01", header.Get("Traceparent"))
+	assert.Equal(t, 1, len(recorder.Ended()))
+}
@@ -25,10 +25,12 @@ import (
 	"sync"
 
 	capi "github.com/envoyproxy/envoy/contrib/golang/common/go/api"
+	"github.com/go-logr/logr"
 
 	"mosn.io/htnn/api/internal/cookie"
 	"mosn.io/htnn/api/internal/plugin_state"
 	"mosn.io/htnn/api/pkg/filtermanager/api"
+	pkgLog "mosn.io/htnn/api/pkg/log"
 )
 
 func init() {
@@ -188,6 +190,18 @@ func (i *ResponseHeaderMap) Status() (int, bool) {
 
 var _ api.ResponseHeaderMap = (*ResponseHeaderMap)(nil)
 
+type ResponseTrailerMap struct {
+	HeaderMap
+}
+
+func NewResponseTrailerMap(hdr http.Header) *ResponseTrailerMap {
+	return &ResponseTrailerMap{
+		HeaderMap: HeaderMap{hdr},
+	}
+}
+
+var _ api.ResponseTrailerMap = (*ResponseTrailerMap)(nil)
+
 type dataBuffer struct {
 	buffer *bytes.Buffer
 }
@@ -303,7 +317,7 @@ func (i *DynamicMetadata) Get(filterName string) map[string]interface{} {
 func (i *DynamicMetadata) Set(filterName string, key string, value interface{}) {
 	dm, ok := i.store[filterName]
 	if !ok {
-		dm := map[string]interface{}{}
+		dm = map[string]interface{}{}
 		i.store[filterName] = dm
 	}
 
@@ -360,6 +374,9 @@ func (i *StreamInfo) AttemptCount() uint32 {
 }
 
 func (i *StreamInfo) DynamicMetadata() api.DynamicMetadata {
+	if i.dynamicMetadata == nil {
+		i.dynamicMetadata = NewDynamicMetadata(map[string]map[string]interface{}{})
+	}
 	return i.dynamicMetadata
 }
 
@@ -512,6 +529,10 @@ func (i *filterCallbackHandler) PluginState() api.PluginState {
 	return i.pluginState
 }
 
+func (i *filterCallbackHandler) Logger() logr.Logger {
+	return pkgLog.NewPluginLogger("", "route", i.StreamInfo().GetRouteName())
+}
+
 var _ api.FilterCallbackHandler = (*filterCallbackHandler)(nil)
 
 type capiFilterCallbackHandler struct {
@@ -26,6 +26,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"mosn.io/htnn/controller/internal/log"
+	"mosn.io/htnn/controller/internal/metrics"
 	"mosn.io/htnn/controller/pkg/component"
 	"mosn.io/htnn/controller/pkg/constant"
 )
@@ -65,9 +66,11 @@ func (o *k8sOutput) FromHTTPFilterPolicy(ctx context.Context, generatedEnvoyFilt
 		if _, ok := generatedEnvoyFilters[key]; !ok {
 			logger.Info("delete EnvoyFilter", "name", e.Name, "namespace", e.Namespace)
 			if err := o.Delete(ctx, e); err != nil {
+				metrics.EnvoyFilterOperationFailuresCounter.Increment(metrics.OperationDelete)
 				return fmt.Errorf("failed to delete EnvoyFilter: %w, namespacedName: %v",
 					err, types.NamespacedName{Name: e.Name, Namespace: e.Namespace})
 			}
+			metrics.EnvoyFilterOperationsCounter.Increment(metrics.OperationDelete)
 		} else {
 			preEnvoyFilterMap[key] = e
 		}
@@ -79,9 +82,11 @@ func (o *k8sOutput) FromHTTPFilterPolicy(ctx context.Context, generatedEnvoyFilt
 			logger.Info("create EnvoyFilter", "name", ef.Name, "namespace", ef.Namespace)
 
 			if err := o.Create(ctx, ef); err != nil {
+				metrics.EnvoyFilterOperationFailuresCounter.Increment(metrics.OperationCreate)
 				nsName := types.NamespacedName{Name: ef.Name, Namespace: ef.Namespace}
 				return fmt.Errorf("failed to create EnvoyFilter: %w, namespacedName: %v", err, nsName)
 			}
+			metrics.EnvoyFilterOperationsCounter.Increment(metrics.OperationCreate)
 
 		} else {
 			if proto.Equal(&envoyfilter.Spec, &ef.Spec) {
@@ -92,9 +97,11 @@ func (o *k8sOutput) FromHTTPFilterPolicy(ctx context.Context, generatedEnvoyFilt
 			// Address metadata.resourceVersion: Invalid value: 0x0 error
 			ef.SetResourceVersion(envoyfilter.ResourceVersion)
 			if err := o.Update(ctx, ef); err != nil {
+				metrics.EnvoyFilterOperationFailuresCounter.Increment(metrics.OperationUpdate)
 				nsName := types.NamespacedName{Name: ef.Name, Namespace: ef.Namespace}
 				return fmt.Errorf("failed to update EnvoyFilter: %w, namespacedName: %v", err, nsName)
 			}
+			metrics.EnvoyFilterOperationsCounter.Increment(metrics.OperationUpdate)
 		}
 	}
 
@@ -116,9 +123,11 @@ func (o *k8sOutput) FromConsumer(ctx context.Context, ef *istiov1a3.EnvoyFilter)
 			logger.Info("delete EnvoyFilter", "name", e.Name, "namespace", e.Namespace)
 
 			if err := o.Delete(ctx, e); err != nil {
+				metrics.EnvoyFilterOperationFailuresCounter.Increment(metrics.OperationDelete)
 				return fmt.Errorf("failed to delete EnvoyFilter: %w, namespacedName: %v",
 					err, types.NamespacedName{Name: e.Name, Namespace: e.Namespace})
 			}
+			metrics.EnvoyFilterOperationsCounter.Increment(metrics.OperationDelete)
 		} else {
 			envoyfilter = e
 		}
@@ -128,16 +137,20 @@ func (o *k8sOutput) FromConsumer(ctx context.Context, ef *istiov1a3.EnvoyFilter)
 		logger.Info("create EnvoyFilter", "name", ef.Name, "namespace", ef.Namespace)
 
 		if err := o.Create(ctx, ef.DeepCopy()); err != nil {
+			metrics.EnvoyFilterOperationFailuresCounter.Increment(metrics.OperationCreate)
 			return fmt.Errorf("failed to create EnvoyFilter: %w, namespacedName: %v", err, nsName)
 		}
+		metrics.EnvoyFilterOperationsCounter.Increment(metrics.OperationCreate)
 	} else {
 		logger.Info("update EnvoyFilter", "name", ef.Name, "namespace", ef.Namespace)
 
 		ef = ef.DeepCopy()
 		ef.SetResourceVersion(envoyfilter.ResourceVersion)
 		if err := o.Update(ctx, ef); err != nil {
+			metrics.EnvoyFilterOperationFailuresCounter.Increment(metrics.OperationUpdate)
 			return fmt.Errorf("failed to update EnvoyFilter: %w, namespacedName: %v", err, nsName)
 		}
+		metrics.EnvoyFilterOperationsCounter.Increment(metrics.OperationUpdate)
 	}
 
 	return nil
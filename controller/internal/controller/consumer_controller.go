@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"time"
 
+	"google.golang.org/protobuf/proto"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
@@ -87,6 +88,7 @@ func (r *ConsumerReconciler) consumersToState(ctx context.Context,
 	}
 
 	namespaceToConsumers := make(map[string]map[string]*mosniov1.Consumer)
+	invalid := 0
 	for i := range consumers.Items {
 		consumer := &consumers.Items[i]
 
@@ -96,10 +98,12 @@ func (r *ConsumerReconciler) consumersToState(ctx context.Context,
 			if err != nil {
 				log.Errorf("invalid Consumer, err: %v, name: %s, namespace: %s", consumer.Name, consumer.Namespace)
 				consumer.SetAccepted(mosniov1.ReasonInvalid, err.Error())
+				invalid++
 				continue
 			}
 		}
 		if !consumer.IsValid() {
+			invalid++
 			continue
 		}
 
@@ -112,6 +116,9 @@ func (r *ConsumerReconciler) consumersToState(ctx context.Context,
 		consumer.SetAccepted(mosniov1.ReasonAccepted)
 	}
 
+	metrics.ConsumerResourcesGauge.Set(float64(len(consumers.Items)-invalid), metrics.StateAccepted)
+	metrics.ConsumerResourcesGauge.Set(float64(invalid), metrics.StateInvalid)
+
 	state := &consumerReconcileState{
 		namespaceToConsumers: namespaceToConsumers,
 	}
@@ -134,6 +141,7 @@ func (r *ConsumerReconciler) generateCustomResource(ctx context.Context, state *
 	}
 
 	ef := istio.GenerateConsumers(consumerData)
+	metrics.EnvoyFilterSizeDistribution.Record(float64(proto.Size(&ef.Spec)))
 
 	return r.Output.FromConsumer(ctx, ef)
 }
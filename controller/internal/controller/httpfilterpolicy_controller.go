@@ -25,8 +25,10 @@ import (
 	"sync"
 	"time"
 
+	"google.golang.org/protobuf/proto"
 	istiov1a3 "istio.io/client-go/pkg/apis/networking/v1alpha3"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
@@ -146,6 +148,7 @@ func (r *HTTPFilterPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Req
 	if err != nil {
 		return ctrl.Result{}, err
 	}
+	recordPolicies(&policies)
 	if initState == nil {
 		return ctrl.Result{}, nil
 	}
@@ -161,6 +164,9 @@ func (r *HTTPFilterPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Req
 	}
 
 	generatedEnvoyFilters := finalState.EnvoyFilters
+	for _, ef := range generatedEnvoyFilters {
+		metrics.EnvoyFilterSizeDistribution.Record(float64(proto.Size(&ef.Spec)))
+	}
 	err = r.output.FromHTTPFilterPolicy(ctx, generatedEnvoyFilters)
 	if err != nil {
 		return ctrl.Result{}, err
@@ -628,6 +634,34 @@ func (r *HTTPFilterPolicyReconciler) policyToTranslationState(ctx context.Contex
 	return initState, nil
 }
 
+func recordPolicies(policies *mosniov1.HTTPFilterPolicyList) {
+	counts := map[string]int{
+		metrics.StateAccepted:       0,
+		metrics.StateInvalid:        0,
+		metrics.StateTargetNotFound: 0,
+	}
+	for i := range policies.Items {
+		policy := &policies.Items[i]
+		cond := meta.FindStatusCondition(policy.Status.Conditions, string(gwapiv1a2.PolicyConditionAccepted))
+		if cond == nil {
+			continue
+		}
+
+		switch gwapiv1a2.PolicyConditionReason(cond.Reason) {
+		case gwapiv1a2.PolicyReasonAccepted:
+			counts[metrics.StateAccepted]++
+		case gwapiv1a2.PolicyReasonInvalid:
+			counts[metrics.StateInvalid]++
+		case gwapiv1a2.PolicyReasonTargetNotFound:
+			counts[metrics.StateTargetNotFound]++
+		}
+	}
+
+	for state, n := range counts {
+		metrics.HFPResourcesGauge.Set(float64(n), state)
+	}
+}
+
 func (r *HTTPFilterPolicyReconciler) updatePolicies(ctx context.Context,
 	policies *mosniov1.HTTPFilterPolicyList) error {
 
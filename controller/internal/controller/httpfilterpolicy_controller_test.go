@@ -23,8 +23,10 @@ import (
 	"github.com/stretchr/testify/assert"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gwapiv1a2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 
 	"mosn.io/htnn/controller/internal/controller/component"
+	"mosn.io/htnn/controller/internal/metrics"
 	"mosn.io/htnn/controller/tests/pkg"
 	mosniov1 "mosn.io/htnn/types/apis/v1"
 )
@@ -59,3 +61,34 @@ func TestNeedReconcile(t *testing.T) {
 	}
 	assert.True(t, r.NeedReconcile(ctx, res))
 }
+
+type fakeGauge struct {
+	values map[string]float64
+}
+
+func (g *fakeGauge) Set(value float64, labelValues ...string) {
+	g.values[labelValues[0]] = value
+}
+
+func TestRecordPolicies(t *testing.T) {
+	gauge := &fakeGauge{values: map[string]float64{}}
+	prev := metrics.HFPResourcesGauge
+	metrics.HFPResourcesGauge = gauge
+	defer func() { metrics.HFPResourcesGauge = prev }()
+
+	policies := mosniov1.HTTPFilterPolicyList{
+		Items: make([]mosniov1.HTTPFilterPolicy, 5),
+	}
+	policies.Items[0].SetAccepted(gwapiv1a2.PolicyReasonAccepted)
+	policies.Items[1].SetAccepted(gwapiv1a2.PolicyReasonAccepted)
+	policies.Items[2].SetAccepted(gwapiv1a2.PolicyReasonInvalid)
+	policies.Items[3].SetAccepted(gwapiv1a2.PolicyReasonTargetNotFound)
+	// the last one is not handled
+
+	recordPolicies(&policies)
+	assert.Equal(t, map[string]float64{
+		"accepted":         2,
+		"invalid":          1,
+		"target_not_found": 1,
+	}, gauge.values)
+}
@@ -22,6 +22,7 @@ import (
 	"time"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
@@ -92,9 +93,21 @@ func (r *ServiceRegistryReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		}
 	}
 
+	r.recordServiceRegistries()
 	return ctrl.Result{}, nil
 }
 
+func (r *ServiceRegistryReconciler) recordServiceRegistries() {
+	accepted := 0
+	for _, serviceRegistry := range r.prevServiceRegistries {
+		if meta.IsStatusConditionTrue(serviceRegistry.Status.Conditions, string(mosniov1.ConditionAccepted)) {
+			accepted++
+		}
+	}
+	metrics.ServiceRegistryResourcesGauge.Set(float64(accepted), metrics.StateAccepted)
+	metrics.ServiceRegistryResourcesGauge.Set(float64(len(r.prevServiceRegistries)-accepted), metrics.StateInvalid)
+}
+
 func (r *ServiceRegistryReconciler) reconcileServiceRegistry(ctx context.Context, nsName types.NamespacedName, prevServiceRegistry *mosniov1.ServiceRegistry) error {
 	var serviceRegistry mosniov1.ServiceRegistry
 	err := r.Get(ctx, nsName, &serviceRegistry)
@@ -24,8 +24,22 @@ const (
 	HFP                     = "htnn_httpfilterpolicy"
 	Consumer                = "htnn_consumer"
 	SR                      = "htnn_service_registry"
+	EnvoyFilter             = "htnn_envoyfilter"
 	TranslateDurationSuffix = "translate_duration_seconds"
 	ReconcileDurationSuffix = "reconcile_duration_seconds"
+	ResourcesSuffix         = "resources"
+
+	LabelState     = "state"
+	LabelRegistry  = "registry"
+	LabelOperation = "operation"
+
+	StateAccepted       = "accepted"
+	StateInvalid        = "invalid"
+	StateTargetNotFound = "target_not_found"
+
+	OperationCreate = "create"
+	OperationUpdate = "update"
+	OperationDelete = "delete"
 )
 
 type voidMetric struct {
@@ -33,11 +47,24 @@ type voidMetric struct {
 
 func (m *voidMetric) Record(value float64) {}
 
+func (m *voidMetric) Set(value float64, labelValues ...string) {}
+
+func (m *voidMetric) Increment(labelValues ...string) {}
+
 var (
 	HFPTranslateDurationDistribution             component.Distribution = &voidMetric{}
 	HFPReconcileDurationDistribution             component.Distribution = &voidMetric{}
 	ConsumerReconcileDurationDistribution        component.Distribution = &voidMetric{}
 	ServiceRegistryReconcileDurationDistribution component.Distribution = &voidMetric{}
+	EnvoyFilterSizeDistribution                  component.Distribution = &voidMetric{}
+
+	HFPResourcesGauge                  component.Gauge = &voidMetric{}
+	ConsumerResourcesGauge             component.Gauge = &voidMetric{}
+	ServiceRegistryResourcesGauge      component.Gauge = &voidMetric{}
+	ServiceRegistryServiceEntriesGauge component.Gauge = &voidMetric{}
+
+	EnvoyFilterOperationsCounter       component.Counter = &voidMetric{}
+	EnvoyFilterOperationFailuresCounter component.Counter = &voidMetric{}
 )
 
 func InitMetrics(provider component.MetricProvider) {
@@ -62,4 +89,35 @@ func InitMetrics(provider component.MetricProvider) {
 		// minimal: 100 microseconds
 		[]float64{1e-4, 1e-3, 0.01, 0.1, 1, 10},
 	)
+	EnvoyFilterSizeDistribution = provider.NewDistribution(fmt.Sprintf("%s_size_bytes", EnvoyFilter),
+		"The size in bytes of the EnvoyFilter generated by HTNN.",
+		// minimal: 1KB. The size of a K8S object is limited to about 1.5MB.
+		[]float64{1e3, 1e4, 1e5, 1e6, 1e7},
+	)
+
+	HFPResourcesGauge = provider.NewGauge(fmt.Sprintf("%s_%s", HFP, ResourcesSuffix),
+		"The number of HTTPFilterPolicy, split by whether it is accepted, invalid or its target is not found.",
+		LabelState,
+	)
+	ConsumerResourcesGauge = provider.NewGauge(fmt.Sprintf("%s_%s", Consumer, ResourcesSuffix),
+		"The number of Consumer, split by whether it is accepted or invalid.",
+		LabelState,
+	)
+	ServiceRegistryResourcesGauge = provider.NewGauge(fmt.Sprintf("%s_%s", SR, ResourcesSuffix),
+		"The number of ServiceRegistry, split by whether it is accepted or invalid.",
+		LabelState,
+	)
+	ServiceRegistryServiceEntriesGauge = provider.NewGauge(fmt.Sprintf("%s_service_entries", SR),
+		"The number of ServiceEntries generated from each ServiceRegistry.",
+		LabelRegistry,
+	)
+
+	EnvoyFilterOperationsCounter = provider.NewCounter(fmt.Sprintf("%s_operations", EnvoyFilter),
+		"The number of EnvoyFilter successfully written by HTNN, split by create, update or delete.",
+		LabelOperation,
+	)
+	EnvoyFilterOperationFailuresCounter = provider.NewCounter(fmt.Sprintf("%s_operation_failures", EnvoyFilter),
+		"The number of failed EnvoyFilter writes, split by create, update or delete.",
+		LabelOperation,
+	)
 }
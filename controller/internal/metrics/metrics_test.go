@@ -24,6 +24,8 @@ import (
 
 type metricProvider struct {
 	distributions int
+	gauges        map[string][]string
+	counters      map[string][]string
 }
 
 func (m *metricProvider) NewDistribution(name string, description string, buckets []float64) component.Distribution {
@@ -31,8 +33,31 @@ func (m *metricProvider) NewDistribution(name string, description string, bucket
 	return nil
 }
 
+func (m *metricProvider) NewGauge(name string, description string, labelNames ...string) component.Gauge {
+	m.gauges[name] = labelNames
+	return nil
+}
+
+func (m *metricProvider) NewCounter(name string, description string, labelNames ...string) component.Counter {
+	m.counters[name] = labelNames
+	return nil
+}
+
 func TestInitMetrics(t *testing.T) {
-	p := &metricProvider{}
+	p := &metricProvider{
+		gauges:   map[string][]string{},
+		counters: map[string][]string{},
+	}
 	InitMetrics(p)
-	assert.Equal(t, 4, p.distributions)
+	assert.Equal(t, 5, p.distributions)
+	assert.Equal(t, map[string][]string{
+		"htnn_httpfilterpolicy_resources":       {"state"},
+		"htnn_consumer_resources":               {"state"},
+		"htnn_service_registry_resources":       {"state"},
+		"htnn_service_registry_service_entries": {"registry"},
+	}, p.gauges)
+	assert.Equal(t, map[string][]string{
+		"htnn_envoyfilter_operations":         {"operation"},
+		"htnn_envoyfilter_operation_failures": {"operation"},
+	}, p.counters)
 }
@@ -45,7 +45,7 @@ func UpdateRegistry(registry *mosniov1.ServiceRegistry, prevServiceRegistry *mos
 
 	key := types.NamespacedName{Namespace: registry.Namespace, Name: registry.Name}
 	if reg, ok := registries[key]; !ok {
-		reg, err := pkgRegistry.CreateRegistry(registry.Spec.Type, store, registry.ObjectMeta)
+		reg, err := pkgRegistry.CreateRegistry(registry.Spec.Type, store.forRegistry(key.String()), registry.ObjectMeta)
 		if err != nil {
 			return err
 		}
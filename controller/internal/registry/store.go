@@ -22,6 +22,7 @@ import (
 	istioapi "istio.io/api/networking/v1alpha3"
 
 	"mosn.io/htnn/controller/internal/log"
+	"mosn.io/htnn/controller/internal/metrics"
 	"mosn.io/htnn/controller/pkg/component"
 	pkgRegistry "mosn.io/htnn/controller/pkg/registry"
 )
@@ -31,23 +32,68 @@ type serviceEntryStore struct {
 
 	lock    sync.RWMutex
 	entries map[string]*istioapi.ServiceEntry
+	// the registry which the service comes from
+	registries map[string]string
+	// the number of services from each registry
+	counts map[string]int
 }
 
 func newServiceEntryStore(output component.Output) *serviceEntryStore {
 	return &serviceEntryStore{
-		output:  output,
-		entries: make(map[string]*istioapi.ServiceEntry),
+		output:     output,
+		entries:    make(map[string]*istioapi.ServiceEntry),
+		registries: make(map[string]string),
+		counts:     make(map[string]int),
 	}
 }
 
+// forRegistry returns a view of the store which tracks the services from the given registry
+func (store *serviceEntryStore) forRegistry(registry string) pkgRegistry.ServiceEntryStore {
+	return &registryServiceEntryStore{
+		serviceEntryStore: store,
+		registry:          registry,
+	}
+}
+
+func (store *serviceEntryStore) trackService(service string, registry string) {
+	prev, ok := store.registries[service]
+	if ok && prev == registry {
+		return
+	}
+	if ok {
+		store.counts[prev]--
+		metrics.ServiceRegistryServiceEntriesGauge.Set(float64(store.counts[prev]), prev)
+	}
+
+	store.registries[service] = registry
+	store.counts[registry]++
+	metrics.ServiceRegistryServiceEntriesGauge.Set(float64(store.counts[registry]), registry)
+}
+
+func (store *serviceEntryStore) untrackService(service string) {
+	registry, ok := store.registries[service]
+	if !ok {
+		return
+	}
+
+	delete(store.registries, service)
+	store.counts[registry]--
+	metrics.ServiceRegistryServiceEntriesGauge.Set(float64(store.counts[registry]), registry)
+}
+
 // Implement ServiceEntryStore interface
 
 func (store *serviceEntryStore) Update(service string, se *pkgRegistry.ServiceEntryWrapper) {
+	store.update("", service, se)
+}
+
+func (store *serviceEntryStore) update(registry string, service string, se *pkgRegistry.ServiceEntryWrapper) {
 	store.lock.Lock()
 	defer store.lock.Unlock()
 
 	log.Infof("service entry store updates service: %s, entry: %v", service, &se.ServiceEntry)
 	ctx := context.Background()
+	store.trackService(service, registry)
 
 	if prev, ok := store.entries[service]; ok {
 		// Some registry SDKs may send the same service entry multiple times. For example, at least in
@@ -73,5 +119,16 @@ func (store *serviceEntryStore) Delete(service string) {
 
 	log.Infof("service entry store deletes service: %s", service)
 	delete(store.entries, service)
+	store.untrackService(service)
 	store.output.FromServiceRegistry(context.Background(), store.entries)
 }
+
+type registryServiceEntryStore struct {
+	*serviceEntryStore
+
+	registry string
+}
+
+func (store *registryServiceEntryStore) Update(service string, se *pkgRegistry.ServiceEntryWrapper) {
+	store.serviceEntryStore.update(store.registry, service, se)
+}
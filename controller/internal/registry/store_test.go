@@ -22,6 +22,7 @@ import (
 	istioapi "istio.io/api/networking/v1alpha3"
 
 	"mosn.io/htnn/controller/internal/controller/component"
+	"mosn.io/htnn/controller/internal/metrics"
 	pkgRegistry "mosn.io/htnn/controller/pkg/registry"
 	"mosn.io/htnn/controller/tests/pkg"
 )
@@ -52,3 +53,49 @@ func TestStoreRepeatedUpdate(t *testing.T) {
 
 	require.Equal(t, 1, counter)
 }
+
+type fakeGauge struct {
+	values map[string]float64
+}
+
+func (g *fakeGauge) Set(value float64, labelValues ...string) {
+	g.values[labelValues[0]] = value
+}
+
+func TestStoreServiceEntriesPerRegistry(t *testing.T) {
+	client := pkg.FakeK8sClient(t)
+	out := component.NewK8sOutput(client)
+	patches := gomonkey.ApplyMethodFunc(out, "FromServiceRegistry", func(ctx interface{}, serviceEntries map[string]*istioapi.ServiceEntry) {
+	})
+	defer patches.Reset()
+
+	gauge := &fakeGauge{values: map[string]float64{}}
+	prev := metrics.ServiceRegistryServiceEntriesGauge
+	metrics.ServiceRegistryServiceEntriesGauge = gauge
+	defer func() { metrics.ServiceRegistryServiceEntriesGauge = prev }()
+
+	store := newServiceEntryStore(out)
+	a := store.forRegistry("default/a")
+	b := store.forRegistry("default/b")
+	newEntry := func(host string) *pkgRegistry.ServiceEntryWrapper {
+		return &pkgRegistry.ServiceEntryWrapper{
+			ServiceEntry: istioapi.ServiceEntry{
+				Hosts: []string{host},
+			},
+		}
+	}
+
+	a.Update("x", newEntry("x.nacos"))
+	a.Update("y", newEntry("y.nacos"))
+	b.Update("z", newEntry("z.nacos"))
+	require.Equal(t, map[string]float64{"default/a": 2, "default/b": 1}, gauge.values)
+
+	// the service is moved to another registry
+	b.Update("y", newEntry("y2.nacos"))
+	require.Equal(t, map[string]float64{"default/a": 1, "default/b": 2}, gauge.values)
+
+	a.Delete("x")
+	b.Delete("z")
+	b.Delete("unknown")
+	require.Equal(t, map[string]float64{"default/a": 0, "default/b": 1}, gauge.values)
+}
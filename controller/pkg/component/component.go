@@ -63,8 +63,24 @@ type Distribution interface {
 	Record(value float64)
 }
 
+type Gauge interface {
+	// Set sets the value of the gauge. The labelValues are the values of the label names given
+	// when creating the gauge, in the same order.
+	Set(value float64, labelValues ...string)
+}
+
+type Counter interface {
+	// Increment adds one to the counter. The labelValues are the values of the label names given
+	// when creating the counter, in the same order.
+	Increment(labelValues ...string)
+}
+
 type MetricProvider interface {
 	// NewDistribution creates a new Metric type called Distribution. This means that the
 	// data collected by the Metric will be collected and exported as a histogram, with the specified bounds.
 	NewDistribution(name, description string, bounds []float64) Distribution
+	// NewGauge creates a new Metric type called Gauge, which can go up and down, with the specified label names.
+	NewGauge(name, description string, labelNames ...string) Gauge
+	// NewCounter creates a new Metric type called Counter, which only goes up, with the specified label names.
+	NewCounter(name, description string, labelNames ...string) Counter
 }
@@ -0,0 +1,44 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package redact hides the sensitive values, like the credentials in the headers, before they
+// are written to the logs.
+package redact
+
+import (
+	"strings"
+)
+
+const (
+	// Value replaces the redacted values
+	Value = "***"
+)
+
+var (
+	// DefaultHeaders are the headers which are always redacted
+	DefaultHeaders = []string{"authorization", "proxy-authorization", "cookie", "set-cookie"}
+)
+
+// HeaderSet returns the lowercase names of the headers to redact, which are the default ones
+// plus the given ones
+func HeaderSet(headers []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(DefaultHeaders)+len(headers))
+	for _, h := range DefaultHeaders {
+		set[h] = struct{}{}
+	}
+	for _, h := range headers {
+		set[strings.ToLower(h)] = struct{}{}
+	}
+	return set
+}
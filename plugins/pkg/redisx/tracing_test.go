@@ -0,0 +1,94 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redisx
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"mosn.io/htnn/api/pkg/tracing"
+	"mosn.io/htnn/api/plugins/tests/pkg/envoy"
+)
+
+func TestTracingHook(t *testing.T) {
+	require.Nil(t, tracing.SetPropagators("tracecontext"))
+	defer tracing.SetPropagators("")
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	defer provider.Shutdown(context.Background())
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "plugin")
+	defer parent.End()
+
+	hook := TracingHook{}
+	process := hook.ProcessHook(func(ctx context.Context, cmd redis.Cmder) error {
+		return redis.Nil
+	})
+	cmd := redis.NewCmd(ctx, "get", "key")
+	assert.Equal(t, redis.Nil, process(ctx, cmd))
+
+	pipeline := hook.ProcessPipelineHook(func(ctx context.Context, cmds []redis.Cmder) error {
+		return errors.New("timeout")
+	})
+	cmds := []redis.Cmder{redis.NewCmd(ctx, "incr", "key"), redis.NewCmd(ctx, "expire", "key", 1)}
+	assert.NotNil(t, pipeline(ctx, cmds))
+
+	// no span in the context
+	assert.Equal(t, redis.Nil, process(context.Background(), cmd))
+
+	spans := recorder.Ended()
+	require.Equal(t, 2, len(spans))
+	assert.Equal(t, "redis get", spans[0].Name())
+	assert.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent().SpanID())
+	assert.Equal(t, codes.Unset, spans[0].Status().Code)
+	assert.Equal(t, "redis pipeline", spans[1].Name())
+	assert.Equal(t, codes.Error, spans[1].Status().Code)
+}
+
+func TestTracingHookWithRequest(t *testing.T) {
+	require.Nil(t, tracing.SetPropagators("tracecontext"))
+	defer tracing.SetPropagators("")
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	defer provider.Shutdown(context.Background())
+	pluginCtx, parent := provider.Tracer("test").Start(context.Background(), "plugin")
+	defer parent.End()
+
+	// the span of the running plugin is set by the pluginTracing plugin
+	cb := envoy.NewFilterCallbackHandler()
+	cb.PluginState().Set(tracing.StateNamespace, tracing.StateKeyCurrent, pluginCtx)
+	ctx := tracing.ContextWithRequest(context.Background(), cb, envoy.NewRequestHeaderMap(http.Header{}))
+
+	hook := TracingHook{}
+	process := hook.ProcessHook(func(ctx context.Context, cmd redis.Cmder) error {
+		return nil
+	})
+	assert.Nil(t, process(ctx, redis.NewCmd(ctx, "get", "key")))
+
+	spans := recorder.Ended()
+	require.Equal(t, 1, len(spans))
+	assert.Equal(t, "redis get", spans[0].Name())
+	assert.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent().SpanID())
+}
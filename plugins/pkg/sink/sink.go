@@ -0,0 +1,532 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sink delivers the records, like the access logs, to the external storage in batches.
+package sink
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"google.golang.org/protobuf/proto"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	v1 "mosn.io/htnn/types/plugins/api/v1"
+)
+
+const (
+	defaultTimeout    = 10 * time.Second
+	defaultMaxBackups = 5
+)
+
+var (
+	// The writers are shared by the configurations with the same sink, so the records are not
+	// lost and the file is not reopened when the routes are updated. A writer is closed once
+	// no configuration references it.
+	writers     = map[string]*sharedWriter{}
+	writersLock sync.Mutex
+
+	// The file sinks are shared by path, so that the writers with different batch options
+	// don't rotate the same file independently.
+	fileSinks     = map[string]*sharedFileSink{}
+	fileSinksLock sync.Mutex
+)
+
+type sharedWriter struct {
+	*BatchWriter
+	refs    int
+	release func()
+}
+
+type sharedFileSink struct {
+	*FileSink
+	refs int
+}
+
+// Sink writes a batch of records. Each record is a JSON object.
+// Write is called by a single goroutine.
+type Sink interface {
+	Write(records [][]byte) error
+}
+
+// FileSink appends the records to a file, one record per line. It is safe to be written by
+// multiple writers.
+type FileSink struct {
+	lock sync.Mutex
+	path string
+	file *os.File
+
+	maxSize    int64
+	maxBackups int
+	size       int64
+}
+
+func NewFileSink(path string) *FileSink {
+	return &FileSink{path: path}
+}
+
+// NewRotatingFileSink returns a FileSink which rotates the file when its size exceeds maxSize.
+// The rotated files are named like `$path.1`, and at most maxBackups of them are kept.
+func NewRotatingFileSink(path string, maxSize int64, maxBackups int) *FileSink {
+	return &FileSink{
+		path:       path,
+		maxSize:    maxSize,
+		maxBackups: maxBackups,
+	}
+}
+
+func (s *FileSink) open() error {
+	f, err := os.OpenFile(s.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return err
+	}
+	st, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	s.file = f
+	s.size = st.Size()
+	return nil
+}
+
+func (s *FileSink) rotate() error {
+	s.file.Close()
+	s.file = nil
+
+	if s.maxBackups == 0 {
+		return os.Remove(s.path)
+	}
+	for i := s.maxBackups - 1; i >= 1; i-- {
+		err := os.Rename(fmt.Sprintf("%s.%d", s.path, i), fmt.Sprintf("%s.%d", s.path, i+1))
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	return os.Rename(s.path, s.path+".1")
+}
+
+func (s *FileSink) Write(records [][]byte) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.file == nil {
+		if err := s.open(); err != nil {
+			return err
+		}
+	}
+
+	var buf bytes.Buffer
+	for _, record := range records {
+		buf.Write(record)
+		buf.WriteByte('\n')
+	}
+
+	if s.maxSize > 0 && s.size > 0 && s.size+int64(buf.Len()) > s.maxSize {
+		if err := s.rotate(); err != nil {
+			return err
+		}
+		if err := s.open(); err != nil {
+			return err
+		}
+	}
+
+	n, err := s.file.Write(buf.Bytes())
+	s.size += int64(n)
+	return err
+}
+
+func (s *FileSink) setRotation(maxSize int64, maxBackups int) {
+	s.lock.Lock()
+	s.maxSize = maxSize
+	s.maxBackups = maxBackups
+	s.lock.Unlock()
+}
+
+// Close closes the file. The file will be reopened if the sink is written again.
+func (s *FileSink) Close() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.file == nil {
+		return nil
+	}
+	err := s.file.Close()
+	s.file = nil
+	return err
+}
+
+// HTTPSink posts the records to the given URL as a JSON array
+type HTTPSink struct {
+	url     string
+	headers map[string]string
+	client  *http.Client
+}
+
+func NewHTTPSink(url string, headers map[string]string, timeout time.Duration) *HTTPSink {
+	return &HTTPSink{
+		url:     url,
+		headers: headers,
+		client:  &http.Client{Timeout: timeout},
+	}
+}
+
+func (s *HTTPSink) Write(records [][]byte) error {
+	var buf bytes.Buffer
+	buf.WriteByte('[')
+	for i, record := range records {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		buf.Write(record)
+	}
+	buf.WriteByte(']')
+
+	req, err := http.NewRequest(http.MethodPost, s.url, &buf)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range s.headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	// drain the body so the connection can be reused
+	_, _ = io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (s *HTTPSink) Close() error {
+	s.client.CloseIdleConnections()
+	return nil
+}
+
+type kafkaWriter interface {
+	WriteMessages(ctx context.Context, msgs ...kafka.Message) error
+	Close() error
+}
+
+// KafkaSink produces each record as a message to the topic
+type KafkaSink struct {
+	writer  kafkaWriter
+	timeout time.Duration
+}
+
+func NewKafkaSink(brokers []string, topic string, timeout time.Duration) *KafkaSink {
+	return &KafkaSink{
+		writer: &kafka.Writer{
+			Addr:         kafka.TCP(brokers...),
+			Topic:        topic,
+			Balancer:     &kafka.LeastBytes{},
+			WriteTimeout: timeout,
+			// The records are already batched by the BatchWriter, don't wait for more
+			BatchTimeout: time.Millisecond,
+		},
+		timeout: timeout,
+	}
+}
+
+func (s *KafkaSink) Write(records [][]byte) error {
+	msgs := make([]kafka.Message, len(records))
+	for i, record := range records {
+		msgs[i] = kafka.Message{Value: record}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+	return s.writer.WriteMessages(ctx, msgs...)
+}
+
+func (s *KafkaSink) Close() error {
+	return s.writer.Close()
+}
+
+type BatchOptions struct {
+	// The maximum number of records sent in a batch
+	MaxRecords int
+	// The maximum time to wait before sending a batch
+	FlushInterval time.Duration
+	// The maximum number of records waiting to be sent
+	QueueSize int
+	// OnError is called when the sink fails to write or records are dropped
+	OnError func(err error)
+}
+
+// BatchWriter collects the records and writes them to the sink asynchronously
+type BatchWriter struct {
+	sink Sink
+	opts BatchOptions
+	// key in the shared writers, empty if the writer is not created by GetWriter
+	key string
+
+	ch      chan []byte
+	closing chan struct{}
+	done    chan struct{}
+	once    sync.Once
+
+	lock    sync.Mutex
+	dropped int
+}
+
+func NewBatchWriter(sink Sink, opts BatchOptions) *BatchWriter {
+	if opts.MaxRecords <= 0 {
+		opts.MaxRecords = 100
+	}
+	if opts.FlushInterval <= 0 {
+		opts.FlushInterval = time.Second
+	}
+	if opts.QueueSize <= 0 {
+		opts.QueueSize = 10000
+	}
+	if opts.OnError == nil {
+		opts.OnError = func(err error) {}
+	}
+
+	w := &BatchWriter{
+		sink:    sink,
+		opts:    opts,
+		ch:      make(chan []byte, opts.QueueSize),
+		closing: make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	go w.run()
+	return w
+}
+
+// Add queues the record. It doesn't block. The record is dropped if the queue is full.
+func (w *BatchWriter) Add(record []byte) {
+	select {
+	case w.ch <- record:
+	default:
+		w.lock.Lock()
+		w.dropped++
+		w.lock.Unlock()
+	}
+}
+
+// Close flushes the queued records and stops the writer
+func (w *BatchWriter) Close() {
+	w.once.Do(func() {
+		close(w.closing)
+	})
+	<-w.done
+}
+
+func (w *BatchWriter) flush(batch [][]byte) {
+	w.lock.Lock()
+	dropped := w.dropped
+	w.dropped = 0
+	w.lock.Unlock()
+	if dropped > 0 {
+		// report the dropped records in batch to avoid flooding the log
+		w.opts.OnError(fmt.Errorf("queue is full, %d records dropped", dropped))
+	}
+
+	if len(batch) == 0 {
+		return
+	}
+	if err := w.sink.Write(batch); err != nil {
+		w.opts.OnError(fmt.Errorf("failed to write %d records: %w", len(batch), err))
+	}
+}
+
+func (w *BatchWriter) run() {
+	defer close(w.done)
+
+	ticker := time.NewTicker(w.opts.FlushInterval)
+	defer ticker.Stop()
+
+	batch := make([][]byte, 0, w.opts.MaxRecords)
+	for {
+		select {
+		case record := <-w.ch:
+			batch = append(batch, record)
+			if len(batch) >= w.opts.MaxRecords {
+				w.flush(batch)
+				batch = make([][]byte, 0, w.opts.MaxRecords)
+			}
+		case <-ticker.C:
+			w.flush(batch)
+			batch = make([][]byte, 0, w.opts.MaxRecords)
+		case <-w.closing:
+			for {
+				select {
+				case record := <-w.ch:
+					batch = append(batch, record)
+					if len(batch) >= w.opts.MaxRecords {
+						w.flush(batch)
+						batch = make([][]byte, 0, w.opts.MaxRecords)
+					}
+				default:
+					w.flush(batch)
+					return
+				}
+			}
+		}
+	}
+}
+
+// NewSink creates the Sink from the configuration
+func NewSink(c *v1.Sink) Sink {
+	switch {
+	case c.GetFile() != nil:
+		file := c.GetFile()
+		maxBackups := defaultMaxBackups
+		if file.MaxBackups != 0 {
+			maxBackups = int(file.MaxBackups)
+		}
+		return NewRotatingFileSink(file.Path, int64(file.MaxSize), maxBackups)
+	case c.GetKafka() != nil:
+		k := c.GetKafka()
+		timeout := defaultTimeout
+		if k.Timeout != nil {
+			timeout = k.Timeout.AsDuration()
+		}
+		return NewKafkaSink(k.Brokers, k.Topic, timeout)
+	default:
+		h := c.GetHttp()
+		timeout := defaultTimeout
+		if h.Timeout != nil {
+			timeout = h.Timeout.AsDuration()
+		}
+		return NewHTTPSink(h.Url, h.Headers, timeout)
+	}
+}
+
+func acquireFileSink(c *v1.FileSink) *FileSink {
+	maxBackups := defaultMaxBackups
+	if c.MaxBackups != 0 {
+		maxBackups = int(c.MaxBackups)
+	}
+
+	fileSinksLock.Lock()
+	defer fileSinksLock.Unlock()
+
+	if s, ok := fileSinks[c.Path]; ok {
+		s.refs++
+		// the latest configuration wins
+		s.setRotation(int64(c.MaxSize), maxBackups)
+		return s.FileSink
+	}
+
+	s := NewRotatingFileSink(c.Path, int64(c.MaxSize), maxBackups)
+	fileSinks[c.Path] = &sharedFileSink{FileSink: s, refs: 1}
+	return s
+}
+
+func releaseFileSink(path string) {
+	fileSinksLock.Lock()
+	defer fileSinksLock.Unlock()
+
+	s, ok := fileSinks[path]
+	if !ok {
+		return
+	}
+	s.refs--
+	if s.refs == 0 {
+		delete(fileSinks, path)
+		s.Close()
+	}
+}
+
+// GetWriter returns the BatchWriter which writes to the configured sink. The writer is shared
+// by the configurations from the same plugin with the same sink and batch options, and the file
+// sink is shared by path. The name is used as the prefix of the error log. The caller should
+// call ReleaseWriter once the writer is no longer used.
+func GetWriter(name string, c *v1.Sink, batch *v1.Batch) (*BatchWriter, error) {
+	opts := proto.MarshalOptions{Deterministic: true}
+	sinkKey, err := opts.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+	batchKey, err := opts.Marshal(batch)
+	if err != nil {
+		return nil, err
+	}
+	key := name + "|" + string(sinkKey) + "|" + string(batchKey)
+
+	writersLock.Lock()
+	defer writersLock.Unlock()
+
+	if w, ok := writers[key]; ok {
+		w.refs++
+		return w.BatchWriter, nil
+	}
+
+	var s Sink
+	var release func()
+	if file := c.GetFile(); file != nil {
+		s = acquireFileSink(file)
+		release = func() {
+			releaseFileSink(file.Path)
+		}
+	} else {
+		s = NewSink(c)
+		release = func() {
+			if closer, ok := s.(io.Closer); ok {
+				closer.Close()
+			}
+		}
+	}
+
+	w := NewBatchWriter(s, BatchOptions{
+		MaxRecords:    int(batch.GetMaxRecords()),
+		FlushInterval: batch.GetFlushInterval().AsDuration(),
+		QueueSize:     int(batch.GetQueueSize()),
+		OnError: func(err error) {
+			api.LogErrorf("%s: %v", name, err)
+		},
+	})
+	w.key = key
+	writers[key] = &sharedWriter{BatchWriter: w, refs: 1, release: release}
+	return w, nil
+}
+
+// ReleaseWriter drops a reference to the writer returned by GetWriter. When the writer is no
+// longer referenced, the queued records are flushed and the writer is closed in background.
+func ReleaseWriter(w *BatchWriter) {
+	writersLock.Lock()
+	defer writersLock.Unlock()
+
+	sw, ok := writers[w.key]
+	if !ok || sw.BatchWriter != w {
+		return
+	}
+	sw.refs--
+	if sw.refs > 0 {
+		return
+	}
+	delete(writers, w.key)
+	// flushing may take a while, don't block the caller
+	go func() {
+		sw.Close()
+		sw.release()
+	}()
+}
@@ -0,0 +1,304 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sink
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	v1 "mosn.io/htnn/types/plugins/api/v1"
+)
+
+func TestFileSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "access.log")
+	s := NewFileSink(path)
+	require.Nil(t, s.Write([][]byte{[]byte(`{"a":1}`), []byte(`{"a":2}`)}))
+	require.Nil(t, s.Write([][]byte{[]byte(`{"a":3}`)}))
+
+	b, err := os.ReadFile(path)
+	require.Nil(t, err)
+	assert.Equal(t, "{\"a\":1}\n{\"a\":2}\n{\"a\":3}\n", string(b))
+
+	s = NewFileSink(filepath.Join(t.TempDir(), "not-exist", "access.log"))
+	assert.NotNil(t, s.Write([][]byte{[]byte(`{}`)}))
+}
+
+func TestFileSinkRotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "slow.log")
+	s := NewRotatingFileSink(path, 10, 2)
+	for _, r := range []string{"1234", "5678", "abcd", "efgh", "ijkl"} {
+		require.Nil(t, s.Write([][]byte{[]byte(r)}))
+	}
+
+	read := func(path string) string {
+		b, _ := os.ReadFile(path)
+		return string(b)
+	}
+	assert.Equal(t, "ijkl\n", read(path))
+	assert.Equal(t, "abcd\nefgh\n", read(path+".1"))
+	assert.Equal(t, "1234\n5678\n", read(path+".2"))
+
+	// the oldest file is removed
+	require.Nil(t, s.Write([][]byte{[]byte("mnop"), []byte("qrst")}))
+	assert.Equal(t, "mnop\nqrst\n", read(path))
+	assert.Equal(t, "ijkl\n", read(path+".1"))
+	assert.Equal(t, "abcd\nefgh\n", read(path+".2"))
+	_, err := os.Stat(path + ".3")
+	assert.True(t, os.IsNotExist(err))
+
+	// the size of the existing file is counted
+	s = NewRotatingFileSink(path, 10, 0)
+	require.Nil(t, s.Write([][]byte{[]byte("u")}))
+	assert.Equal(t, "u\n", read(path))
+	assert.Equal(t, "ijkl\n", read(path+".1"))
+}
+
+// kafkaStandIn records the messages in memory instead of sending them to the Kafka brokers
+type kafkaStandIn struct {
+	msgs []kafka.Message
+	err  error
+}
+
+func (w *kafkaStandIn) WriteMessages(ctx context.Context, msgs ...kafka.Message) error {
+	if _, ok := ctx.Deadline(); !ok {
+		return errors.New("deadline is required")
+	}
+	w.msgs = append(w.msgs, msgs...)
+	return w.err
+}
+
+func (w *kafkaStandIn) Close() error {
+	return nil
+}
+
+func TestKafkaSink(t *testing.T) {
+	s := NewKafkaSink([]string{"127.0.0.1:9092"}, "slow", time.Second)
+	w := &kafkaStandIn{}
+	s.writer = w
+
+	require.Nil(t, s.Write([][]byte{[]byte(`{"a":1}`), []byte(`{"a":2}`)}))
+	require.Equal(t, 2, len(w.msgs))
+	assert.Equal(t, `{"a":1}`, string(w.msgs[0].Value))
+	assert.Equal(t, `{"a":2}`, string(w.msgs[1].Value))
+
+	w.err = errors.New("leader not available")
+	assert.ErrorContains(t, s.Write([][]byte{[]byte(`{}`)}), "leader not available")
+}
+
+func TestNewSink(t *testing.T) {
+	s := NewSink(&v1.Sink{Sink: &v1.Sink_File{File: &v1.FileSink{Path: "/tmp/a.log", MaxSize: 1024}}})
+	assert.Equal(t, &FileSink{path: "/tmp/a.log", maxSize: 1024, maxBackups: 5}, s)
+
+	s = NewSink(&v1.Sink{Sink: &v1.Sink_Kafka{Kafka: &v1.KafkaSink{
+		Brokers: []string{"127.0.0.1:9092"},
+		Topic:   "slow",
+		Timeout: durationpb.New(time.Second),
+	}}})
+	ks := s.(*KafkaSink)
+	assert.Equal(t, time.Second, ks.timeout)
+	assert.Equal(t, "slow", ks.writer.(*kafka.Writer).Topic)
+
+	s = NewSink(&v1.Sink{Sink: &v1.Sink_Http{Http: &v1.HttpSink{Url: "http://127.0.0.1/log"}}})
+	assert.Equal(t, defaultTimeout, s.(*HTTPSink).client.Timeout)
+}
+
+func TestGetWriter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "access.log")
+	c := &v1.Sink{Sink: &v1.Sink_File{File: &v1.FileSink{Path: path}}}
+	w1, err := GetWriter("test", c, nil)
+	require.Nil(t, err)
+	w2, err := GetWriter("test", &v1.Sink{Sink: &v1.Sink_File{File: &v1.FileSink{Path: path}}}, nil)
+	require.Nil(t, err)
+	assert.Same(t, w1, w2)
+
+	w3, err := GetWriter("test", c, &v1.Batch{MaxRecords: 1})
+	require.Nil(t, err)
+	assert.NotSame(t, w1, w3)
+	// the file is shared by path
+	assert.Same(t, w1.sink, w3.sink)
+
+	w4, err := GetWriter("another", c, nil)
+	require.Nil(t, err)
+	assert.NotSame(t, w1, w4)
+
+	w4.Add([]byte("4"))
+	ReleaseWriter(w4)
+	ReleaseWriter(w1)
+	ReleaseWriter(w3)
+
+	writersLock.Lock()
+	assert.Equal(t, 1, len(writers))
+	writersLock.Unlock()
+	assert.Eventually(t, func() bool {
+		fileSinksLock.Lock()
+		defer fileSinksLock.Unlock()
+		return fileSinks[path].refs == 1
+	}, time.Second, 10*time.Millisecond)
+
+	// the released writer flushes the queued records before closing
+	assert.Eventually(t, func() bool {
+		b, _ := os.ReadFile(path)
+		return string(b) == "4\n"
+	}, time.Second, 10*time.Millisecond)
+
+	ReleaseWriter(w2)
+	assert.Eventually(t, func() bool {
+		fileSinksLock.Lock()
+		defer fileSinksLock.Unlock()
+		return len(fileSinks) == 0
+	}, time.Second, 10*time.Millisecond)
+	writersLock.Lock()
+	assert.Equal(t, 0, len(writers))
+	writersLock.Unlock()
+	assert.Nil(t, w1.sink.(*FileSink).file)
+
+	// a new writer is created after all the references are released
+	w5, err := GetWriter("test", c, nil)
+	require.Nil(t, err)
+	assert.NotSame(t, w1, w5)
+	ReleaseWriter(w5)
+}
+
+func TestHTTPSink(t *testing.T) {
+	var body []byte
+	var header http.Header
+	status := 200
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		header = r.Header
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	s := NewHTTPSink(srv.URL, map[string]string{"Authorization": "token"}, time.Second)
+	require.Nil(t, s.Write([][]byte{[]byte(`{"a":1}`), []byte(`{"a":2}`)}))
+	assert.Equal(t, `[{"a":1},{"a":2}]`, string(body))
+	assert.Equal(t, "application/json", header.Get("Content-Type"))
+	assert.Equal(t, "token", header.Get("Authorization"))
+
+	status = 503
+	assert.ErrorContains(t, s.Write([][]byte{[]byte(`{}`)}), "unexpected status code: 503")
+}
+
+type memorySink struct {
+	lock    sync.Mutex
+	batches [][][]byte
+	err     error
+}
+
+func (s *memorySink) Write(records [][]byte) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.batches = append(s.batches, records)
+	return s.err
+}
+
+func (s *memorySink) Batches() [][][]byte {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.batches
+}
+
+func TestBatchWriter(t *testing.T) {
+	s := &memorySink{}
+	w := NewBatchWriter(s, BatchOptions{
+		MaxRecords:    2,
+		FlushInterval: time.Hour,
+	})
+	w.Add([]byte("1"))
+	w.Add([]byte("2"))
+	w.Add([]byte("3"))
+	assert.Eventually(t, func() bool {
+		return len(s.Batches()) == 1
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, [][]byte{[]byte("1"), []byte("2")}, s.Batches()[0])
+
+	// the rest is flushed when closing
+	w.Close()
+	require.Equal(t, 2, len(s.Batches()))
+	assert.Equal(t, [][]byte{[]byte("3")}, s.Batches()[1])
+}
+
+func TestBatchWriterFlushInterval(t *testing.T) {
+	s := &memorySink{}
+	w := NewBatchWriter(s, BatchOptions{
+		FlushInterval: 50 * time.Millisecond,
+	})
+	defer w.Close()
+
+	w.Add([]byte("1"))
+	assert.Eventually(t, func() bool {
+		return len(s.Batches()) == 1
+	}, time.Second, 10*time.Millisecond)
+}
+
+type blockingSink struct {
+	entered chan struct{}
+	release chan struct{}
+}
+
+func (s *blockingSink) Write(records [][]byte) error {
+	s.entered <- struct{}{}
+	<-s.release
+	return errors.New("ouch")
+}
+
+func TestBatchWriterError(t *testing.T) {
+	s := &blockingSink{
+		entered: make(chan struct{}, 2),
+		release: make(chan struct{}),
+	}
+	var lock sync.Mutex
+	var errs []string
+	w := NewBatchWriter(s, BatchOptions{
+		MaxRecords:    1,
+		QueueSize:     1,
+		FlushInterval: time.Hour,
+		OnError: func(err error) {
+			lock.Lock()
+			errs = append(errs, err.Error())
+			lock.Unlock()
+		},
+	})
+
+	w.Add([]byte("1"))
+	// the writer is blocked, so the queue is full after adding the second record
+	<-s.entered
+	w.Add([]byte("2"))
+	w.Add([]byte("3"))
+	close(s.release)
+	w.Close()
+
+	lock.Lock()
+	defer lock.Unlock()
+	assert.Equal(t, []string{
+		"failed to write 1 records: ouch",
+		"queue is full, 1 records dropped",
+		"failed to write 1 records: ouch",
+	}, errs)
+}
@@ -15,16 +15,35 @@
 package plugins
 
 import (
+	_ "mosn.io/htnn/plugins/plugins/access_log"
+	_ "mosn.io/htnn/plugins/plugins/buffer_limit"
 	_ "mosn.io/htnn/plugins/plugins/casbin"
 	_ "mosn.io/htnn/plugins/plugins/cel_script"
+	_ "mosn.io/htnn/plugins/plugins/circuit_breaker"
 	_ "mosn.io/htnn/plugins/plugins/consumer_restriction"
+	_ "mosn.io/htnn/plugins/plugins/csrf"
+	_ "mosn.io/htnn/plugins/plugins/data_masking"
 	_ "mosn.io/htnn/plugins/plugins/debug_mode"
 	_ "mosn.io/htnn/plugins/plugins/demo"
 	_ "mosn.io/htnn/plugins/plugins/ext_auth"
+	_ "mosn.io/htnn/plugins/plugins/header_rewrite"
 	_ "mosn.io/htnn/plugins/plugins/hmac_auth"
+	_ "mosn.io/htnn/plugins/plugins/ip_restriction"
+	_ "mosn.io/htnn/plugins/plugins/json_transform"
 	_ "mosn.io/htnn/plugins/plugins/key_auth"
 	_ "mosn.io/htnn/plugins/plugins/limit_count_redis"
 	_ "mosn.io/htnn/plugins/plugins/limit_req"
+	_ "mosn.io/htnn/plugins/plugins/load_shedding"
+	_ "mosn.io/htnn/plugins/plugins/mock_response"
 	_ "mosn.io/htnn/plugins/plugins/oidc"
 	_ "mosn.io/htnn/plugins/plugins/opa"
+	_ "mosn.io/htnn/plugins/plugins/plugin_tracing"
+	_ "mosn.io/htnn/plugins/plugins/quota"
+	_ "mosn.io/htnn/plugins/plugins/request_id"
+	_ "mosn.io/htnn/plugins/plugins/request_metrics"
+	_ "mosn.io/htnn/plugins/plugins/request_validation"
+	_ "mosn.io/htnn/plugins/plugins/response_cache"
+	_ "mosn.io/htnn/plugins/plugins/uri_rewrite"
+	_ "mosn.io/htnn/plugins/plugins/waf"
+	_ "mosn.io/htnn/plugins/plugins/webhook_signature"
 )
@@ -0,0 +1,79 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package access_log
+
+import (
+	"runtime"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/plugins"
+	"mosn.io/htnn/plugins/pkg/redact"
+	"mosn.io/htnn/plugins/pkg/sink"
+	"mosn.io/htnn/types/plugins/access_log"
+	v1 "mosn.io/htnn/types/plugins/api/v1"
+)
+
+func init() {
+	plugins.RegisterHttpPlugin(access_log.Name, &plugin{})
+}
+
+type plugin struct {
+	access_log.Plugin
+}
+
+func (p *plugin) Factory() api.FilterFactory {
+	return factory
+}
+
+func (p *plugin) Config() api.PluginConfig {
+	return &config{}
+}
+
+type config struct {
+	access_log.CustomConfig
+
+	sampleRate float64
+	redacted   map[string]struct{}
+	writer     *sink.BatchWriter
+}
+
+func (conf *config) Init(cb api.ConfigCallbackHandler) error {
+	conf.sampleRate = conf.SampleRate
+	if conf.sampleRate == 0 {
+		conf.sampleRate = 1
+	}
+
+	conf.redacted = redact.HeaderSet(conf.RedactedHeaders)
+
+	writer, err := getWriter(&conf.Config)
+	if err != nil {
+		return err
+	}
+	conf.writer = writer
+	runtime.SetFinalizer(conf, func(conf *config) {
+		sink.ReleaseWriter(conf.writer)
+	})
+	return nil
+}
+
+func getWriter(c *access_log.Config) (*sink.BatchWriter, error) {
+	s := &v1.Sink{}
+	if file := c.GetFile(); file != nil {
+		s.Sink = &v1.Sink_File{File: file}
+	} else {
+		s.Sink = &v1.Sink_Http{Http: c.GetHttp()}
+	}
+	return sink.GetWriter(access_log.Name, s, c.GetBatch())
+}
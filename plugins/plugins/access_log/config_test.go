@@ -0,0 +1,114 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package access_log
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestConfig(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{
+			name:  "fields required",
+			input: `{"file":{"path":"/tmp/access.log"}}`,
+			err:   "invalid Config.Fields",
+		},
+		{
+			name:  "sink required",
+			input: `{"fields":[{"name":"path","builtin":"PATH"}]}`,
+			err:   "invalid Config.Sink",
+		},
+		{
+			name:  "field source required",
+			input: `{"fields":[{"name":"path"}],"file":{"path":"/tmp/access.log"}}`,
+			err:   "invalid Field.Source",
+		},
+		{
+			name:  "duplicate field",
+			input: `{"fields":[{"name":"path","builtin":"PATH"},{"name":"path","requestHeader":":path"}],"file":{"path":"/tmp/access.log"}}`,
+			err:   "duplicate field name: path",
+		},
+		{
+			name:  "invalid sample rate",
+			input: `{"fields":[{"name":"path","builtin":"PATH"}],"sampleRate":2,"file":{"path":"/tmp/access.log"}}`,
+			err:   "invalid Config.SampleRate",
+		},
+		{
+			name:  "invalid url",
+			input: `{"fields":[{"name":"path","builtin":"PATH"}],"http":{"url":"/logs"}}`,
+			err:   "invalid HttpSink.Url",
+		},
+		{
+			name:  "invalid batch",
+			input: `{"fields":[{"name":"path","builtin":"PATH"}],"http":{"url":"http://127.0.0.1:8080/logs"},"batch":{"maxRecords":100000}}`,
+			err:   "invalid Batch.MaxRecords",
+		},
+		{
+			name: "pass",
+			input: `{"fields":[
+				{"name":"path","builtin":"PATH"},
+				{"name":"ua","requestHeader":"user-agent"},
+				{"name":"time","property":"request.time"},
+				{"name":"decision","pluginState":{"namespace":"opa","key":"allow"}}
+			],"http":{"url":"http://127.0.0.1:8080/logs","timeout":"1s"},"batch":{"maxRecords":10,"flushInterval":"0.1s"}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := &config{}
+			err := protojson.Unmarshal([]byte(tt.input), conf)
+			if err == nil {
+				err = conf.Validate()
+			}
+			if tt.err == "" {
+				require.Nil(t, err)
+				err = conf.Init(nil)
+				assert.Nil(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestWriterShared(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "access.log")
+	newConf := func(input string) *config {
+		conf := &config{}
+		require.Nil(t, protojson.Unmarshal([]byte(input), conf))
+		require.Nil(t, conf.Validate())
+		require.Nil(t, conf.Init(nil))
+		return conf
+	}
+
+	c1 := newConf(`{"fields":[{"name":"path","builtin":"PATH"}],"file":{"path":"` + path + `"}}`)
+	c2 := newConf(`{"fields":[{"name":"host","builtin":"HOST"}],"sampleRate":0.5,"file":{"path":"` + path + `"}}`)
+	assert.Same(t, c1.writer, c2.writer)
+	assert.Equal(t, float64(1), c1.sampleRate)
+	assert.Equal(t, 0.5, c2.sampleRate)
+
+	c3 := newConf(`{"fields":[{"name":"path","builtin":"PATH"}],"file":{"path":"` + path + `"},"batch":{"maxRecords":1}}`)
+	assert.NotSame(t, c1.writer, c3.writer)
+}
@@ -0,0 +1,192 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package access_log
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/plugins/pkg/redact"
+	"mosn.io/htnn/types/plugins/access_log"
+)
+
+func factory(c interface{}, callbacks api.FilterCallbackHandler) api.Filter {
+	return &filter{
+		callbacks: callbacks,
+		config:    c.(*config),
+	}
+}
+
+type filter struct {
+	api.PassThroughFilter
+
+	callbacks api.FilterCallbackHandler
+	config    *config
+}
+
+func (f *filter) header(headers api.HeaderMap, name string) any {
+	if headers == nil {
+		return nil
+	}
+	v, ok := headers.Get(name)
+	if !ok {
+		return nil
+	}
+	if _, ok := f.config.redacted[strings.ToLower(name)]; ok {
+		return redact.Value
+	}
+	return v
+}
+
+func (f *filter) allHeaders(headers api.HeaderMap) any {
+	if headers == nil {
+		return nil
+	}
+	m := map[string]string{}
+	headers.Range(func(key, value string) bool {
+		if _, ok := f.config.redacted[strings.ToLower(key)]; ok {
+			m[key] = redact.Value
+			return true
+		}
+		if prev, ok := m[key]; ok {
+			value = prev + "," + value
+		}
+		m[key] = value
+		return true
+	})
+	return m
+}
+
+func (f *filter) builtin(b access_log.Builtin, reqHeaders api.RequestHeaderMap, respHeaders api.ResponseHeaderMap) any {
+	info := f.callbacks.StreamInfo()
+	var v string
+	var ok bool
+	switch b {
+	case access_log.Builtin_CONSUMER:
+		c := f.callbacks.GetConsumer()
+		if c == nil {
+			return nil
+		}
+		return c.Name()
+	case access_log.Builtin_RESPONSE_CODE:
+		code, ok := info.ResponseCode()
+		if !ok {
+			return nil
+		}
+		return code
+	case access_log.Builtin_RESPONSE_CODE_DETAILS:
+		v, ok = info.ResponseCodeDetails()
+	case access_log.Builtin_ROUTE:
+		v = info.GetRouteName()
+		ok = v != ""
+	case access_log.Builtin_METHOD:
+		return reqHeaders.Method()
+	case access_log.Builtin_PATH:
+		return reqHeaders.Path()
+	case access_log.Builtin_HOST:
+		return reqHeaders.Host()
+	case access_log.Builtin_DOWNSTREAM_REMOTE_ADDRESS:
+		return info.DownstreamRemoteAddress()
+	case access_log.Builtin_UPSTREAM_REMOTE_ADDRESS:
+		v, ok = info.UpstreamRemoteAddress()
+	case access_log.Builtin_UPSTREAM_CLUSTER:
+		v, ok = info.UpstreamClusterName()
+	case access_log.Builtin_DURATION:
+		dur, err := f.callbacks.GetProperty("request.duration")
+		if err != nil {
+			return nil
+		}
+		d, err := time.ParseDuration(dur)
+		if err != nil {
+			return nil
+		}
+		return d.Seconds()
+	case access_log.Builtin_REQUEST_HEADERS:
+		return f.allHeaders(reqHeaders)
+	case access_log.Builtin_RESPONSE_HEADERS:
+		return f.allHeaders(respHeaders)
+	}
+
+	if !ok {
+		return nil
+	}
+	return v
+}
+
+func (f *filter) value(field *access_log.Field, reqHeaders api.RequestHeaderMap, respHeaders api.ResponseHeaderMap) any {
+	switch src := field.Source.(type) {
+	case *access_log.Field_RequestHeader:
+		return f.header(reqHeaders, src.RequestHeader)
+	case *access_log.Field_ResponseHeader:
+		return f.header(respHeaders, src.ResponseHeader)
+	case *access_log.Field_Property:
+		v, err := f.callbacks.GetProperty(src.Property)
+		if err != nil {
+			return nil
+		}
+		return v
+	case *access_log.Field_Builtin:
+		return f.builtin(src.Builtin, reqHeaders, respHeaders)
+	case *access_log.Field_PluginState:
+		return f.callbacks.PluginState().Get(src.PluginState.Namespace, src.PluginState.Key)
+	}
+	return nil
+}
+
+// record renders the fields as a JSON object. The fields are in the same order as the configuration,
+// and the fields without value are omitted.
+func (f *filter) record(reqHeaders api.RequestHeaderMap, respHeaders api.ResponseHeaderMap) []byte {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	first := true
+	for _, field := range f.config.Fields {
+		v := f.value(field, reqHeaders, respHeaders)
+		if v == nil {
+			continue
+		}
+
+		b, err := json.Marshal(v)
+		if err != nil {
+			// the value in the PluginState may not be marshalable
+			b, _ = json.Marshal(fmt.Sprint(v))
+		}
+		name, _ := json.Marshal(field.Name)
+
+		if !first {
+			buf.WriteByte(',')
+		}
+		first = false
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(b)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes()
+}
+
+func (f *filter) OnLog(reqHeaders api.RequestHeaderMap, reqTrailers api.RequestTrailerMap,
+	respHeaders api.ResponseHeaderMap, respTrailers api.ResponseTrailerMap) {
+
+	if f.config.sampleRate < 1 && rand.Float64() >= f.config.sampleRate {
+		return
+	}
+
+	f.config.writer.Add(f.record(reqHeaders, respHeaders))
+}
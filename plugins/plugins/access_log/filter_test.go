@@ -0,0 +1,173 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package access_log
+
+import (
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/plugins/tests/pkg/envoy"
+	"mosn.io/htnn/plugins/pkg/sink"
+)
+
+type consumer struct {
+	name string
+}
+
+func (c *consumer) Name() string {
+	return c.name
+}
+
+func (c *consumer) PluginConfig(name string) api.PluginConsumerConfig {
+	return nil
+}
+
+type streamInfo struct {
+	envoy.StreamInfo
+}
+
+func (i *streamInfo) GetRouteName() string {
+	return "default/route"
+}
+
+func (i *streamInfo) ResponseCode() (uint32, bool) {
+	return 403, true
+}
+
+func (i *streamInfo) UpstreamClusterName() (string, bool) {
+	return "backend", true
+}
+
+type callbacks struct {
+	api.FilterCallbackHandler
+}
+
+func (cb *callbacks) GetProperty(key string) (string, error) {
+	switch key {
+	case "request.duration":
+		return "1.5s", nil
+	case "request.protocol":
+		return "HTTP/1.1", nil
+	}
+	return "", errors.New("value not found")
+}
+
+func newConfig(t *testing.T, input string) *config {
+	conf := &config{}
+	require.Nil(t, protojson.Unmarshal([]byte(input), conf))
+	require.Nil(t, conf.Validate())
+	require.Nil(t, conf.Init(nil))
+	return conf
+}
+
+func newCallbacks() api.FilterCallbackHandler {
+	cb := envoy.NewFilterCallbackHandler()
+	cb.SetStreamInfo(&streamInfo{})
+	cb.SetConsumer(&consumer{name: "marvin"})
+	cb.PluginState().Set("opa", "allow", false)
+	cb.PluginState().Set("limitReq", "reason", map[string]any{"key": "1.1.1.1"})
+	cb.PluginState().Set("bad", "value", func() {})
+	return &callbacks{cb}
+}
+
+func TestRecord(t *testing.T) {
+	conf := newConfig(t, `{"fields":[
+		{"name":"consumer","builtin":"CONSUMER"},
+		{"name":"code","builtin":"RESPONSE_CODE"},
+		{"name":"details","builtin":"RESPONSE_CODE_DETAILS"},
+		{"name":"route","builtin":"ROUTE"},
+		{"name":"method","builtin":"METHOD"},
+		{"name":"path","builtin":"PATH"},
+		{"name":"host","builtin":"HOST"},
+		{"name":"client","builtin":"DOWNSTREAM_REMOTE_ADDRESS"},
+		{"name":"upstream","builtin":"UPSTREAM_REMOTE_ADDRESS"},
+		{"name":"cluster","builtin":"UPSTREAM_CLUSTER"},
+		{"name":"duration","builtin":"DURATION"},
+		{"name":"protocol","property":"request.protocol"},
+		{"name":"missing","property":"request.id"},
+		{"name":"ua","requestHeader":"user-agent"},
+		{"name":"auth","requestHeader":"authorization"},
+		{"name":"type","responseHeader":"content-type"},
+		{"name":"allow","pluginState":{"namespace":"opa","key":"allow"}},
+		{"name":"reason","pluginState":{"namespace":"limitReq","key":"reason"}},
+		{"name":"none","pluginState":{"namespace":"limitReq","key":"none"}},
+		{"name":"bad","pluginState":{"namespace":"bad","key":"value"}}
+	],"file":{"path":"/tmp/access.log"}}`)
+
+	f := factory(conf, newCallbacks()).(*filter)
+	reqHdr := envoy.NewRequestHeaderMap(http.Header{
+		":authority":     []string{"test.local"},
+		":method":        []string{"POST"},
+		":path":          []string{"/echo?a=1"},
+		"User-Agent":     []string{"curl"},
+		"Authorization":  []string{"Bearer xxx"},
+		"X-Forwarded-By": []string{"a"},
+	})
+	respHdr := envoy.NewResponseHeaderMap(http.Header{
+		"Content-Type": []string{"application/json"},
+	})
+	record := f.record(reqHdr, respHdr)
+	assert.Regexp(t, `^\{"consumer":"marvin","code":403,"route":"default/route","method":"POST","path":"/echo\?a=1",`+
+		`"host":"test.local","client":"183.128.130.43:54321","cluster":"backend","duration":1.5,"protocol":"HTTP/1.1",`+
+		`"ua":"curl","auth":"\*\*\*","type":"application/json","allow":false,"reason":\{"key":"1.1.1.1"\},"bad":"0x[0-9a-f]+"\}$`,
+		string(record))
+
+	// the response headers are missing when the request is reset
+	conf = newConfig(t, `{"fields":[
+		{"name":"req","builtin":"REQUEST_HEADERS"},
+		{"name":"resp","builtin":"RESPONSE_HEADERS"},
+		{"name":"type","responseHeader":"content-type"}
+	],"redactedHeaders":["X-Forwarded-By"],"file":{"path":"/tmp/access.log"}}`)
+	f = factory(conf, newCallbacks()).(*filter)
+	record = f.record(envoy.NewRequestHeaderMap(http.Header{
+		"Authorization":  []string{"Bearer xxx"},
+		"X-Forwarded-By": []string{"a", "b"},
+		"Accept":         []string{"text/html", "application/json"},
+	}), nil)
+	// the default redacted headers are kept
+	assert.JSONEq(t, `{"req":{"Authorization":"***","X-Forwarded-By":"***","Accept":"text/html,application/json"}}`,
+		string(record))
+}
+
+func TestOnLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "access.log")
+	input := `{"fields":[{"name":"path","builtin":"PATH"}],"file":{"path":"` + path + `"}}`
+
+	conf := newConfig(t, input)
+	conf.writer = sink.NewBatchWriter(sink.NewFileSink(path), sink.BatchOptions{})
+	f := factory(conf, newCallbacks())
+	reqHdr := envoy.NewRequestHeaderMap(http.Header{
+		":path": []string{"/echo"},
+	})
+	f.OnLog(reqHdr, nil, nil, nil)
+	f.OnLog(reqHdr, nil, nil, nil)
+
+	// sampled out
+	conf.sampleRate = 0.000001
+	f.OnLog(reqHdr, nil, nil, nil)
+
+	conf.writer.Close()
+	b, err := os.ReadFile(path)
+	require.Nil(t, err)
+	assert.Equal(t, "{\"path\":\"/echo\"}\n{\"path\":\"/echo\"}\n", string(b))
+}
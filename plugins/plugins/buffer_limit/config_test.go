@@ -0,0 +1,61 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package buffer_limit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"mosn.io/htnn/api/pkg/plugins"
+)
+
+func TestConfig(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{
+			name:  "maxRequestBytes required",
+			input: `{}`,
+			err:   "invalid Config.MaxRequestBytes",
+		},
+		{
+			name:  "pass",
+			input: `{"maxRequestBytes":1024,"allowPartialMessage":true}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := &config{}
+			err := protojson.Unmarshal([]byte(tt.input), conf)
+			if err == nil {
+				err = conf.Validate()
+			}
+			if tt.err == "" {
+				assert.Nil(t, err)
+				var limiter plugins.RequestBodyLimiter = conf
+				maxBytes, allowPartial := limiter.RequestBodyLimit()
+				assert.Equal(t, uint32(1024), maxBytes)
+				assert.True(t, allowPartial)
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
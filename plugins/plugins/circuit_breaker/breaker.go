@@ -0,0 +1,168 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package circuit_breaker
+
+import (
+	"sync"
+	"time"
+)
+
+type breakerState int
+
+const (
+	stateClosed breakerState = iota
+	stateOpen
+	stateHalfOpen
+)
+
+func (s breakerState) String() string {
+	switch s {
+	case stateOpen:
+		return "open"
+	case stateHalfOpen:
+		return "half-open"
+	default:
+		return "closed"
+	}
+}
+
+type breakerOptions struct {
+	failureThreshold int
+	openDuration     time.Duration
+	halfOpenRequests int
+}
+
+type breaker struct {
+	state    breakerState
+	failures int
+	openedAt time.Time
+	// the number of probes issued and succeeded in the half-open state
+	probes    int
+	successes int
+}
+
+// breakers keeps the state of the breakers by key. Only the breakers which have seen failures
+// are stored, so the keys which are healthy don't take memory.
+type breakers struct {
+	lock sync.Mutex
+	m    map[string]*breaker
+	opts breakerOptions
+}
+
+func newBreakers(opts breakerOptions) *breakers {
+	return &breakers{
+		m:    map[string]*breaker{},
+		opts: opts,
+	}
+}
+
+// Allow reports whether the request with the given key can be forwarded, and whether it is
+// a probe request issued in the half-open state.
+func (bs *breakers) Allow(key string, now time.Time) (allowed bool, probe bool) {
+	bs.lock.Lock()
+	defer bs.lock.Unlock()
+
+	b, ok := bs.m[key]
+	if !ok {
+		return true, false
+	}
+
+	if b.state == stateOpen {
+		if now.Sub(b.openedAt) < bs.opts.openDuration {
+			return false, false
+		}
+		b.state = stateHalfOpen
+		b.probes = 0
+		b.successes = 0
+	}
+
+	if b.state == stateHalfOpen {
+		if b.probes >= bs.opts.halfOpenRequests {
+			return false, false
+		}
+		b.probes++
+		return true, true
+	}
+	return true, false
+}
+
+// Record records the result of an allowed request.
+func (bs *breakers) Record(key string, probe bool, failed bool, now time.Time) {
+	bs.lock.Lock()
+	defer bs.lock.Unlock()
+
+	b, ok := bs.m[key]
+	if !ok {
+		if !failed {
+			return
+		}
+		b = &breaker{}
+		bs.m[key] = b
+	}
+
+	switch b.state {
+	case stateClosed:
+		if !failed {
+			delete(bs.m, key)
+			return
+		}
+		b.failures++
+		if b.failures >= bs.opts.failureThreshold {
+			b.state = stateOpen
+			b.openedAt = now
+		}
+	case stateHalfOpen:
+		// ignore the requests allowed before the breaker is open
+		if !probe {
+			return
+		}
+		if failed {
+			b.state = stateOpen
+			b.openedAt = now
+			return
+		}
+		b.successes++
+		if b.successes >= bs.opts.halfOpenRequests {
+			delete(bs.m, key)
+		}
+	}
+}
+
+// Cancel gives back the probe quota when the result of the probe request is unknown,
+// for example, the client aborts the request.
+func (bs *breakers) Cancel(key string, probe bool) {
+	if !probe {
+		return
+	}
+
+	bs.lock.Lock()
+	defer bs.lock.Unlock()
+
+	b, ok := bs.m[key]
+	if ok && b.state == stateHalfOpen && b.probes > 0 {
+		b.probes--
+	}
+}
+
+func (bs *breakers) State(key string) breakerState {
+	bs.lock.Lock()
+	defer bs.lock.Unlock()
+
+	b, ok := bs.m[key]
+	if !ok {
+		return stateClosed
+	}
+	return b.state
+}
@@ -0,0 +1,107 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package circuit_breaker
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/cel-go/cel"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/plugins"
+	"mosn.io/htnn/types/pkg/expr"
+	"mosn.io/htnn/types/plugins/circuit_breaker"
+)
+
+func init() {
+	plugins.RegisterHttpPlugin(circuit_breaker.Name, &plugin{})
+}
+
+type plugin struct {
+	circuit_breaker.Plugin
+}
+
+func (p *plugin) Factory() api.FilterFactory {
+	return factory
+}
+
+func (p *plugin) Config() api.PluginConfig {
+	return &config{}
+}
+
+type config struct {
+	circuit_breaker.CustomConfig
+
+	keyScript     expr.Script
+	failureStatus map[uint32]struct{}
+	timeout       time.Duration
+
+	replyStatus int
+	replyHeader http.Header
+
+	breakers *breakers
+}
+
+func (conf *config) Init(cb api.ConfigCallbackHandler) error {
+	if conf.Key != "" {
+		conf.keyScript, _ = expr.CompileCel(conf.Key, cel.StringType)
+	}
+
+	if len(conf.FailureStatus) > 0 {
+		conf.failureStatus = make(map[uint32]struct{}, len(conf.FailureStatus))
+		for _, s := range conf.FailureStatus {
+			conf.failureStatus[s] = struct{}{}
+		}
+	}
+	if conf.Timeout != nil {
+		conf.timeout = conf.Timeout.AsDuration()
+	}
+
+	conf.replyStatus = 503
+	if conf.LocalReply != nil {
+		if conf.LocalReply.Status != 0 {
+			conf.replyStatus = int(conf.LocalReply.Status)
+		}
+		if len(conf.LocalReply.Headers) > 0 {
+			conf.replyHeader = http.Header{}
+			for _, h := range conf.LocalReply.Headers {
+				conf.replyHeader.Add(h.Key, h.Value)
+			}
+		}
+	}
+
+	opts := breakerOptions{
+		failureThreshold: int(conf.FailureThreshold),
+		openDuration:     30 * time.Second,
+		halfOpenRequests: 1,
+	}
+	if conf.OpenDuration != nil {
+		opts.openDuration = conf.OpenDuration.AsDuration()
+	}
+	if conf.HalfOpenRequests != 0 {
+		opts.halfOpenRequests = int(conf.HalfOpenRequests)
+	}
+	conf.breakers = newBreakers(opts)
+	return nil
+}
+
+func (conf *config) isFailureStatus(code uint32) bool {
+	if conf.failureStatus == nil {
+		return code >= 500
+	}
+	_, ok := conf.failureStatus[code]
+	return ok
+}
@@ -0,0 +1,77 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package circuit_breaker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestConfig(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{
+			name:  "failureThreshold required",
+			input: `{}`,
+			err:   "invalid Config.FailureThreshold",
+		},
+		{
+			name:  "bad key",
+			input: `{"failureThreshold":3,"key":"request.method() == \"GET\""}`,
+			err:   "got bool, wanted string",
+		},
+		{
+			name:  "bad failure status",
+			input: `{"failureThreshold":3,"failureStatus":[600]}`,
+			err:   "invalid Config.FailureStatus",
+		},
+		{
+			name:  "bad open duration",
+			input: `{"failureThreshold":3,"openDuration":"0.5s"}`,
+			err:   "invalid Config.OpenDuration",
+		},
+		{
+			name:  "bad local reply header",
+			input: `{"failureThreshold":3,"localReply":{"headers":[{"key":"","value":"v"}]}}`,
+			err:   "invalid HeaderValue.Key",
+		},
+		{
+			name:  "pass",
+			input: `{"failureThreshold":3,"key":"request.header(\"x-tenant\")","failureStatus":[502,503],"timeout":"1s","openDuration":"10s","halfOpenRequests":2,"localReply":{"status":429,"body":"try later","headers":[{"key":"retry-after","value":"10"}]}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := &config{}
+			err := protojson.Unmarshal([]byte(tt.input), conf)
+			if err == nil {
+				err = conf.Validate()
+			}
+			if tt.err == "" {
+				assert.Nil(t, err)
+				err = conf.Init(nil)
+				assert.Nil(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
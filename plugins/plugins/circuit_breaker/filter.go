@@ -0,0 +1,130 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package circuit_breaker
+
+import (
+	"time"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+)
+
+func factory(c interface{}, callbacks api.FilterCallbackHandler) api.Filter {
+	return &filter{
+		callbacks: callbacks,
+		config:    c.(*config),
+	}
+}
+
+type filter struct {
+	api.PassThroughFilter
+
+	callbacks api.FilterCallbackHandler
+	config    *config
+
+	// set when the request is allowed
+	allowed bool
+	key     string
+	probe   bool
+	start   time.Time
+	latency time.Duration
+}
+
+func (f *filter) getKey(headers api.RequestHeaderMap) (string, bool) {
+	script := f.config.keyScript
+	if script == nil {
+		return f.callbacks.StreamInfo().GetRouteName(), true
+	}
+
+	res, err := script.EvalWithRequest(f.callbacks, headers)
+	if err != nil {
+		api.LogErrorf("failed to eval script with request: %v", err)
+		return "", false
+	}
+	return res.(string), true
+}
+
+func (f *filter) DecodeHeaders(headers api.RequestHeaderMap, endStream bool) api.ResultAction {
+	key, ok := f.getKey(headers)
+	if !ok {
+		// Don't break the request because of the bad script
+		return api.Continue
+	}
+
+	allowed, probe := f.config.breakers.Allow(key, time.Now())
+	if !allowed {
+		api.LogInfof("circuitBreaker filter rejects request, key: %s", key)
+		conf := f.config
+		resp := &api.LocalResponse{Code: conf.replyStatus}
+		if conf.LocalReply != nil {
+			resp.Msg = conf.LocalReply.Body
+		}
+		if conf.replyHeader != nil {
+			resp.Header = conf.replyHeader.Clone()
+		}
+		return resp
+	}
+
+	f.allowed = true
+	f.key = key
+	f.probe = probe
+	f.start = time.Now()
+	return api.Continue
+}
+
+func (f *filter) EncodeHeaders(headers api.ResponseHeaderMap, endStream bool) api.ResultAction {
+	if f.allowed && f.latency == 0 {
+		f.latency = time.Since(f.start)
+	}
+	return api.Continue
+}
+
+func isTimeout(details string) bool {
+	return details == "upstream_response_timeout" || details == "upstream_per_try_timeout"
+}
+
+func (f *filter) OnLog(reqHeaders api.RequestHeaderMap, reqTrailers api.RequestTrailerMap,
+	respHeaders api.ResponseHeaderMap, respTrailers api.ResponseTrailerMap) {
+
+	if !f.allowed {
+		return
+	}
+
+	breakers := f.config.breakers
+	code, ok := f.callbacks.StreamInfo().ResponseCode()
+	if !ok || code == 0 {
+		// the request is aborted before the response is sent
+		breakers.Cancel(f.key, f.probe)
+		return
+	}
+
+	failed := f.config.isFailureStatus(code)
+	if !failed {
+		details, _ := f.callbacks.StreamInfo().ResponseCodeDetails()
+		failed = isTimeout(details)
+	}
+	if !failed && f.config.timeout > 0 {
+		latency := f.latency
+		if latency == 0 {
+			latency = time.Since(f.start)
+		}
+		failed = latency > f.config.timeout
+	}
+
+	breakers.Record(f.key, f.probe, failed, time.Now())
+	if failed {
+		api.LogDebugf("circuitBreaker filter records failure, key: %s, status: %d, state: %s",
+			f.key, code, breakers.State(f.key))
+	}
+}
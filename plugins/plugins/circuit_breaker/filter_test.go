@@ -0,0 +1,202 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package circuit_breaker
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/plugins/tests/pkg/envoy"
+)
+
+func TestBreakers(t *testing.T) {
+	bs := newBreakers(breakerOptions{
+		failureThreshold: 2,
+		openDuration:     time.Second,
+		halfOpenRequests: 2,
+	})
+	now := time.Now()
+
+	allowed, probe := bs.Allow("a", now)
+	assert.True(t, allowed)
+	assert.False(t, probe)
+
+	// success resets the consecutive failures
+	bs.Record("a", false, true, now)
+	bs.Record("a", false, false, now)
+	assert.Equal(t, 0, len(bs.m))
+
+	bs.Record("a", false, true, now)
+	bs.Record("a", false, true, now)
+	assert.Equal(t, stateOpen, bs.State("a"))
+	allowed, _ = bs.Allow("a", now.Add(500*time.Millisecond))
+	assert.False(t, allowed)
+	// other keys are not affected
+	allowed, _ = bs.Allow("b", now)
+	assert.True(t, allowed)
+
+	// half-open
+	now = now.Add(time.Second)
+	for i := 0; i < 2; i++ {
+		allowed, probe = bs.Allow("a", now)
+		assert.True(t, allowed)
+		assert.True(t, probe)
+	}
+	assert.Equal(t, stateHalfOpen, bs.State("a"))
+	allowed, _ = bs.Allow("a", now)
+	assert.False(t, allowed)
+
+	// the probe quota is given back when the result is unknown
+	bs.Cancel("a", true)
+	allowed, probe = bs.Allow("a", now)
+	assert.True(t, allowed)
+	assert.True(t, probe)
+
+	// the requests allowed before the breaker is open are ignored
+	bs.Record("a", false, true, now)
+	assert.Equal(t, stateHalfOpen, bs.State("a"))
+
+	bs.Record("a", true, false, now)
+	assert.Equal(t, stateHalfOpen, bs.State("a"))
+	bs.Record("a", true, false, now)
+	assert.Equal(t, stateClosed, bs.State("a"))
+	assert.Equal(t, 0, len(bs.m))
+
+	// failed probe opens the breaker again
+	bs.Record("a", false, true, now)
+	bs.Record("a", false, true, now)
+	now = now.Add(time.Second)
+	allowed, probe = bs.Allow("a", now)
+	assert.True(t, allowed)
+	bs.Record("a", probe, true, now)
+	assert.Equal(t, stateOpen, bs.State("a"))
+	allowed, _ = bs.Allow("a", now)
+	assert.False(t, allowed)
+}
+
+type streamInfo struct {
+	envoy.StreamInfo
+
+	code    uint32
+	details string
+}
+
+func (i *streamInfo) ResponseCode() (uint32, bool) {
+	return i.code, i.code != 0
+}
+
+func (i *streamInfo) ResponseCodeDetails() (string, bool) {
+	return i.details, i.details != ""
+}
+
+func newFilter(conf *config, info *streamInfo) *filter {
+	cb := envoy.NewFilterCallbackHandler()
+	cb.SetStreamInfo(info)
+	return factory(conf, cb).(*filter)
+}
+
+func TestFilter(t *testing.T) {
+	conf := &config{}
+	err := protojson.Unmarshal([]byte(`{
+		"key":"request.header(\"x-tenant\")",
+		"failureThreshold":2,
+		"failureStatus":[502],
+		"timeout":"0.1s",
+		"localReply":{"status":429,"body":"try later","headers":[{"key":"retry-after","value":"30"}]}
+	}`), conf)
+	require.NoError(t, err)
+	require.NoError(t, conf.Validate())
+	require.NoError(t, conf.Init(nil))
+
+	hdr := envoy.NewRequestHeaderMap(http.Header{"X-Tenant": []string{"a"}})
+
+	tests := []struct {
+		name    string
+		info    *streamInfo
+		latency time.Duration
+		failed  bool
+	}{
+		{
+			name: "not failure status",
+			info: &streamInfo{code: 500},
+		},
+		{
+			name:   "failure status",
+			info:   &streamInfo{code: 502},
+			failed: true,
+		},
+		{
+			name:   "timeout by Envoy",
+			info:   &streamInfo{code: 504, details: "upstream_response_timeout"},
+			failed: true,
+		},
+		{
+			name:    "timeout",
+			info:    &streamInfo{code: 200},
+			latency: 200 * time.Millisecond,
+			failed:  true,
+		},
+		{
+			name: "aborted",
+			info: &streamInfo{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFilter(conf, tt.info)
+			require.Equal(t, api.Continue, f.DecodeHeaders(hdr, true))
+			f.start = f.start.Add(-tt.latency)
+			f.EncodeHeaders(envoy.NewResponseHeaderMap(http.Header{}), true)
+			f.OnLog(hdr, nil, nil, nil)
+
+			b := conf.breakers.m["a"]
+			if tt.failed {
+				require.NotNil(t, b)
+				assert.Equal(t, 1, b.failures)
+			} else {
+				assert.Nil(t, b)
+			}
+			delete(conf.breakers.m, "a")
+		})
+	}
+
+	for i := 0; i < 2; i++ {
+		f := newFilter(conf, &streamInfo{code: 502})
+		require.Equal(t, api.Continue, f.DecodeHeaders(hdr, true))
+		f.OnLog(hdr, nil, nil, nil)
+	}
+
+	f := newFilter(conf, &streamInfo{})
+	res := f.DecodeHeaders(hdr, true)
+	resp, ok := res.(*api.LocalResponse)
+	require.True(t, ok)
+	assert.Equal(t, 429, resp.Code)
+	assert.Equal(t, "try later", resp.Msg)
+	assert.Equal(t, "30", resp.Header.Get("retry-after"))
+	// the rejected request is not recorded
+	f.OnLog(hdr, nil, nil, nil)
+	assert.Equal(t, 2, conf.breakers.m["a"].failures)
+
+	// other keys are not affected
+	f = newFilter(conf, &streamInfo{})
+	assert.Equal(t, api.Continue, f.DecodeHeaders(envoy.NewRequestHeaderMap(http.Header{"X-Tenant": []string{"b"}}), true))
+}
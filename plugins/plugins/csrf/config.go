@@ -0,0 +1,151 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package csrf
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"strings"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/plugins"
+	"mosn.io/htnn/types/pkg/expr"
+	"mosn.io/htnn/types/plugins/csrf"
+)
+
+const (
+	defaultCookieName = "htnn_csrf_token"
+	defaultHeaderName = "x-csrf-token"
+	defaultFormField  = "csrf_token"
+)
+
+func init() {
+	plugins.RegisterHttpPlugin(csrf.Name, &plugin{})
+}
+
+type plugin struct {
+	csrf.Plugin
+}
+
+func (p *plugin) Factory() api.FilterFactory {
+	return factory
+}
+
+func (p *plugin) Config() api.PluginConfig {
+	return &config{}
+}
+
+type config struct {
+	csrf.CustomConfig
+
+	secret         []byte
+	cookieName     string
+	headerName     string
+	formField      string
+	trustedOrigins map[string]bool
+	exemptPaths    expr.Matcher
+}
+
+func (conf *config) Init(cb api.ConfigCallbackHandler) error {
+	conf.secret = []byte(conf.Secret)
+
+	conf.cookieName = defaultCookieName
+	if conf.Cookie != nil && conf.Cookie.Name != "" {
+		conf.cookieName = conf.Cookie.Name
+	}
+	conf.headerName = defaultHeaderName
+	if conf.HeaderName != "" {
+		conf.headerName = conf.HeaderName
+	}
+	conf.formField = defaultFormField
+	if conf.FormField != "" {
+		conf.formField = conf.FormField
+	}
+
+	conf.trustedOrigins = make(map[string]bool, len(conf.TrustedOrigins))
+	for _, o := range conf.TrustedOrigins {
+		origin, err := csrf.ParseOrigin(o)
+		if err != nil {
+			return err
+		}
+		conf.trustedOrigins[origin] = true
+	}
+
+	if len(conf.ExemptPaths) > 0 {
+		m, err := expr.BuildRepeatedStringMatcher(conf.ExemptPaths)
+		if err != nil {
+			return err
+		}
+		conf.exemptPaths = m
+	}
+	return nil
+}
+
+func (conf *config) sign(nonce string) string {
+	mac := hmac.New(sha256.New, conf.secret)
+	mac.Write([]byte(nonce))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// newToken generates a token in the form of `nonce.signature`
+func (conf *config) newToken() string {
+	b := make([]byte, 32)
+	_, _ = rand.Read(b)
+	nonce := base64.RawURLEncoding.EncodeToString(b)
+	return nonce + "." + conf.sign(nonce)
+}
+
+func (conf *config) verifyToken(token string) bool {
+	nonce, sig, ok := strings.Cut(token, ".")
+	if !ok || nonce == "" {
+		return false
+	}
+	return hmac.Equal([]byte(sig), []byte(conf.sign(nonce)))
+}
+
+func (conf *config) newCookie(token string) *http.Cookie {
+	c := &http.Cookie{
+		Name:  conf.cookieName,
+		Value: token,
+		Path:  "/",
+		// HttpOnly is not set as the script needs to read the token and send it back via the header
+	}
+
+	cc := conf.Cookie
+	if cc == nil {
+		c.SameSite = http.SameSiteLaxMode
+		return c
+	}
+	if cc.Path != "" {
+		c.Path = cc.Path
+	}
+	c.Domain = cc.Domain
+	c.Secure = cc.Secure
+	switch cc.SameSite {
+	case csrf.SameSite_STRICT:
+		c.SameSite = http.SameSiteStrictMode
+	case csrf.SameSite_NONE:
+		c.SameSite = http.SameSiteNoneMode
+	default:
+		c.SameSite = http.SameSiteLaxMode
+	}
+	if cc.MaxAge != nil {
+		c.MaxAge = int(cc.MaxAge.AsDuration().Seconds())
+	}
+	return c
+}
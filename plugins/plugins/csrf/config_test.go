@@ -0,0 +1,118 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package csrf
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestConfig(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{
+			name:  "short secret",
+			input: `{"secret":"short"}`,
+			err:   "invalid Config.Secret: value length must be at least 16 runes",
+		},
+		{
+			name:  "invalid cookie name",
+			input: `{"secret":"0123456789abcdef","cookie":{"name":"a b"}}`,
+			err:   `invalid cookie name "a b"`,
+		},
+		{
+			name:  "invalid origin",
+			input: `{"secret":"0123456789abcdef","trustedOrigins":["example.com"]}`,
+			err:   `invalid origin "example.com"`,
+		},
+		{
+			name:  "invalid exempt path",
+			input: `{"secret":"0123456789abcdef","exemptPaths":[{"regex":"("}]}`,
+			err:   "error parsing regexp",
+		},
+		{
+			name:  "pseudo header",
+			input: `{"secret":"0123456789abcdef","headerName":":path"}`,
+			err:   "pseudo header can't be used to carry the token",
+		},
+		{
+			name: "pass",
+			input: `{"secret":"0123456789abcdef","cookie":{"name":"csrf","secure":true,"sameSite":"STRICT","maxAge":"3600s"},
+				"trustedOrigins":["https://Example.com/path"],"exemptPaths":[{"prefix":"/webhooks/"}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := &config{}
+			err := protojson.Unmarshal([]byte(tt.input), conf)
+			if err == nil {
+				err = conf.Validate()
+			}
+			if tt.err == "" {
+				assert.Nil(t, err)
+				assert.Nil(t, conf.Init(nil))
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestToken(t *testing.T) {
+	conf := &config{}
+	conf.Secret = "0123456789abcdef"
+	assert.Nil(t, conf.Init(nil))
+
+	token := conf.newToken()
+	assert.True(t, conf.verifyToken(token))
+	assert.NotEqual(t, token, conf.newToken())
+
+	assert.False(t, conf.verifyToken(""))
+	assert.False(t, conf.verifyToken("nonce"))
+	assert.False(t, conf.verifyToken(token+"x"))
+
+	other := &config{}
+	other.Secret = "fedcba9876543210"
+	assert.Nil(t, other.Init(nil))
+	assert.False(t, other.verifyToken(token))
+}
+
+func TestCookie(t *testing.T) {
+	conf := &config{}
+	assert.Nil(t, protojson.Unmarshal([]byte(`{"secret":"0123456789abcdef"}`), conf))
+	assert.Nil(t, conf.Init(nil))
+	c := conf.newCookie("token")
+	assert.Equal(t, "htnn_csrf_token=token; Path=/; SameSite=Lax", c.String())
+
+	conf = &config{}
+	assert.Nil(t, protojson.Unmarshal([]byte(`{"secret":"0123456789abcdef",
+		"cookie":{"name":"csrf","path":"/app","domain":"example.com","secure":true,"sameSite":"NONE","maxAge":"3600s"}}`), conf))
+	assert.Nil(t, conf.Init(nil))
+	c = conf.newCookie("token")
+	assert.Equal(t, "csrf", c.Name)
+	assert.Equal(t, "/app", c.Path)
+	assert.Equal(t, "example.com", c.Domain)
+	assert.True(t, c.Secure)
+	assert.False(t, c.HttpOnly)
+	assert.Equal(t, http.SameSiteNoneMode, c.SameSite)
+	assert.Equal(t, 3600, c.MaxAge)
+}
@@ -0,0 +1,211 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package csrf
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/url"
+	"strings"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/types/plugins/csrf"
+)
+
+const (
+	maxFormFieldSize = 4096
+)
+
+var (
+	safeMethods = map[string]bool{
+		"GET":     true,
+		"HEAD":    true,
+		"OPTIONS": true,
+		"TRACE":   true,
+	}
+)
+
+func factory(c interface{}, callbacks api.FilterCallbackHandler) api.Filter {
+	return &filter{
+		callbacks: callbacks,
+		config:    c.(*config),
+	}
+}
+
+type filter struct {
+	api.PassThroughFilter
+
+	callbacks api.FilterCallbackHandler
+	config    *config
+
+	// the token in the cookie, which is compared with the one in the form
+	cookieToken string
+	// the new token issued to the client
+	newToken string
+}
+
+func (f *filter) reject(msg string) api.ResultAction {
+	return &api.LocalResponse{Code: 403, Msg: msg}
+}
+
+func (f *filter) originAllowed(headers api.RequestHeaderMap) bool {
+	origin, _ := headers.Get("origin")
+	if origin == "" || origin == "null" {
+		// some browsers don't send the Origin, for example, the old browsers or the requests
+		// in privacy-sensitive contexts. Fall back to the Referer.
+		referer, ok := headers.Get("referer")
+		if !ok {
+			return false
+		}
+		origin = referer
+	}
+
+	o, err := csrf.ParseOrigin(origin)
+	if err != nil {
+		return false
+	}
+	if f.config.trustedOrigins[o] {
+		return true
+	}
+	// the same origin is always allowed. Only the host is compared as the TLS may be
+	// terminated before the gateway.
+	_, host, _ := strings.Cut(o, "://")
+	return host == strings.ToLower(headers.Host())
+}
+
+func tokenMatched(a, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
+
+func formMediaType(headers api.RequestHeaderMap) (string, map[string]string) {
+	ct, ok := headers.Get("content-type")
+	if !ok {
+		return "", nil
+	}
+	mt, params, err := mime.ParseMediaType(ct)
+	if err != nil {
+		return "", nil
+	}
+	if mt != "application/x-www-form-urlencoded" && mt != "multipart/form-data" {
+		return "", nil
+	}
+	return mt, params
+}
+
+func (f *filter) DecodeHeaders(headers api.RequestHeaderMap, endStream bool) api.ResultAction {
+	config := f.config
+	var token string
+	if c := headers.Cookie(config.cookieName); c != nil && config.verifyToken(c.Value) {
+		token = c.Value
+	}
+
+	if safeMethods[headers.Method()] {
+		if token == "" {
+			f.newToken = config.newToken()
+		}
+		return api.Continue
+	}
+
+	if config.exemptPaths != nil && config.exemptPaths.Match(headers.Url().Path) {
+		return api.Continue
+	}
+
+	if len(config.trustedOrigins) > 0 && !f.originAllowed(headers) {
+		return f.reject("origin not allowed")
+	}
+
+	if token == "" {
+		return f.reject("invalid CSRF token")
+	}
+	if v, ok := headers.Get(config.headerName); ok {
+		if !tokenMatched(v, token) {
+			return f.reject("invalid CSRF token")
+		}
+		return api.Continue
+	}
+
+	if mt, _ := formMediaType(headers); mt != "" && !endStream {
+		f.cookieToken = token
+		return api.WaitAllData
+	}
+	return f.reject("missing CSRF token")
+}
+
+func (f *filter) formToken(headers api.RequestHeaderMap, data []byte) (string, error) {
+	mt, params := formMediaType(headers)
+	if mt == "application/x-www-form-urlencoded" {
+		values, err := url.ParseQuery(string(data))
+		if err != nil {
+			return "", err
+		}
+		return values.Get(f.config.formField), nil
+	}
+
+	boundary := params["boundary"]
+	if boundary == "" {
+		return "", errors.New("missing boundary")
+	}
+	reader := multipart.NewReader(bytes.NewReader(data), boundary)
+	for {
+		part, err := reader.NextPart()
+		if err != nil {
+			if err == io.EOF {
+				return "", nil
+			}
+			return "", err
+		}
+		if part.FormName() == f.config.formField && part.FileName() == "" {
+			b, err := io.ReadAll(io.LimitReader(part, maxFormFieldSize))
+			if err != nil {
+				return "", err
+			}
+			return string(b), nil
+		}
+	}
+}
+
+func (f *filter) DecodeRequest(headers api.RequestHeaderMap, buf api.BufferInstance, trailers api.RequestTrailerMap) api.ResultAction {
+	if f.cookieToken == "" {
+		return api.Continue
+	}
+
+	var data []byte
+	if buf != nil {
+		data = buf.Bytes()
+	}
+	token, err := f.formToken(headers, data)
+	if err != nil {
+		api.LogInfof("csrf: failed to parse form: %v", err)
+		return f.reject("missing CSRF token")
+	}
+	if token == "" {
+		return f.reject("missing CSRF token")
+	}
+	if !tokenMatched(token, f.cookieToken) {
+		return f.reject("invalid CSRF token")
+	}
+	return api.Continue
+}
+
+func (f *filter) EncodeHeaders(headers api.ResponseHeaderMap, endStream bool) api.ResultAction {
+	if f.newToken != "" {
+		headers.Add("set-cookie", f.config.newCookie(f.newToken).String())
+	}
+	return api.Continue
+}
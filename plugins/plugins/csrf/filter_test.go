@@ -0,0 +1,254 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package csrf
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/plugins/tests/pkg/envoy"
+)
+
+func newConfig(t *testing.T, input string) *config {
+	conf := &config{}
+	require.Nil(t, protojson.Unmarshal([]byte(input), conf))
+	require.Nil(t, conf.Validate())
+	require.Nil(t, conf.Init(nil))
+	return conf
+}
+
+func TestIssueToken(t *testing.T) {
+	conf := newConfig(t, `{"secret":"0123456789abcdef"}`)
+	cb := envoy.NewFilterCallbackHandler()
+
+	f := factory(conf, cb)
+	hdr := envoy.NewRequestHeaderMap(http.Header{":method": []string{"GET"}, ":path": []string{"/"}})
+	assert.Equal(t, api.Continue, f.DecodeHeaders(hdr, true))
+	rspHdr := envoy.NewResponseHeaderMap(http.Header{})
+	assert.Equal(t, api.Continue, f.EncodeHeaders(rspHdr, true))
+	setCookie, ok := rspHdr.Get("set-cookie")
+	require.True(t, ok)
+	cookies := (&http.Response{Header: http.Header{"Set-Cookie": []string{setCookie}}}).Cookies()
+	require.Len(t, cookies, 1)
+	c := cookies[0]
+	assert.Equal(t, "htnn_csrf_token", c.Name)
+	assert.True(t, conf.verifyToken(c.Value))
+
+	// don't issue token if the existing one is valid
+	f = factory(conf, cb)
+	hdr = envoy.NewRequestHeaderMap(http.Header{
+		":method": []string{"GET"},
+		":path":   []string{"/"},
+		"Cookie":  []string{"htnn_csrf_token=" + c.Value},
+	})
+	assert.Equal(t, api.Continue, f.DecodeHeaders(hdr, true))
+	rspHdr = envoy.NewResponseHeaderMap(http.Header{})
+	assert.Equal(t, api.Continue, f.EncodeHeaders(rspHdr, true))
+	_, ok = rspHdr.Get("set-cookie")
+	assert.False(t, ok)
+
+	// reissue the forged token
+	f = factory(conf, cb)
+	hdr = envoy.NewRequestHeaderMap(http.Header{
+		":method": []string{"HEAD"},
+		":path":   []string{"/"},
+		"Cookie":  []string{"htnn_csrf_token=a.b"},
+	})
+	assert.Equal(t, api.Continue, f.DecodeHeaders(hdr, true))
+	rspHdr = envoy.NewResponseHeaderMap(http.Header{})
+	assert.Equal(t, api.Continue, f.EncodeHeaders(rspHdr, true))
+	_, ok = rspHdr.Get("set-cookie")
+	assert.True(t, ok)
+}
+
+func multipartForm(t *testing.T, fields map[string]string) (string, []byte) {
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	for k, v := range fields {
+		require.Nil(t, w.WriteField(k, v))
+	}
+	fw, err := w.CreateFormFile("csrf_token", "file.txt")
+	require.Nil(t, err)
+	_, _ = fw.Write([]byte("not the token"))
+	require.Nil(t, w.Close())
+	return w.FormDataContentType(), buf.Bytes()
+}
+
+func TestVerifyToken(t *testing.T) {
+	conf := newConfig(t, `{"secret":"0123456789abcdef","exemptPaths":[{"prefix":"/webhooks/"}]}`)
+	token := conf.newToken()
+	otherToken := conf.newToken()
+	ct, form := multipartForm(t, map[string]string{"name": "a", "csrf_token": token})
+	_, formWithoutToken := multipartForm(t, map[string]string{"name": "a"})
+
+	tests := []struct {
+		name   string
+		header http.Header
+		body   string
+		msg    string
+	}{
+		{
+			name:   "header",
+			header: http.Header{"Cookie": []string{"htnn_csrf_token=" + token}, "X-Csrf-Token": []string{token}},
+		},
+		{
+			name:   "mismatched header",
+			header: http.Header{"Cookie": []string{"htnn_csrf_token=" + token}, "X-Csrf-Token": []string{otherToken}},
+			msg:    "invalid CSRF token",
+		},
+		{
+			name:   "no cookie",
+			header: http.Header{"X-Csrf-Token": []string{token}},
+			msg:    "invalid CSRF token",
+		},
+		{
+			name:   "forged cookie",
+			header: http.Header{"Cookie": []string{"htnn_csrf_token=a.b"}, "X-Csrf-Token": []string{"a.b"}},
+			msg:    "invalid CSRF token",
+		},
+		{
+			name:   "missing token",
+			header: http.Header{"Cookie": []string{"htnn_csrf_token=" + token}, "Content-Type": []string{"application/json"}},
+			body:   `{}`,
+			msg:    "missing CSRF token",
+		},
+		{
+			name:   "urlencoded form",
+			header: http.Header{"Cookie": []string{"htnn_csrf_token=" + token}, "Content-Type": []string{"application/x-www-form-urlencoded"}},
+			body:   "name=a&csrf_token=" + token,
+		},
+		{
+			name:   "mismatched form",
+			header: http.Header{"Cookie": []string{"htnn_csrf_token=" + token}, "Content-Type": []string{"application/x-www-form-urlencoded"}},
+			body:   "name=a&csrf_token=" + otherToken,
+			msg:    "invalid CSRF token",
+		},
+		{
+			name:   "form without token",
+			header: http.Header{"Cookie": []string{"htnn_csrf_token=" + token}, "Content-Type": []string{"application/x-www-form-urlencoded"}},
+			body:   "name=a",
+			msg:    "missing CSRF token",
+		},
+		{
+			name:   "multipart form",
+			header: http.Header{"Cookie": []string{"htnn_csrf_token=" + token}, "Content-Type": []string{ct}},
+			body:   string(form),
+		},
+		{
+			name:   "multipart form without token",
+			header: http.Header{"Cookie": []string{"htnn_csrf_token=" + token}, "Content-Type": []string{ct}},
+			body:   string(formWithoutToken),
+			msg:    "missing CSRF token",
+		},
+		{
+			name:   "exempt path",
+			header: http.Header{":path": []string{"/webhooks/github"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cb := envoy.NewFilterCallbackHandler()
+			f := factory(conf, cb)
+			h := tt.header.Clone()
+			h.Set(":method", "POST")
+			if h.Get(":path") == "" {
+				h.Set(":path", "/submit")
+			}
+			hdr := envoy.NewRequestHeaderMap(h)
+
+			res := f.DecodeHeaders(hdr, tt.body == "")
+			if res == api.WaitAllData {
+				res = f.DecodeRequest(hdr, envoy.NewBufferInstance([]byte(tt.body)), nil)
+			}
+			if tt.msg == "" {
+				assert.Equal(t, api.Continue, res)
+			} else {
+				lr, ok := res.(*api.LocalResponse)
+				require.True(t, ok, res)
+				assert.Equal(t, 403, lr.Code)
+				assert.Equal(t, tt.msg, lr.Msg)
+			}
+		})
+	}
+}
+
+func TestTrustedOrigins(t *testing.T) {
+	conf := newConfig(t, `{"secret":"0123456789abcdef","trustedOrigins":["https://app.example.com"]}`)
+	token := conf.newToken()
+
+	tests := []struct {
+		name   string
+		header http.Header
+		pass   bool
+	}{
+		{
+			name:   "trusted origin",
+			header: http.Header{"Origin": []string{"https://APP.example.com"}},
+			pass:   true,
+		},
+		{
+			name:   "same origin",
+			header: http.Header{"Origin": []string{"https://api.example.com"}},
+			pass:   true,
+		},
+		{
+			name:   "untrusted origin",
+			header: http.Header{"Origin": []string{"https://evil.com"}},
+		},
+		{
+			name:   "referer",
+			header: http.Header{"Origin": []string{"null"}, "Referer": []string{"https://app.example.com/page?a=1"}},
+			pass:   true,
+		},
+		{
+			name:   "untrusted referer",
+			header: http.Header{"Referer": []string{"https://evil.com/page"}},
+		},
+		{
+			name:   "no origin",
+			header: http.Header{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cb := envoy.NewFilterCallbackHandler()
+			f := factory(conf, cb)
+			h := tt.header.Clone()
+			h.Set(":method", "DELETE")
+			h.Set(":path", "/")
+			h.Set(":authority", "api.example.com")
+			h.Set("Cookie", "htnn_csrf_token="+token)
+			h.Set("X-Csrf-Token", token)
+
+			res := f.DecodeHeaders(envoy.NewRequestHeaderMap(h), true)
+			if tt.pass {
+				assert.Equal(t, api.Continue, res)
+			} else {
+				lr, ok := res.(*api.LocalResponse)
+				require.True(t, ok, res)
+				assert.Equal(t, "origin not allowed", lr.Msg)
+			}
+		})
+	}
+}
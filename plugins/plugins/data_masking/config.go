@@ -0,0 +1,89 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package data_masking
+
+import (
+	"regexp"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/plugins"
+	"mosn.io/htnn/types/plugins/data_masking"
+	"mosn.io/htnn/types/plugins/json_transform"
+)
+
+const (
+	defaultMaskChar       = '*'
+	defaultMaxMatchLength = 256
+)
+
+func init() {
+	plugins.RegisterHttpPlugin(data_masking.Name, &plugin{})
+}
+
+type plugin struct {
+	data_masking.Plugin
+}
+
+func (p *plugin) Factory() api.FilterFactory {
+	return factory
+}
+
+func (p *plugin) Config() api.PluginConfig {
+	return &config{}
+}
+
+type config struct {
+	data_masking.CustomConfig
+
+	rules          []*rule
+	maxMatchLength int
+}
+
+func (conf *config) Init(cb api.ConfigCallbackHandler) error {
+	for _, r := range conf.Rules {
+		mr := &rule{
+			action:     r.Action,
+			maskChar:   defaultMaskChar,
+			keepPrefix: int(r.KeepPrefix),
+			keepSuffix: int(r.KeepSuffix),
+		}
+		if r.MaskChar != "" {
+			mr.maskChar = []rune(r.MaskChar)[0]
+		}
+		if r.Path != "" {
+			segs, err := json_transform.ParsePath(r.Path)
+			if err != nil {
+				return err
+			}
+			mr.path = segs
+		}
+		if r.Regex != "" {
+			re, err := regexp.Compile(r.Regex)
+			if err != nil {
+				return err
+			}
+			mr.regex = re
+		}
+		conf.rules = append(conf.rules, mr)
+	}
+
+	if conf.Streaming != nil {
+		conf.maxMatchLength = int(conf.Streaming.MaxMatchLength)
+		if conf.maxMatchLength == 0 {
+			conf.maxMatchLength = defaultMaxMatchLength
+		}
+	}
+	return nil
+}
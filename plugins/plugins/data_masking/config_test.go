@@ -0,0 +1,94 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package data_masking
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestConfig(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{
+			name:  "empty",
+			input: `{}`,
+			err:   "rules are required unless exempt is true",
+		},
+		{
+			name:  "exempt",
+			input: `{"exempt":true}`,
+		},
+		{
+			name:  "neither path nor regex",
+			input: `{"rules":[{"action":"HASH"}]}`,
+			err:   "rule 0: at least one of path and regex is required",
+		},
+		{
+			name:  "invalid path",
+			input: `{"rules":[{"path":"$.a["}]}`,
+			err:   "unclosed bracket",
+		},
+		{
+			name:  "mask root",
+			input: `{"rules":[{"path":"$"}]}`,
+			err:   "can't mask the root",
+		},
+		{
+			name:  "invalid regex",
+			input: `{"rules":[{"regex":"("}]}`,
+			err:   "error parsing regexp",
+		},
+		{
+			name:  "invalid mask char",
+			input: `{"rules":[{"regex":"a","maskChar":"**"}]}`,
+			err:   "invalid Rule.MaskChar",
+		},
+		{
+			name:  "path in streaming mode",
+			input: `{"rules":[{"path":"$.phone"}],"streaming":{}}`,
+			err:   "rule 0: path is not supported in streaming mode",
+		},
+		{
+			name: "pass",
+			input: `{"rules":[
+				{"path":"$.user.phone","keepPrefix":3,"keepSuffix":4},
+				{"path":"$.items[*].card","regex":"\\d{12}","action":"HASH"},
+				{"regex":"\\d{17}[\\dX]","action":"REMOVE"}
+			]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := &config{}
+			err := protojson.Unmarshal([]byte(tt.input), conf)
+			if err == nil {
+				err = conf.Validate()
+			}
+			if tt.err == "" {
+				assert.Nil(t, err)
+				assert.Nil(t, conf.Init(nil))
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,177 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package data_masking
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+)
+
+func factory(c interface{}, callbacks api.FilterCallbackHandler) api.Filter {
+	conf := c.(*config)
+	if conf.Exempt {
+		return &api.PassThroughFilter{}
+	}
+	return &filter{
+		callbacks: callbacks,
+		config:    conf,
+	}
+}
+
+type filter struct {
+	api.PassThroughFilter
+
+	callbacks api.FilterCallbackHandler
+	config    *config
+
+	buffered  bool
+	streaming bool
+	// the tail of the previous chunk which may contain a part of the sensitive data
+	carry []byte
+}
+
+func mediaType(headers api.HeaderMap) string {
+	ct, ok := headers.Get("content-type")
+	if !ok {
+		return ""
+	}
+	mt, _, err := mime.ParseMediaType(ct)
+	if err != nil {
+		return ""
+	}
+	return mt
+}
+
+func isJSON(mt string) bool {
+	return mt == "application/json" || strings.HasSuffix(mt, "+json")
+}
+
+func isText(mt string) bool {
+	return strings.HasPrefix(mt, "text/") || isJSON(mt) ||
+		mt == "application/xml" || strings.HasSuffix(mt, "+xml")
+}
+
+func (f *filter) EncodeHeaders(headers api.ResponseHeaderMap, endStream bool) api.ResultAction {
+	if endStream {
+		return api.Continue
+	}
+	if enc, ok := headers.Get("content-encoding"); ok && enc != "identity" {
+		api.LogDebugf("dataMasking: skip masking the response encoded with %s", enc)
+		return api.Continue
+	}
+
+	mt := mediaType(headers)
+	if f.config.Streaming != nil {
+		if !isText(mt) {
+			return api.Continue
+		}
+		// the length of the masked body is unknown in advance
+		headers.Del("content-length")
+		f.streaming = true
+		return api.Continue
+	}
+
+	if !isJSON(mt) {
+		return api.Continue
+	}
+	f.buffered = true
+	return api.WaitAllData
+}
+
+func (f *filter) EncodeResponse(headers api.ResponseHeaderMap, buf api.BufferInstance, trailers api.ResponseTrailerMap) api.ResultAction {
+	if !f.buffered || buf == nil || buf.Len() == 0 {
+		return api.Continue
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(buf.Bytes()))
+	// keep the precision of numbers
+	dec.UseNumber()
+	var body interface{}
+	if err := dec.Decode(&body); err != nil {
+		api.LogInfof("dataMasking: mask invalid JSON body as text: %v", err)
+		_ = buf.Set(f.maskText(buf.Bytes()))
+	} else {
+		for _, r := range f.config.rules {
+			body = r.maskJSON(body)
+		}
+		data, err := json.Marshal(body)
+		if err != nil {
+			api.LogErrorf("dataMasking: failed to marshal body: %v", err)
+			return api.Continue
+		}
+		_ = buf.Set(data)
+	}
+
+	if _, ok := headers.Get("content-length"); ok {
+		headers.Set("content-length", strconv.Itoa(buf.Len()))
+	}
+	return api.Continue
+}
+
+// maskText masks the text with the rules which only have regex
+func (f *filter) maskText(data []byte) []byte {
+	for _, r := range f.config.rules {
+		if r.path == nil {
+			data = r.maskText(data)
+		}
+	}
+	return data
+}
+
+func (f *filter) EncodeData(buf api.BufferInstance, endStream bool) api.ResultAction {
+	if !f.streaming {
+		return api.Continue
+	}
+
+	data := append(f.carry, buf.Bytes()...)
+	f.carry = nil
+	if endStream {
+		_ = buf.Set(f.maskText(data))
+		return api.Continue
+	}
+
+	// hold back the tail which may be a part of the sensitive data
+	end := len(data) - f.config.maxMatchLength
+	if end <= 0 {
+		f.carry = data
+		_ = buf.Set(nil)
+		return api.Continue
+	}
+	for end > 0 && !utf8.RuneStart(data[end]) {
+		end--
+	}
+	// don't cut a match in the middle
+	for changed := true; changed; {
+		changed = false
+		for _, r := range f.config.rules {
+			for _, loc := range r.regex.FindAllIndex(data, -1) {
+				if loc[0] < end && loc[1] > end {
+					end = loc[1]
+					changed = true
+				}
+			}
+		}
+	}
+
+	f.carry = append([]byte(nil), data[end:]...)
+	_ = buf.Set(f.maskText(data[:end]))
+	return api.Continue
+}
@@ -0,0 +1,229 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package data_masking
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/plugins/tests/pkg/envoy"
+)
+
+func newFilter(t *testing.T, input string) api.Filter {
+	conf := &config{}
+	require.Nil(t, protojson.Unmarshal([]byte(input), conf))
+	require.Nil(t, conf.Validate())
+	require.Nil(t, conf.Init(nil))
+
+	cb := envoy.NewFilterCallbackHandler()
+	return factory(conf, cb)
+}
+
+func TestTransform(t *testing.T) {
+	tests := []struct {
+		name   string
+		rule   string
+		input  string
+		output string
+	}{
+		{
+			name:   "mask all",
+			rule:   `{"regex":"."}`,
+			input:  "abc",
+			output: "***",
+		},
+		{
+			name:   "keep prefix and suffix",
+			rule:   `{"regex":".+","keepPrefix":3,"keepSuffix":4,"maskChar":"#"}`,
+			input:  "13812345678",
+			output: "138####5678",
+		},
+		{
+			name:   "too short to keep",
+			rule:   `{"regex":".+","keepPrefix":3,"keepSuffix":4}`,
+			input:  "1234567",
+			output: "*******",
+		},
+		{
+			name:   "unicode",
+			rule:   `{"regex":".+","keepPrefix":1}`,
+			input:  "张小明",
+			output: "张**",
+		},
+		{
+			name:   "hash",
+			rule:   `{"regex":".+","action":"HASH"}`,
+			input:  "abc",
+			output: "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad",
+		},
+		{
+			name:   "remove",
+			rule:   `{"regex":".+","action":"REMOVE"}`,
+			input:  "abc",
+			output: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := &config{}
+			require.Nil(t, protojson.Unmarshal([]byte(`{"rules":[`+tt.rule+`]}`), conf))
+			require.Nil(t, conf.Validate())
+			require.Nil(t, conf.Init(nil))
+			assert.Equal(t, tt.output, conf.rules[0].transform(tt.input))
+		})
+	}
+}
+
+func TestMaskJSON(t *testing.T) {
+	tests := []struct {
+		name   string
+		config string
+		input  string
+		output string
+	}{
+		{
+			name:   "path",
+			config: `{"rules":[{"path":"$.user.phone","keepPrefix":3,"keepSuffix":4},{"path":"$.missing.field"}]}`,
+			input:  `{"user":{"name":"a","phone":"13812345678"}}`,
+			output: `{"user":{"name":"a","phone":"138****5678"}}`,
+		},
+		{
+			name:   "number and container",
+			config: `{"rules":[{"path":"$.card"},{"path":"$.user"}]}`,
+			input:  `{"card":6222021234,"user":{"name":"ab","ids":["c",1],"ok":true,"none":null}}`,
+			output: `{"card":"**********","user":{"ids":["*","*"],"name":"**","none":null,"ok":true}}`,
+		},
+		{
+			name:   "wildcard with regex",
+			config: `{"rules":[{"path":"$.items[*].note","regex":"\\d{4}","action":"REMOVE"}]}`,
+			input:  `{"items":[{"note":"card 1234 end"},{"note":"none"},{"other":"1234"}]}`,
+			output: `{"items":[{"note":"card  end"},{"note":"none"},{"other":"1234"}]}`,
+		},
+		{
+			name:   "remove",
+			config: `{"rules":[{"path":"$.items[*].secret","action":"REMOVE"},{"path":"$.tags[0]","action":"REMOVE"},{"path":"$.*.token","action":"REMOVE"}]}`,
+			input:  `{"items":[{"id":1,"secret":"a"},{"id":2,"secret":"b"}],"tags":["x","y"],"a":{"token":"t","b":1}}`,
+			output: `{"a":{"b":1},"items":[{"id":1},{"id":2}],"tags":["y"]}`,
+		},
+		{
+			name:   "regex without path",
+			config: `{"rules":[{"regex":"1[3-9]\\d{9}","keepPrefix":3,"keepSuffix":4}]}`,
+			input:  `{"phone":"13812345678","list":[{"desc":"call 13912345678"}],"num":13812345678,"big":12345678901234567890}`,
+			output: `{"big":12345678901234567890,"list":[{"desc":"call 139****5678"}],"num":"138****5678","phone":"138****5678"}`,
+		},
+		{
+			name:   "invalid JSON",
+			config: `{"rules":[{"path":"$.phone"},{"regex":"\\d{11}","action":"HASH"}]}`,
+			input:  `{"phone":"13812345678"`,
+			output: `{"phone":"38aed9048140b0e437ea81461d9ea4524169f6795004da120bcf7d41894e4d15"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFilter(t, tt.config)
+			hdr := envoy.NewResponseHeaderMap(http.Header{
+				"Content-Type":   []string{"application/json"},
+				"Content-Length": []string{"100"},
+			})
+			assert.Equal(t, api.WaitAllData, f.EncodeHeaders(hdr, false))
+			buf := envoy.NewBufferInstance([]byte(tt.input))
+			assert.Equal(t, api.Continue, f.EncodeResponse(hdr, buf, nil))
+			assert.Equal(t, tt.output, buf.String())
+			cl, _ := hdr.Get("content-length")
+			assert.Equal(t, strconv.Itoa(len(tt.output)), cl)
+		})
+	}
+}
+
+func TestSkipMasking(t *testing.T) {
+	tests := []struct {
+		name      string
+		config    string
+		header    http.Header
+		endStream bool
+	}{
+		{
+			name:   "not JSON",
+			config: `{"rules":[{"path":"$.a"}]}`,
+			header: http.Header{"Content-Type": []string{"text/plain"}},
+		},
+		{
+			name:   "compressed",
+			config: `{"rules":[{"path":"$.a"}]}`,
+			header: http.Header{"Content-Type": []string{"application/json"}, "Content-Encoding": []string{"gzip"}},
+		},
+		{
+			name:      "no body",
+			config:    `{"rules":[{"path":"$.a"}]}`,
+			header:    http.Header{"Content-Type": []string{"application/json"}},
+			endStream: true,
+		},
+		{
+			name:   "binary in streaming mode",
+			config: `{"rules":[{"regex":"a"}],"streaming":{}}`,
+			header: http.Header{"Content-Type": []string{"application/octet-stream"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFilter(t, tt.config)
+			assert.Equal(t, api.Continue, f.EncodeHeaders(envoy.NewResponseHeaderMap(tt.header), tt.endStream))
+			buf := envoy.NewBufferInstance([]byte("a"))
+			assert.Equal(t, api.Continue, f.EncodeData(buf, true))
+			assert.Equal(t, "a", buf.String())
+		})
+	}
+}
+
+func TestExempt(t *testing.T) {
+	f := newFilter(t, `{"exempt":true}`)
+	_, ok := f.(*filter)
+	assert.False(t, ok)
+}
+
+func TestStreaming(t *testing.T) {
+	f := newFilter(t, `{"rules":[{"regex":"1[3-9]\\d{9}","keepPrefix":3,"keepSuffix":4}],"streaming":{"maxMatchLength":16}}`)
+	hdr := envoy.NewResponseHeaderMap(http.Header{
+		"Content-Type":   []string{"text/plain; charset=utf-8"},
+		"Content-Length": []string{"100"},
+	})
+	assert.Equal(t, api.Continue, f.EncodeHeaders(hdr, false))
+	_, ok := hdr.Get("content-length")
+	assert.False(t, ok)
+
+	input := "电话: 13812345678, 备用电话: 13912345678, " + strings.Repeat("x", 20) + "end 13712345678"
+	var out strings.Builder
+	// split the input into small chunks so the phone numbers are cut across chunks
+	for i := 0; i < len(input); i += 5 {
+		end := i + 5
+		if end > len(input) {
+			end = len(input)
+		}
+		buf := envoy.NewBufferInstance([]byte(input[i:end]))
+		assert.Equal(t, api.Continue, f.EncodeData(buf, end == len(input)))
+		out.WriteString(buf.String())
+	}
+	assert.Equal(t, "电话: 138****5678, 备用电话: 139****5678, "+strings.Repeat("x", 20)+"end 137****5678", out.String())
+}
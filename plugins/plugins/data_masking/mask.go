@@ -0,0 +1,161 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package data_masking
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"regexp"
+	"strings"
+
+	"mosn.io/htnn/types/plugins/data_masking"
+	"mosn.io/htnn/types/plugins/json_transform"
+)
+
+type rule struct {
+	path       []json_transform.Segment
+	regex      *regexp.Regexp
+	action     data_masking.Action
+	maskChar   rune
+	keepPrefix int
+	keepSuffix int
+}
+
+// transform masks, hashes or removes the given sensitive data
+func (r *rule) transform(s string) string {
+	switch r.action {
+	case data_masking.Action_HASH:
+		sum := sha256.Sum256([]byte(s))
+		return hex.EncodeToString(sum[:])
+	case data_masking.Action_REMOVE:
+		return ""
+	}
+
+	runes := []rune(s)
+	n := len(runes)
+	if r.keepPrefix+r.keepSuffix >= n {
+		// too short to keep anything
+		return strings.Repeat(string(r.maskChar), n)
+	}
+	for i := r.keepPrefix; i < n-r.keepSuffix; i++ {
+		runes[i] = r.maskChar
+	}
+	return string(runes)
+}
+
+func (r *rule) maskText(data []byte) []byte {
+	return r.regex.ReplaceAllFunc(data, func(b []byte) []byte {
+		return []byte(r.transform(string(b)))
+	})
+}
+
+// maskValue masks the JSON value. It returns false if the value should be removed.
+func (r *rule) maskValue(v interface{}) (interface{}, bool) {
+	if r.regex == nil && r.action == data_masking.Action_REMOVE {
+		return nil, false
+	}
+
+	switch val := v.(type) {
+	case nil:
+		return nil, true
+	case map[string]interface{}:
+		for k, child := range val {
+			if nv, keep := r.maskValue(child); keep {
+				val[k] = nv
+			} else {
+				delete(val, k)
+			}
+		}
+		return val, true
+	case []interface{}:
+		res := val[:0]
+		for _, child := range val {
+			if nv, keep := r.maskValue(child); keep {
+				res = append(res, nv)
+			}
+		}
+		return res, true
+	case string:
+		return r.maskString(val), true
+	case json.Number:
+		s := val.String()
+		if ns := r.maskString(s); ns != s {
+			// the masked number becomes a string
+			return ns, true
+		}
+		return val, true
+	}
+	// bool is not sensitive
+	return v, true
+}
+
+func (r *rule) maskString(s string) string {
+	if r.regex == nil {
+		return r.transform(s)
+	}
+	return r.regex.ReplaceAllStringFunc(s, r.transform)
+}
+
+// applyPath masks the values matched by the path. It returns false if the node itself should be removed.
+func (r *rule) applyPath(node interface{}, segs []json_transform.Segment) (interface{}, bool) {
+	if len(segs) == 0 {
+		return r.maskValue(node)
+	}
+
+	seg := segs[0]
+	switch n := node.(type) {
+	case map[string]interface{}:
+		if seg.Index >= 0 {
+			return node, true
+		}
+		for k, child := range n {
+			if !seg.Wildcard && k != seg.Key {
+				continue
+			}
+			if nv, keep := r.applyPath(child, segs[1:]); keep {
+				n[k] = nv
+			} else {
+				delete(n, k)
+			}
+		}
+	case []interface{}:
+		if !seg.Wildcard && (seg.Index < 0 || seg.Index >= len(n)) {
+			return node, true
+		}
+		res := n[:0]
+		for i, child := range n {
+			if !seg.Wildcard && i != seg.Index {
+				res = append(res, child)
+				continue
+			}
+			if nv, keep := r.applyPath(child, segs[1:]); keep {
+				res = append(res, nv)
+			}
+		}
+		return res, true
+	}
+	return node, true
+}
+
+func (r *rule) maskJSON(body interface{}) interface{} {
+	if r.path == nil {
+		// mask all the string values
+		body, _ = r.maskValue(body)
+		return body
+	}
+	body, _ = r.applyPath(body, r.path)
+	return body
+}
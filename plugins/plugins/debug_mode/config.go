@@ -15,13 +15,25 @@
 package debug_mode
 
 import (
+	"runtime"
+	"time"
+
+	"github.com/google/cel-go/cel"
+
 	"mosn.io/htnn/api/pkg/filtermanager/api"
 	"mosn.io/htnn/api/pkg/plugins"
+	"mosn.io/htnn/plugins/pkg/redact"
+	"mosn.io/htnn/plugins/pkg/sink"
+	"mosn.io/htnn/types/pkg/expr"
 	"mosn.io/htnn/types/plugins/debug_mode"
 )
 
 const (
 	Name = debug_mode.Name
+
+	defaultTriggerHeader = "x-htnn-debug"
+	defaultMaxAge        = 5 * time.Minute
+	defaultReportHeader  = "x-htnn-debug-report"
 )
 
 func init() {
@@ -35,3 +47,66 @@ type plugin struct {
 func (p *plugin) Factory() api.FilterFactory {
 	return factory
 }
+
+func (p *plugin) Config() api.PluginConfig {
+	return &config{}
+}
+
+type config struct {
+	debug_mode.CustomConfig
+
+	triggerScript expr.Script
+	secret        []byte
+	triggerHeader string
+	maxAge        time.Duration
+	reportHeader  string
+
+	redacted    map[string]struct{}
+	maxBodySize int
+	writer      *sink.BatchWriter
+}
+
+func (conf *config) Init(cb api.ConfigCallbackHandler) error {
+	if trigger := conf.Trigger; trigger != nil {
+		if trigger.Expr != "" {
+			conf.triggerScript, _ = expr.CompileCel(trigger.Expr, cel.BoolType)
+		}
+
+		if trigger.Hmac != nil {
+			conf.secret = []byte(trigger.Hmac.Secret)
+			conf.triggerHeader = trigger.Hmac.Header
+			if conf.triggerHeader == "" {
+				conf.triggerHeader = defaultTriggerHeader
+			}
+			conf.maxAge = defaultMaxAge
+			if trigger.Hmac.MaxAge != nil {
+				conf.maxAge = trigger.Hmac.MaxAge.AsDuration()
+			}
+		}
+	}
+
+	if conf.Report != nil {
+		conf.reportHeader = conf.Report.Header
+		if conf.reportHeader == "" {
+			conf.reportHeader = defaultReportHeader
+		}
+	}
+
+	if slowLog := conf.SlowLog; slowLog != nil {
+		conf.redacted = redact.HeaderSet(slowLog.RedactedHeaders)
+
+		conf.maxBodySize = int(slowLog.MaxBodySize)
+
+		if slowLog.Sink != nil {
+			writer, err := sink.GetWriter(Name, slowLog.Sink, slowLog.Batch)
+			if err != nil {
+				return err
+			}
+			conf.writer = writer
+			runtime.SetFinalizer(conf, func(conf *config) {
+				sink.ReleaseWriter(conf.writer)
+			})
+		}
+	}
+	return nil
+}
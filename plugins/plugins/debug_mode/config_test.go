@@ -19,9 +19,8 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/encoding/protojson"
-
-	"mosn.io/htnn/types/plugins/debug_mode"
 )
 
 func TestConfig(t *testing.T) {
@@ -40,16 +39,61 @@ func TestConfig(t *testing.T) {
 			input: `{"slowLog":{}}`,
 			err:   "value is required",
 		},
+		{
+			name:  "sink is required",
+			input: `{"slowLog":{"threshold":"1s","sink":{}}}`,
+			err:   "invalid Sink.Sink: value is required",
+		},
+		{
+			name:  "kafka brokers are required",
+			input: `{"slowLog":{"threshold":"1s","sink":{"kafka":{"topic":"slow"}}}}`,
+			err:   "invalid KafkaSink.Brokers",
+		},
+		{
+			name:  "body size limit",
+			input: `{"slowLog":{"threshold":"1s","maxBodySize":1048577}}`,
+			err:   "invalid SlowLog.MaxBodySize",
+		},
+		{
+			name:  "slow log with sink",
+			input: `{"slowLog":{"threshold":"1s","maxBodySize":1024,"sink":{"kafka":{"brokers":["127.0.0.1:9092"],"topic":"slow"}}}}`,
+		},
+		{
+			name:  "empty trigger",
+			input: `{"trigger":{}}`,
+			err:   "either hmac or expr is required in trigger",
+		},
+		{
+			name:  "secret is required",
+			input: `{"trigger":{"hmac":{}}}`,
+			err:   "invalid HmacTrigger.Secret",
+		},
+		{
+			name:  "bad expr",
+			input: `{"trigger":{"expr":"request.header(\"x-debug\")"}}`,
+			err:   "got string, wanted bool",
+		},
+		{
+			name:  "report requires trigger",
+			input: `{"report":{}}`,
+			err:   "report requires trigger",
+		},
+		{
+			name:  "trigger",
+			input: `{"trigger":{"hmac":{"secret":"xxx","maxAge":"60s"},"expr":"request.header(\"x-debug\") == \"1\""},"report":{"trailer":true}}`,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			conf := &debug_mode.Config{}
+			conf := &config{}
 			err := protojson.Unmarshal([]byte(tt.input), conf)
 			if err == nil {
 				err = conf.Validate()
 			}
 			if tt.err == "" {
+				require.Nil(t, err)
+				err = conf.Init(nil)
 				assert.Nil(t, err)
 			} else {
 				assert.ErrorContains(t, err, tt.err)
@@ -15,26 +15,43 @@
 package debug_mode
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"strconv"
+	"strings"
 	"time"
 
 	"mosn.io/htnn/api/pkg/filtermanager/api"
 	"mosn.io/htnn/api/pkg/filtermanager/model"
-	"mosn.io/htnn/types/plugins/debug_mode"
+	"mosn.io/htnn/plugins/pkg/redact"
 )
 
 func factory(c interface{}, callbacks api.FilterCallbackHandler) api.Filter {
-	return &filter{
+	conf := c.(*config)
+	f := &filter{
 		callbacks: callbacks,
-		config:    c.(*debug_mode.Config),
+		config:    conf,
+		enabled:   conf.Trigger == nil,
 	}
+	if conf.maxBodySize > 0 {
+		return &bodyCaptureFilter{filter: f}
+	}
+	// the data methods are not defined, so the body is not passed to Go when it is not captured
+	return f
 }
 
 type filter struct {
 	api.PassThroughFilter
 
 	callbacks api.FilterCallbackHandler
-	config    *debug_mode.Config
+	config    *config
+
+	enabled bool
+
+	reqBody  []byte
+	respBody []byte
 }
 
 type executionPlugin struct {
@@ -47,22 +64,199 @@ type SlowLogReport struct {
 
 	Request struct {
 		Headers map[string][]string `json:"headers"`
+		// The first bytes of the body, up to the max_body_size
+		Body string `json:"body,omitempty"`
 	} `json:"request"`
 	Response struct {
 		Headers map[string][]string `json:"headers"`
+		Body    string              `json:"body,omitempty"`
 	} `json:"response,omitempty"`
 	StreamInfo struct {
 		DownstreamRemoteAddress string `json:"downstream_remote_address"`
 		UpstreamRemoteAddress   string `json:"upstream_remote_address,omitempty"`
 	} `json:"stream_info"`
 
+	Consumer string `json:"consumer,omitempty"`
+	// The plugins run in this request, including the ones from consumer
+	Plugins []string `json:"plugins,omitempty"`
 	// Note: the ExecutedPlugins don't contain plugins executed in OnLog phase
 	ExecutedPlugins []executionPlugin `json:"executed_plugins,omitempty"`
+	// The state reported by the plugins, like the current concurrency limit of loadShedding
+	PluginReports map[string]any `json:"plugin_reports,omitempty"`
+}
+
+// Report is the execution report returned in the response
+type Report struct {
+	Consumer        string            `json:"consumer,omitempty"`
+	Plugins         []string          `json:"plugins,omitempty"`
+	ExecutedPlugins []executionPlugin `json:"executed_plugins,omitempty"`
+}
+
+func sign(secret []byte, timestamp string) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(timestamp))
+	return mac.Sum(nil)
+}
+
+// verifySignature checks the value in the format of `<unix timestamp>:<hex encoded signature>`,
+// where the signature is the HMAC-SHA256 of the timestamp
+func (f *filter) verifySignature(value string) bool {
+	ts, sig, ok := strings.Cut(value, ":")
+	if !ok {
+		return false
+	}
+	sec, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return false
+	}
+	age := time.Since(time.Unix(sec, 0))
+	if age < 0 {
+		// allow the clock skew
+		age = -age
+	}
+	if age > f.config.maxAge {
+		return false
+	}
+
+	got, err := hex.DecodeString(sig)
+	if err != nil {
+		return false
+	}
+	return hmac.Equal(got, sign(f.config.secret, ts))
+}
+
+func (f *filter) triggered(headers api.RequestHeaderMap) bool {
+	config := f.config
+	if config.secret != nil {
+		if v, ok := headers.Get(config.triggerHeader); ok && f.verifySignature(v) {
+			return true
+		}
+	}
+
+	if config.triggerScript != nil {
+		res, err := config.triggerScript.EvalWithRequest(f.callbacks, headers)
+		if err != nil {
+			api.LogErrorf("failed to eval trigger expression: %v", err)
+			return false
+		}
+		return res.(bool)
+	}
+	return false
+}
+
+func (f *filter) DecodeHeaders(headers api.RequestHeaderMap, endStream bool) api.ResultAction {
+	config := f.config
+	if config.Trigger == nil {
+		return api.Continue
+	}
+
+	f.enabled = f.triggered(headers)
+	if config.secret != nil {
+		// the signature is for the gateway only
+		headers.Del(config.triggerHeader)
+	}
+
+	// This is a private API and we don't guarantee its stability
+	state := f.callbacks.PluginState()
+	state.Set("debugMode", "enabled", f.enabled)
+	if !f.enabled {
+		// drop the records of the plugins run before this plugin
+		state.Set("debugMode", "executionRecords", nil)
+	}
+	return api.Continue
+}
+
+func (f *filter) captureBody(body []byte, data api.BufferInstance) []byte {
+	limit := f.config.maxBodySize
+	if !f.enabled || len(body) >= limit {
+		return body
+	}
+	b := data.Bytes()
+	if len(body)+len(b) > limit {
+		b = b[:limit-len(body)]
+	}
+	return append(body, b...)
+}
+
+func (f *filter) executedPlugins() []executionPlugin {
+	// This is a private API and we don't guarantee its stability
+	r, _ := f.callbacks.PluginState().Get("debugMode", "executionRecords").([]model.ExecutionRecord)
+	if len(r) == 0 {
+		return nil
+	}
+
+	plugins := make([]executionPlugin, 0, len(r))
+	for _, record := range r {
+		p := executionPlugin{
+			Name: record.PluginName,
+		}
+		p.PerPhaseCostSeconds = make(map[string]float64)
+		for k, v := range record.Record {
+			p.PerPhaseCostSeconds[k] = v.Seconds()
+		}
+		plugins = append(plugins, p)
+	}
+	return plugins
+}
+
+func (f *filter) plugins() []string {
+	plugins, _ := f.callbacks.PluginState().Get("debugMode", "plugins").([]string)
+	return plugins
+}
+
+func (f *filter) consumer() string {
+	c := f.callbacks.GetConsumer()
+	if c == nil {
+		return ""
+	}
+	return c.Name()
+}
+
+func (f *filter) report() string {
+	report := &Report{
+		Consumer:        f.consumer(),
+		Plugins:         f.plugins(),
+		ExecutedPlugins: f.executedPlugins(),
+	}
+	b, _ := json.Marshal(report)
+	return string(b)
+}
+
+func (f *filter) headers(headers api.HeaderMap) map[string][]string {
+	m := make(map[string][]string)
+	headers.Range(func(key, value string) bool {
+		if _, ok := f.config.redacted[strings.ToLower(key)]; ok {
+			value = redact.Value
+		}
+		m[key] = append(m[key], value)
+		return true
+	})
+	return m
+}
+
+func (f *filter) EncodeHeaders(headers api.ResponseHeaderMap, endStream bool) api.ResultAction {
+	report := f.config.Report
+	if f.enabled && report != nil && !report.Trailer {
+		headers.Set(f.config.reportHeader, f.report())
+	}
+	return api.Continue
+}
+
+func (f *filter) EncodeTrailers(trailers api.ResponseTrailerMap) api.ResultAction {
+	report := f.config.Report
+	if f.enabled && report != nil && report.Trailer {
+		trailers.Set(f.config.reportHeader, f.report())
+	}
+	return api.Continue
 }
 
 func (f *filter) OnLog(reqHeaders api.RequestHeaderMap, reqTrailers api.RequestTrailerMap,
 	respHeaders api.ResponseHeaderMap, respTrailers api.ResponseTrailerMap) {
 
+	if !f.enabled {
+		return
+	}
+
 	config := f.config
 
 	slowLog := config.GetSlowLog()
@@ -87,38 +281,61 @@ func (f *filter) OnLog(reqHeaders api.RequestHeaderMap, reqTrailers api.RequestT
 			report.StreamInfo.DownstreamRemoteAddress = f.callbacks.StreamInfo().DownstreamRemoteAddress()
 			report.StreamInfo.UpstreamRemoteAddress, _ = f.callbacks.StreamInfo().UpstreamRemoteAddress()
 
-			report.Request.Headers = make(map[string][]string)
-			reqHeaders.Range(func(key, value string) bool {
-				report.Request.Headers[key] = append(report.Request.Headers[key], value)
-				return true
-			})
-
+			report.Request.Headers = f.headers(reqHeaders)
+			report.Request.Body = string(f.reqBody)
 			if respHeaders != nil {
-				report.Response.Headers = make(map[string][]string)
-				respHeaders.Range(func(key, value string) bool {
-					report.Response.Headers[key] = append(report.Response.Headers[key], value)
-					return true
-				})
+				report.Response.Headers = f.headers(respHeaders)
 			}
+			report.Response.Body = string(f.respBody)
+
+			report.Consumer = f.consumer()
+			report.Plugins = f.plugins()
+			report.ExecutedPlugins = f.executedPlugins()
 
-			// This is a private API and we don't guarantee its stablibity
-			r := f.callbacks.PluginState().Get("debugMode", "executionRecords")
-			if r != nil {
-				executionRecords := r.([]model.ExecutionRecord)
-				for _, record := range executionRecords {
-					p := executionPlugin{
-						Name: record.PluginName,
-					}
-					p.PerPhaseCostSeconds = make(map[string]float64)
-					for k, v := range record.Record {
-						p.PerPhaseCostSeconds[k] = v.Seconds()
-					}
-					report.ExecutedPlugins = append(report.ExecutedPlugins, p)
-				}
+			reports := f.callbacks.PluginState().Get("debugMode", "pluginReports")
+			if reports != nil {
+				report.PluginReports = reports.(map[string]any)
 			}
 
 			b, _ := json.Marshal(report)
 			api.LogErrorf("slow log report: %s", b)
+			if config.writer != nil {
+				config.writer.Add(b)
+			}
 		}
 	}
 }
+
+// bodyCaptureFilter also captures the body for the slow log. The filter manager treats the
+// promoted methods as not defined, so the methods of filter are forwarded explicitly.
+type bodyCaptureFilter struct {
+	*filter
+}
+
+func (f *bodyCaptureFilter) DecodeHeaders(headers api.RequestHeaderMap, endStream bool) api.ResultAction {
+	return f.filter.DecodeHeaders(headers, endStream)
+}
+
+func (f *bodyCaptureFilter) DecodeData(data api.BufferInstance, endStream bool) api.ResultAction {
+	f.reqBody = f.captureBody(f.reqBody, data)
+	return api.Continue
+}
+
+func (f *bodyCaptureFilter) EncodeHeaders(headers api.ResponseHeaderMap, endStream bool) api.ResultAction {
+	return f.filter.EncodeHeaders(headers, endStream)
+}
+
+func (f *bodyCaptureFilter) EncodeData(data api.BufferInstance, endStream bool) api.ResultAction {
+	f.respBody = f.captureBody(f.respBody, data)
+	return api.Continue
+}
+
+func (f *bodyCaptureFilter) EncodeTrailers(trailers api.ResponseTrailerMap) api.ResultAction {
+	return f.filter.EncodeTrailers(trailers)
+}
+
+func (f *bodyCaptureFilter) OnLog(reqHeaders api.RequestHeaderMap, reqTrailers api.RequestTrailerMap,
+	respHeaders api.ResponseHeaderMap, respTrailers api.ResponseTrailerMap) {
+
+	f.filter.OnLog(reqHeaders, reqTrailers, respHeaders, respTrailers)
+}
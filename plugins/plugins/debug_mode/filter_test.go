@@ -0,0 +1,255 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debug_mode
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/filtermanager/model"
+	"mosn.io/htnn/api/plugins/tests/pkg/envoy"
+)
+
+type consumer struct {
+	name string
+}
+
+func (c *consumer) Name() string {
+	return c.name
+}
+
+func (c *consumer) PluginConfig(name string) api.PluginConsumerConfig {
+	return nil
+}
+
+func newConfig(t *testing.T, input string) *config {
+	conf := &config{}
+	require.Nil(t, protojson.Unmarshal([]byte(input), conf))
+	require.Nil(t, conf.Validate())
+	require.Nil(t, conf.Init(nil))
+	return conf
+}
+
+func signature(secret string, ts time.Time) string {
+	s := strconv.FormatInt(ts.Unix(), 10)
+	return s + ":" + hex.EncodeToString(sign([]byte(secret), s))
+}
+
+func TestTrigger(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name    string
+		input   string
+		header  http.Header
+		enabled bool
+	}{
+		{
+			name:    "no trigger",
+			input:   `{}`,
+			enabled: true,
+		},
+		{
+			name:    "valid signature",
+			input:   `{"trigger":{"hmac":{"secret":"xxx"}}}`,
+			header:  http.Header{"X-Htnn-Debug": []string{signature("xxx", now)}},
+			enabled: true,
+		},
+		{
+			name:    "clock skew",
+			input:   `{"trigger":{"hmac":{"secret":"xxx"}}}`,
+			header:  http.Header{"X-Htnn-Debug": []string{signature("xxx", now.Add(time.Minute))}},
+			enabled: true,
+		},
+		{
+			name:    "custom header",
+			input:   `{"trigger":{"hmac":{"secret":"xxx","header":"x-debug"}}}`,
+			header:  http.Header{"X-Debug": []string{signature("xxx", now)}},
+			enabled: true,
+		},
+		{
+			name:   "no signature",
+			input:  `{"trigger":{"hmac":{"secret":"xxx"}}}`,
+			header: http.Header{},
+		},
+		{
+			name:   "wrong secret",
+			input:  `{"trigger":{"hmac":{"secret":"xxx"}}}`,
+			header: http.Header{"X-Htnn-Debug": []string{signature("yyy", now)}},
+		},
+		{
+			name:   "expired",
+			input:  `{"trigger":{"hmac":{"secret":"xxx","maxAge":"60s"}}}`,
+			header: http.Header{"X-Htnn-Debug": []string{signature("xxx", now.Add(-2*time.Minute))}},
+		},
+		{
+			name:   "bad format",
+			input:  `{"trigger":{"hmac":{"secret":"xxx"}}}`,
+			header: http.Header{"X-Htnn-Debug": []string{"1234"}},
+		},
+		{
+			name:    "expr",
+			input:   `{"trigger":{"expr":"request.header(\"x-user\") == \"tester\""}}`,
+			header:  http.Header{"X-User": []string{"tester"}},
+			enabled: true,
+		},
+		{
+			name:   "expr not matched",
+			input:  `{"trigger":{"expr":"request.header(\"x-user\") == \"tester\""}}`,
+			header: http.Header{"X-User": []string{"alice"}},
+		},
+		{
+			name:    "hmac or expr",
+			input:   `{"trigger":{"hmac":{"secret":"xxx"},"expr":"request.header(\"x-user\") == \"tester\""}}`,
+			header:  http.Header{"X-User": []string{"tester"}},
+			enabled: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cb := envoy.NewFilterCallbackHandler()
+			cb.PluginState().Set("debugMode", "executionRecords", []model.ExecutionRecord{
+				{PluginName: "outer", Record: map[string]time.Duration{"DecodeHeaders": time.Millisecond}},
+			})
+			f := factory(newConfig(t, tt.input), cb).(*filter)
+			hdr := envoy.NewRequestHeaderMap(tt.header)
+			f.DecodeHeaders(hdr, true)
+			assert.Equal(t, tt.enabled, f.enabled)
+
+			_, ok := hdr.Get("x-htnn-debug")
+			assert.False(t, ok)
+
+			records := cb.PluginState().Get("debugMode", "executionRecords")
+			if tt.enabled {
+				assert.NotNil(t, records)
+			} else {
+				assert.Nil(t, records)
+				assert.Equal(t, false, cb.PluginState().Get("debugMode", "enabled"))
+			}
+		})
+	}
+}
+
+func TestReport(t *testing.T) {
+	secret := "xxx"
+	input := `{"trigger":{"hmac":{"secret":"` + secret + `"}},"report":{}}`
+	newFilter := func(conf *config) (*filter, api.FilterCallbackHandler) {
+		cb := envoy.NewFilterCallbackHandler()
+		cb.SetConsumer(&consumer{name: "rick"})
+		cb.PluginState().Set("debugMode", "plugins", []string{"debugMode", "keyAuth", "limitReq"})
+		cb.PluginState().Set("debugMode", "executionRecords", []model.ExecutionRecord{
+			{PluginName: "limitReq", Record: map[string]time.Duration{"DecodeHeaders": 2 * time.Second}},
+		})
+		return factory(conf, cb).(*filter), cb
+	}
+
+	f, _ := newFilter(newConfig(t, input))
+	f.DecodeHeaders(envoy.NewRequestHeaderMap(http.Header{
+		"X-Htnn-Debug": []string{signature(secret, time.Now())},
+	}), true)
+	respHdr := envoy.NewResponseHeaderMap(http.Header{})
+	f.EncodeHeaders(respHdr, true)
+	v, ok := respHdr.Get("x-htnn-debug-report")
+	require.True(t, ok)
+	report := &Report{}
+	require.Nil(t, json.Unmarshal([]byte(v), report))
+	assert.Equal(t, "rick", report.Consumer)
+	assert.Equal(t, []string{"debugMode", "keyAuth", "limitReq"}, report.Plugins)
+	assert.Equal(t, "limitReq", report.ExecutedPlugins[0].Name)
+	assert.Equal(t, float64(2), report.ExecutedPlugins[0].PerPhaseCostSeconds["DecodeHeaders"])
+
+	// not triggered
+	f, _ = newFilter(newConfig(t, input))
+	f.DecodeHeaders(envoy.NewRequestHeaderMap(http.Header{}), true)
+	respHdr = envoy.NewResponseHeaderMap(http.Header{})
+	f.EncodeHeaders(respHdr, true)
+	_, ok = respHdr.Get("x-htnn-debug-report")
+	assert.False(t, ok)
+
+	// report in the trailer
+	f, _ = newFilter(newConfig(t, `{"trigger":{"expr":"true"},"report":{"header":"x-report","trailer":true}}`))
+	f.DecodeHeaders(envoy.NewRequestHeaderMap(http.Header{}), true)
+	respHdr = envoy.NewResponseHeaderMap(http.Header{})
+	f.EncodeHeaders(respHdr, false)
+	_, ok = respHdr.Get("x-report")
+	assert.False(t, ok)
+	trailers := envoy.NewResponseTrailerMap(http.Header{})
+	f.EncodeTrailers(trailers)
+	v, ok = trailers.Get("x-report")
+	require.True(t, ok)
+	assert.Contains(t, v, `"consumer":"rick"`)
+}
+
+type slowCallbacks struct {
+	api.FilterCallbackHandler
+}
+
+func (cb *slowCallbacks) GetProperty(key string) (string, error) {
+	if key == "request.duration" {
+		return "2s", nil
+	}
+	return cb.FilterCallbackHandler.GetProperty(key)
+}
+
+func TestSlowLogExport(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "slow.log")
+	conf := newConfig(t, `{"slowLog":{"threshold":"1s","maxBodySize":5,"redactedHeaders":["x-token"],
+		"sink":{"file":{"path":"`+path+`"}},"batch":{"flushInterval":"0.01s"}}}`)
+	cb := &slowCallbacks{envoy.NewFilterCallbackHandler()}
+	f := factory(conf, cb).(*bodyCaptureFilter)
+
+	reqHdr := envoy.NewRequestHeaderMap(http.Header{
+		"X-Token":       []string{"secret"},
+		"Authorization": []string{"Bearer xxx"},
+	})
+	f.DecodeHeaders(reqHdr, false)
+	f.DecodeData(envoy.NewBufferInstance([]byte("abc")), false)
+	f.DecodeData(envoy.NewBufferInstance([]byte("defgh")), true)
+	respHdr := envoy.NewResponseHeaderMap(http.Header{})
+	f.EncodeHeaders(respHdr, false)
+	f.EncodeData(envoy.NewBufferInstance([]byte("ok")), true)
+	f.OnLog(reqHdr, nil, respHdr, nil)
+
+	var b []byte
+	require.Eventually(t, func() bool {
+		b, _ = os.ReadFile(path)
+		return len(b) > 0
+	}, time.Second, 10*time.Millisecond)
+
+	report := &SlowLogReport{}
+	require.Nil(t, json.Unmarshal(bytes.TrimSpace(b), report))
+	assert.Equal(t, float64(2), report.TotalSeconds)
+	assert.Equal(t, "abcde", report.Request.Body)
+	assert.Equal(t, "ok", report.Response.Body)
+	assert.Equal(t, []string{"***"}, report.Request.Headers["X-Token"])
+	// the default redacted headers are still redacted
+	assert.Equal(t, []string{"***"}, report.Request.Headers["Authorization"])
+
+	// the data methods are not defined when the body is not captured
+	_, ok := factory(newConfig(t, `{"slowLog":{"threshold":"1s"}}`), cb).(*filter)
+	assert.True(t, ok)
+}
@@ -20,6 +20,7 @@ import (
 
 	"mosn.io/htnn/api/pkg/filtermanager/api"
 	"mosn.io/htnn/api/pkg/plugins"
+	"mosn.io/htnn/api/pkg/tracing"
 	"mosn.io/htnn/types/pkg/expr"
 	"mosn.io/htnn/types/plugins/ext_auth"
 )
@@ -55,7 +56,10 @@ func (conf *config) Init(cb api.ConfigCallbackHandler) error {
 		du = timeout.AsDuration()
 	}
 
-	conf.client = &http.Client{Timeout: du}
+	conf.client = &http.Client{
+		Timeout:   du,
+		Transport: tracing.NewTransport(nil),
+	}
 
 	resp := conf.GetHttpService().GetAuthorizationResponse()
 	if resp != nil {
@@ -76,3 +80,8 @@ func (conf *config) Init(cb api.ConfigCallbackHandler) error {
 	}
 	return nil
 }
+
+func (conf *config) RequestBodyLimit() (uint32, bool) {
+	svc := conf.GetHttpService()
+	return svc.GetMaxRequestBytes(), svc.GetAllowPartialMessage()
+}
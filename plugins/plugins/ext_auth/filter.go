@@ -16,11 +16,13 @@ package ext_auth
 
 import (
 	"bytes"
+	"context"
 	"io"
 	"net/http"
 	"net/url"
 
 	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/tracing"
 )
 
 func factory(c interface{}, callbacks api.FilterCallbackHandler) api.Filter {
@@ -46,7 +48,8 @@ func (f *filter) check(headers api.RequestHeaderMap, data api.BufferInstance) ap
 		return &api.LocalResponse{Code: 503}
 	}
 
-	req, err := http.NewRequest(headers.Method(), path, bytes.NewReader([]byte{}))
+	ctx := tracing.ContextWithRequest(context.Background(), f.callbacks, headers)
+	req, err := http.NewRequestWithContext(ctx, headers.Method(), path, bytes.NewReader([]byte{}))
 	if err != nil {
 		api.LogWarnf("failed to new request to ext authz server: %v", err)
 		return &api.LocalResponse{Code: 503}
@@ -21,9 +21,12 @@ import (
 
 	"github.com/agiledragon/gomonkey/v2"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/protobuf/encoding/protojson"
 
 	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/tracing"
 	"mosn.io/htnn/api/plugins/tests/pkg/envoy"
 )
 
@@ -248,3 +251,29 @@ func TestExtAuth(t *testing.T) {
 		})
 	}
 }
+
+func TestExtAuthTraceContext(t *testing.T) {
+	require.Nil(t, tracing.SetPropagators("tracecontext"))
+	defer tracing.SetPropagators("")
+
+	cb := envoy.NewFilterCallbackHandler()
+	conf := &config{}
+	protojson.Unmarshal([]byte(`{"httpService":{"url": "http://127.0.0.1:10001/ext_auth"}}`), conf)
+	conf.Init(nil)
+	patches := gomonkey.ApplyMethodFunc(conf.client, "Do", func(r *http.Request) (*http.Response, error) {
+		sc := trace.SpanContextFromContext(r.Context())
+		assert.Equal(t, "0af7651916cd43dd8448eb211c80319c", sc.TraceID().String())
+		assert.Equal(t, "b7ad6b7169203331", sc.SpanID().String())
+		return response(200), nil
+	})
+	defer patches.Reset()
+
+	f := factory(conf, cb)
+	hdr := envoy.NewRequestHeaderMap(http.Header{
+		":authority":  {"test.local"},
+		":method":     {"GET"},
+		":path":       {"/"},
+		"Traceparent": {"00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01"},
+	})
+	assert.Equal(t, api.Continue, f.DecodeHeaders(hdr, true))
+}
@@ -0,0 +1,112 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package header_rewrite
+
+import (
+	"text/template"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/plugins"
+	"mosn.io/htnn/types/pkg/expr"
+	"mosn.io/htnn/types/plugins/header_rewrite"
+)
+
+func init() {
+	plugins.RegisterHttpPlugin(header_rewrite.Name, &plugin{})
+}
+
+type plugin struct {
+	header_rewrite.Plugin
+}
+
+func (p *plugin) Factory() api.FilterFactory {
+	return factory
+}
+
+func (p *plugin) Config() api.PluginConfig {
+	return &config{}
+}
+
+type value struct {
+	key     string
+	literal string
+	tmpl    *template.Template
+}
+
+type operations struct {
+	remove expr.Matcher
+	rename []*header_rewrite.Rename
+	set    []*value
+	add    []*value
+}
+
+type config struct {
+	header_rewrite.CustomConfig
+
+	requestHeaders  *operations
+	queryParams     *operations
+	responseHeaders *operations
+}
+
+func buildOperations(ops *header_rewrite.Operations, isHeader bool) (*operations, error) {
+	if ops == nil {
+		return nil, nil
+	}
+
+	res := &operations{
+		rename: ops.Rename,
+	}
+	if len(ops.Remove) > 0 {
+		var err error
+		if isHeader {
+			res.remove, err = expr.BuildRepeatedStringMatcherIgnoreCase(ops.Remove)
+		} else {
+			res.remove, err = expr.BuildRepeatedStringMatcher(ops.Remove)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, hv := range ops.Set {
+		tmpl, err := header_rewrite.ParseTemplate(hv.Value)
+		if err != nil {
+			return nil, err
+		}
+		res.set = append(res.set, &value{key: hv.Key, literal: hv.Value, tmpl: tmpl})
+	}
+	for _, hv := range ops.Add {
+		tmpl, err := header_rewrite.ParseTemplate(hv.Value)
+		if err != nil {
+			return nil, err
+		}
+		res.add = append(res.add, &value{key: hv.Key, literal: hv.Value, tmpl: tmpl})
+	}
+	return res, nil
+}
+
+func (conf *config) Init(cb api.ConfigCallbackHandler) error {
+	var err error
+	conf.requestHeaders, err = buildOperations(conf.RequestHeaders, true)
+	if err != nil {
+		return err
+	}
+	conf.queryParams, err = buildOperations(conf.QueryParams, false)
+	if err != nil {
+		return err
+	}
+	conf.responseHeaders, err = buildOperations(conf.ResponseHeaders, true)
+	return err
+}
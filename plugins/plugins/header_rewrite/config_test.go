@@ -0,0 +1,85 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package header_rewrite
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestConfig(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{
+			name:  "empty",
+			input: `{}`,
+			err:   "at least one of requestHeaders, queryParams and responseHeaders is required",
+		},
+		{
+			name:  "invalid header name",
+			input: `{"requestHeaders":{"set":[{"key":"","value":"a"}]}}`,
+			err:   "invalid HeaderValue.Key",
+		},
+		{
+			name:  "pseudo header",
+			input: `{"requestHeaders":{"set":[{"key":":path","value":"/"}]}}`,
+			err:   "pseudo header :path can't be rewritten",
+		},
+		{
+			name:  "pseudo header in rename",
+			input: `{"responseHeaders":{"rename":[{"from":":status","to":"status"}]}}`,
+			err:   "pseudo header :status can't be rewritten",
+		},
+		{
+			name:  "invalid template",
+			input: `{"requestHeaders":{"add":[{"key":"x-user","value":"{{ .Header "}]}}`,
+			err:   "invalid template of x-user",
+		},
+		{
+			name:  "invalid regex",
+			input: `{"queryParams":{"remove":[{"regex":"("}]}}`,
+			err:   "error parsing regexp",
+		},
+		{
+			name: "pass",
+			input: `{
+				"requestHeaders":{"remove":[{"prefix":"x-internal-"}],"set":[{"key":"x-user","value":"{{ .Consumer }}"}]},
+				"queryParams":{"rename":[{"from":"a","to":"b"}],"add":[{"key":"route","value":"{{ .Route }}"}]},
+				"responseHeaders":{"add":[{"key":"x-req-id","value":"{{ .Header \"x-request-id\" }}"}]}
+			}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := &config{}
+			err := protojson.Unmarshal([]byte(tt.input), conf)
+			if err == nil {
+				err = conf.Validate()
+			}
+			if tt.err == "" {
+				assert.Nil(t, err)
+				assert.Nil(t, conf.Init(nil))
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,225 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package header_rewrite
+
+import (
+	"net/url"
+	"strings"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+)
+
+func factory(c interface{}, callbacks api.FilterCallbackHandler) api.Filter {
+	return &filter{
+		callbacks: callbacks,
+		config:    c.(*config),
+	}
+}
+
+type filter struct {
+	api.PassThroughFilter
+
+	callbacks  api.FilterCallbackHandler
+	config     *config
+	reqHeaders api.RequestHeaderMap
+	rspHeaders api.ResponseHeaderMap
+	query      url.Values
+}
+
+// The methods below can be used in the templates, like `{{ .Header "x-user" }}`
+
+func (f *filter) Header(name string) string {
+	if f.reqHeaders == nil {
+		return ""
+	}
+	v, _ := f.reqHeaders.Get(name)
+	return v
+}
+
+func (f *filter) ResponseHeader(name string) string {
+	if f.rspHeaders == nil {
+		return ""
+	}
+	v, _ := f.rspHeaders.Get(name)
+	return v
+}
+
+func (f *filter) Query(name string) string {
+	if f.reqHeaders == nil {
+		return ""
+	}
+	if f.query == nil {
+		f.query = f.reqHeaders.Url().Query()
+	}
+	return f.query.Get(name)
+}
+
+func (f *filter) Consumer() string {
+	consumer := f.callbacks.GetConsumer()
+	if consumer == nil {
+		return ""
+	}
+	return consumer.Name()
+}
+
+func (f *filter) Route() string {
+	return f.callbacks.StreamInfo().GetRouteName()
+}
+
+func (f *filter) Property(name string) string {
+	v, err := f.callbacks.GetProperty(name)
+	if err != nil {
+		api.LogInfof("headerRewrite: failed to get property %s: %v", name, err)
+		return ""
+	}
+	return v
+}
+
+type renderedValue struct {
+	key   string
+	value string
+}
+
+type renderedOperations struct {
+	*operations
+
+	set []renderedValue
+	add []renderedValue
+}
+
+func (f *filter) renderValues(values []*value) []renderedValue {
+	res := make([]renderedValue, 0, len(values))
+	for _, v := range values {
+		if v.tmpl == nil {
+			res = append(res, renderedValue{key: v.key, value: v.literal})
+			continue
+		}
+
+		var sb strings.Builder
+		err := v.tmpl.Execute(&sb, f)
+		if err != nil {
+			api.LogErrorf("headerRewrite: failed to render the value of %s: %v", v.key, err)
+			continue
+		}
+		res = append(res, renderedValue{key: v.key, value: sb.String()})
+	}
+	return res
+}
+
+func (f *filter) render(ops *operations) *renderedOperations {
+	if ops == nil {
+		return nil
+	}
+	return &renderedOperations{
+		operations: ops,
+		set:        f.renderValues(ops.set),
+		add:        f.renderValues(ops.add),
+	}
+}
+
+func applyToHeaders(ops *renderedOperations, headers api.HeaderMap) {
+	if ops.remove != nil {
+		var names []string
+		headers.Range(func(k, v string) bool {
+			if k[0] != ':' && ops.remove.Match(k) {
+				names = append(names, k)
+			}
+			return true
+		})
+		for _, name := range names {
+			headers.Del(name)
+		}
+	}
+
+	for _, r := range ops.rename {
+		values := headers.Values(r.From)
+		if len(values) == 0 {
+			continue
+		}
+		headers.Del(r.To)
+		for _, v := range values {
+			headers.Add(r.To, v)
+		}
+		headers.Del(r.From)
+	}
+
+	for _, v := range ops.set {
+		headers.Set(v.key, v.value)
+	}
+	for _, v := range ops.add {
+		headers.Add(v.key, v.value)
+	}
+}
+
+func applyToQuery(ops *renderedOperations, headers api.RequestHeaderMap) {
+	u := headers.Url()
+	query := u.Query()
+
+	if ops.remove != nil {
+		for k := range query {
+			if ops.remove.Match(k) {
+				delete(query, k)
+			}
+		}
+	}
+
+	for _, r := range ops.rename {
+		values, ok := query[r.From]
+		if !ok {
+			continue
+		}
+		delete(query, r.From)
+		query[r.To] = values
+	}
+
+	for _, v := range ops.set {
+		query.Set(v.key, v.value)
+	}
+	for _, v := range ops.add {
+		query.Add(v.key, v.value)
+	}
+
+	path := u.EscapedPath()
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+	headers.Set(":path", path)
+}
+
+func (f *filter) DecodeHeaders(headers api.RequestHeaderMap, endStream bool) api.ResultAction {
+	f.reqHeaders = headers
+
+	// render all the values before applying the operations, so the templates always see the
+	// original request
+	reqOps := f.render(f.config.requestHeaders)
+	queryOps := f.render(f.config.queryParams)
+	if reqOps != nil {
+		applyToHeaders(reqOps, headers)
+	}
+	if queryOps != nil {
+		applyToQuery(queryOps, headers)
+	}
+	return api.Continue
+}
+
+func (f *filter) EncodeHeaders(headers api.ResponseHeaderMap, endStream bool) api.ResultAction {
+	if f.config.responseHeaders == nil {
+		return api.Continue
+	}
+
+	f.rspHeaders = headers
+	applyToHeaders(f.render(f.config.responseHeaders), headers)
+	return api.Continue
+}
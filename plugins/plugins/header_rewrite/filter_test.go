@@ -0,0 +1,168 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package header_rewrite
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/plugins/tests/pkg/envoy"
+)
+
+type consumer struct {
+	name string
+}
+
+func (c *consumer) Name() string {
+	return c.name
+}
+
+func (c *consumer) PluginConfig(name string) api.PluginConsumerConfig {
+	return nil
+}
+
+type streamInfo struct {
+	envoy.StreamInfo
+}
+
+func (i *streamInfo) GetRouteName() string {
+	return "default/route"
+}
+
+type callbacks struct {
+	api.FilterCallbackHandler
+}
+
+func (cb *callbacks) GetProperty(key string) (string, error) {
+	if key == "xds.cluster_name" {
+		return "backend", nil
+	}
+	return "", errors.New("value not found")
+}
+
+func newFilter(t *testing.T, input string) api.Filter {
+	conf := &config{}
+	require.Nil(t, protojson.Unmarshal([]byte(input), conf))
+	require.Nil(t, conf.Validate())
+	require.Nil(t, conf.Init(nil))
+
+	cb := envoy.NewFilterCallbackHandler()
+	cb.SetStreamInfo(&streamInfo{})
+	cb.SetConsumer(&consumer{name: "marvin"})
+	return factory(conf, &callbacks{cb})
+}
+
+func TestRequestHeaders(t *testing.T) {
+	f := newFilter(t, `{"requestHeaders":{
+		"remove":[{"prefix":"x-internal-"},{"exact":"X-Debug"}],
+		"rename":[{"from":"x-token","to":"authorization"},{"from":"x-missing","to":"x-other"}],
+		"set":[
+			{"key":"x-consumer","value":"{{ .Consumer }}"},
+			{"key":"x-route","value":"{{ .Route }}"},
+			{"key":"x-cluster","value":"{{ .Property \"xds.cluster_name\" }}"},
+			{"key":"x-unknown","value":"[{{ .Property \"unknown\" }}]"},
+			{"key":"x-token-copy","value":"{{ .Header \"x-token\" }}"},
+			{"key":"x-page","value":"page-{{ .Query \"page\" }}"},
+			{"key":"x-static","value":"static"}
+		],
+		"add":[{"key":"x-tag","value":"b"}]
+	}}`)
+
+	hdr := envoy.NewRequestHeaderMap(http.Header{
+		":path":             []string{"/?page=2"},
+		"X-Internal-Secret": []string{"1"},
+		"X-Debug":           []string{"1"},
+		"X-Token":           []string{"Bearer t"},
+		"Authorization":     []string{"Basic b"},
+		"X-Tag":             []string{"a"},
+		"X-Static":          []string{"1", "2"},
+	})
+	assert.Equal(t, api.Continue, f.DecodeHeaders(hdr, true))
+
+	assert.Empty(t, hdr.Values("x-internal-secret"))
+	assert.Empty(t, hdr.Values("x-debug"))
+	assert.Empty(t, hdr.Values("x-token"))
+	assert.Empty(t, hdr.Values("x-other"))
+	assert.Equal(t, []string{"Bearer t"}, hdr.Values("authorization"))
+	assert.Equal(t, "marvin", hdr.GetRaw("x-consumer"))
+	assert.Equal(t, "default/route", hdr.GetRaw("x-route"))
+	assert.Equal(t, "backend", hdr.GetRaw("x-cluster"))
+	assert.Equal(t, "[]", hdr.GetRaw("x-unknown"))
+	// templates are rendered with the original request
+	assert.Equal(t, "Bearer t", hdr.GetRaw("x-token-copy"))
+	assert.Equal(t, "page-2", hdr.GetRaw("x-page"))
+	assert.Equal(t, []string{"static"}, hdr.Values("x-static"))
+	assert.Equal(t, []string{"a", "b"}, hdr.Values("x-tag"))
+	assert.Equal(t, "/?page=2", hdr.GetRaw(":path"))
+}
+
+func TestQueryParams(t *testing.T) {
+	f := newFilter(t, `{"queryParams":{
+		"remove":[{"prefix":"debug_"}],
+		"rename":[{"from":"q","to":"query"}],
+		"set":[{"key":"user","value":"{{ .Consumer }}"}],
+		"add":[{"key":"tag","value":"{{ .Header \"x-tag\" }}"}]
+	}}`)
+
+	hdr := envoy.NewRequestHeaderMap(http.Header{
+		":path": []string{"/search%20it?q=htnn&debug_level=1&tag=a&user=tom"},
+		"X-Tag": []string{"b c"},
+	})
+	f.DecodeHeaders(hdr, true)
+	assert.Equal(t, "/search%20it?query=htnn&tag=a&tag=b+c&user=marvin", hdr.GetRaw(":path"))
+
+	f = newFilter(t, `{"queryParams":{"remove":[{"regex":".*"}]}}`)
+	hdr = envoy.NewRequestHeaderMap(http.Header{":path": []string{"/path?a=1"}})
+	f.DecodeHeaders(hdr, true)
+	assert.Equal(t, "/path", hdr.GetRaw(":path"))
+}
+
+func TestResponseHeaders(t *testing.T) {
+	f := newFilter(t, `{"responseHeaders":{
+		"remove":[{"exact":"server"}],
+		"rename":[{"from":"x-upstream-id","to":"x-id"}],
+		"set":[{"key":"x-request-id","value":"{{ .Header \"x-request-id\" }}"}],
+		"add":[{"key":"x-status","value":"{{ .ResponseHeader \":status\" }}"}]
+	}}`)
+
+	reqHdr := envoy.NewRequestHeaderMap(http.Header{"X-Request-Id": []string{"abc"}})
+	f.DecodeHeaders(reqHdr, true)
+	assert.Equal(t, "abc", reqHdr.GetRaw("x-request-id"))
+
+	rspHdr := envoy.NewResponseHeaderMap(http.Header{
+		":status":       []string{"201"},
+		"Server":        []string{"envoy"},
+		"X-Upstream-Id": []string{"1"},
+	})
+	assert.Equal(t, api.Continue, f.EncodeHeaders(rspHdr, true))
+	assert.Empty(t, rspHdr.Values("server"))
+	assert.Empty(t, rspHdr.Values("x-upstream-id"))
+	assert.Equal(t, "1", rspHdr.GetRaw("x-id"))
+	assert.Equal(t, "abc", rspHdr.GetRaw("x-request-id"))
+	assert.Equal(t, "201", rspHdr.GetRaw("x-status"))
+	assert.Equal(t, "201", rspHdr.GetRaw(":status"))
+
+	// local reply before this plugin
+	f = newFilter(t, `{"responseHeaders":{"set":[{"key":"x-request-id","value":"[{{ .Header \"x-request-id\" }}]"}]}}`)
+	rspHdr = envoy.NewResponseHeaderMap(http.Header{})
+	f.EncodeHeaders(rspHdr, true)
+	assert.Equal(t, "[]", rspHdr.GetRaw("x-request-id"))
+}
@@ -0,0 +1,74 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ip_restriction
+
+import (
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/plugins"
+	"mosn.io/htnn/types/plugins/ip_restriction"
+)
+
+func init() {
+	plugins.RegisterHttpPlugin(ip_restriction.Name, &plugin{})
+}
+
+type plugin struct {
+	ip_restriction.Plugin
+}
+
+func (p *plugin) Factory() api.FilterFactory {
+	return factory
+}
+
+func (p *plugin) Config() api.PluginConfig {
+	return &config{}
+}
+
+type config struct {
+	ip_restriction.CustomConfig
+
+	allow          *prefixTree
+	deny           *prefixTree
+	trustedProxies *prefixTree
+	status         int
+}
+
+func newPrefixTree(prefixes []string) *prefixTree {
+	if len(prefixes) == 0 {
+		return nil
+	}
+
+	t := &prefixTree{}
+	for _, s := range prefixes {
+		// already validated
+		prefix, _ := ip_restriction.ParsePrefix(s)
+		t.Insert(prefix)
+	}
+	return t
+}
+
+func (conf *config) Init(cb api.ConfigCallbackHandler) error {
+	conf.allow = newPrefixTree(conf.Allow)
+	conf.deny = newPrefixTree(conf.Deny)
+	if conf.XForwardedFor != nil {
+		conf.trustedProxies = newPrefixTree(conf.XForwardedFor.TrustedProxies)
+	}
+
+	conf.status = 403
+	if conf.Status >= 400 {
+		conf.status = int(conf.Status)
+	}
+	return nil
+}
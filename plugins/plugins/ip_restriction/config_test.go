@@ -0,0 +1,83 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ip_restriction
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestConfig(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{
+			name:  "allow or deny required",
+			input: `{}`,
+			err:   "either allow or deny is required",
+		},
+		{
+			name:  "invalid cidr",
+			input: `{"allow":["10.0.0.0/33"]}`,
+			err:   `invalid allow "10.0.0.0/33"`,
+		},
+		{
+			name:  "invalid ip",
+			input: `{"deny":["10.0.0"]}`,
+			err:   `invalid deny "10.0.0"`,
+		},
+		{
+			name:  "xff without trusted proxies",
+			input: `{"allow":["10.0.0.0/8"],"xForwardedFor":{}}`,
+			err:   "exactly one of trustedProxyCount and trustedProxies is required",
+		},
+		{
+			name:  "xff with both",
+			input: `{"allow":["10.0.0.0/8"],"xForwardedFor":{"trustedProxyCount":1,"trustedProxies":["1.1.1.1"]}}`,
+			err:   "exactly one of trustedProxyCount and trustedProxies is required",
+		},
+		{
+			name:  "invalid trusted proxy",
+			input: `{"allow":["10.0.0.0/8"],"xForwardedFor":{"trustedProxies":["x"]}}`,
+			err:   `invalid trustedProxies "x"`,
+		},
+		{
+			name:  "pass",
+			input: `{"allow":["10.0.0.0/8","::1"],"deny":["10.0.0.1"],"xForwardedFor":{"trustedProxies":["192.168.0.0/16"]},"status":401}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := &config{}
+			err := protojson.Unmarshal([]byte(tt.input), conf)
+			if err == nil {
+				err = conf.Validate()
+			}
+			if tt.err == "" {
+				assert.Nil(t, err)
+				err = conf.Init(nil)
+				assert.Nil(t, err)
+				assert.Equal(t, 401, conf.status)
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
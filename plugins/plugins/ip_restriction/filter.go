@@ -0,0 +1,110 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ip_restriction
+
+import (
+	"net/netip"
+	"strings"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+)
+
+func factory(c interface{}, callbacks api.FilterCallbackHandler) api.Filter {
+	return &filter{
+		callbacks: callbacks,
+		config:    c.(*config),
+	}
+}
+
+type filter struct {
+	api.PassThroughFilter
+
+	callbacks api.FilterCallbackHandler
+	config    *config
+}
+
+func (f *filter) reject() api.ResultAction {
+	return &api.LocalResponse{Code: f.config.status, Msg: f.config.Message}
+}
+
+func parseForwardedFor(headers api.RequestHeaderMap) []string {
+	var addrs []string
+	for _, v := range headers.Values("x-forwarded-for") {
+		for _, s := range strings.Split(v, ",") {
+			s = strings.TrimSpace(s)
+			if s != "" {
+				addrs = append(addrs, s)
+			}
+		}
+	}
+	return addrs
+}
+
+func (f *filter) clientIP(headers api.RequestHeaderMap, remote netip.Addr) (netip.Addr, error) {
+	xff := f.config.XForwardedFor
+	if xff == nil {
+		return remote, nil
+	}
+
+	addrs := parseForwardedFor(headers)
+	if xff.TrustedProxyCount > 0 {
+		n := int(xff.TrustedProxyCount)
+		if n > len(addrs) {
+			// follow Envoy's behavior: fall back to the downstream remote address
+			return remote, nil
+		}
+		return netip.ParseAddr(addrs[len(addrs)-n])
+	}
+
+	ip := remote
+	for i := len(addrs) - 1; i >= 0 && f.config.trustedProxies.Contains(ip); i-- {
+		var err error
+		ip, err = netip.ParseAddr(addrs[i])
+		if err != nil {
+			return ip, err
+		}
+	}
+	return ip, nil
+}
+
+func (f *filter) DecodeHeaders(headers api.RequestHeaderMap, endStream bool) api.ResultAction {
+	remote := f.callbacks.StreamInfo().DownstreamRemoteParsedAddress()
+	if remote == nil {
+		api.LogInfo("ipRestriction: downstream remote address not found")
+		return f.reject()
+	}
+	remoteIP, err := netip.ParseAddr(remote.IP)
+	if err != nil {
+		api.LogInfof("ipRestriction: invalid downstream remote address %s: %v", remote.IP, err)
+		return f.reject()
+	}
+
+	ip, err := f.clientIP(headers, remoteIP)
+	if err != nil {
+		api.LogInfof("ipRestriction: invalid client IP: %v", err)
+		return f.reject()
+	}
+
+	if f.config.deny != nil && f.config.deny.Contains(ip) {
+		api.LogInfof("ipRestriction: IP %s is denied", ip)
+		return f.reject()
+	}
+	if f.config.allow != nil && !f.config.allow.Contains(ip) {
+		api.LogInfof("ipRestriction: IP %s is not allowed", ip)
+		return f.reject()
+	}
+
+	return api.Continue
+}
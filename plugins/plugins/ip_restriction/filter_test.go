@@ -0,0 +1,162 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ip_restriction
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/plugins/tests/pkg/envoy"
+)
+
+type streamInfo struct {
+	envoy.StreamInfo
+
+	ip string
+}
+
+func (i *streamInfo) DownstreamRemoteParsedAddress() *api.IPAddress {
+	return &api.IPAddress{
+		Address: i.ip + ":12345",
+		IP:      i.ip,
+		Port:    12345,
+	}
+}
+
+func TestDecodeHeaders(t *testing.T) {
+	tests := []struct {
+		name   string
+		config string
+		remote string
+		xff    []string
+		status int
+	}{
+		{
+			name:   "allowed",
+			config: `{"allow":["10.0.0.0/8"]}`,
+			remote: "10.1.1.1",
+		},
+		{
+			name:   "not allowed",
+			config: `{"allow":["10.0.0.0/8"]}`,
+			remote: "11.1.1.1",
+			status: 403,
+		},
+		{
+			name:   "denied",
+			config: `{"allow":["10.0.0.0/8"],"deny":["10.1.0.0/16"]}`,
+			remote: "10.1.1.1",
+			status: 403,
+		},
+		{
+			name:   "deny only",
+			config: `{"deny":["10.1.0.0/16"],"status":401}`,
+			remote: "10.2.1.1",
+		},
+		{
+			name:   "custom status",
+			config: `{"deny":["10.1.0.0/16"],"status":401}`,
+			remote: "10.1.1.1",
+			status: 401,
+		},
+		{
+			name:   "xff ignored by default",
+			config: `{"allow":["10.0.0.0/8"]}`,
+			remote: "10.1.1.1",
+			xff:    []string{"1.1.1.1"},
+		},
+		{
+			name:   "trusted proxy count",
+			config: `{"allow":["10.0.0.0/8"],"xForwardedFor":{"trustedProxyCount":2}}`,
+			remote: "1.1.1.1",
+			xff:    []string{"1.2.3.4, 10.0.0.1", "2.2.2.2"},
+		},
+		{
+			name:   "trusted proxy count, spoofed",
+			config: `{"allow":["10.0.0.0/8"],"xForwardedFor":{"trustedProxyCount":1}}`,
+			remote: "1.1.1.1",
+			xff:    []string{"10.0.0.1, 2.2.2.2"},
+			status: 403,
+		},
+		{
+			name:   "trusted proxy count, fall back to remote address",
+			config: `{"allow":["10.0.0.0/8"],"xForwardedFor":{"trustedProxyCount":3}}`,
+			remote: "10.1.1.1",
+			xff:    []string{"1.1.1.1, 2.2.2.2"},
+		},
+		{
+			name:   "trusted proxy count, invalid ip",
+			config: `{"deny":["10.0.0.0/8"],"xForwardedFor":{"trustedProxyCount":1}}`,
+			remote: "1.1.1.1",
+			xff:    []string{"unknown"},
+			status: 403,
+		},
+		{
+			name:   "trusted proxies",
+			config: `{"deny":["1.2.3.4"],"xForwardedFor":{"trustedProxies":["192.168.0.0/16","172.16.0.1"]}}`,
+			remote: "192.168.1.1",
+			xff:    []string{"1.2.3.4, 172.16.0.1"},
+			status: 403,
+		},
+		{
+			name:   "trusted proxies, untrusted remote address",
+			config: `{"deny":["1.2.3.4"],"xForwardedFor":{"trustedProxies":["192.168.0.0/16"]}}`,
+			remote: "8.8.8.8",
+			xff:    []string{"1.2.3.4"},
+		},
+		{
+			name:   "trusted proxies, all trusted",
+			config: `{"allow":["192.168.0.0/16"],"xForwardedFor":{"trustedProxies":["192.168.0.0/16"]}}`,
+			remote: "192.168.1.1",
+			xff:    []string{"192.168.1.2"},
+		},
+		{
+			name:   "ipv6",
+			config: `{"allow":["2001:db8::/32"]}`,
+			remote: "2001:db8::1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := &config{}
+			err := protojson.Unmarshal([]byte(tt.config), conf)
+			assert.Nil(t, err)
+			assert.Nil(t, conf.Validate())
+			assert.Nil(t, conf.Init(nil))
+
+			cb := envoy.NewFilterCallbackHandler()
+			cb.SetStreamInfo(&streamInfo{ip: tt.remote})
+			f := factory(conf, cb)
+			hdr := http.Header{}
+			for _, v := range tt.xff {
+				hdr.Add("X-Forwarded-For", v)
+			}
+			res := f.DecodeHeaders(envoy.NewRequestHeaderMap(hdr), true)
+			if tt.status == 0 {
+				assert.Equal(t, api.Continue, res)
+			} else {
+				resp, ok := res.(*api.LocalResponse)
+				if assert.True(t, ok) {
+					assert.Equal(t, tt.status, resp.Code)
+				}
+			}
+		})
+	}
+}
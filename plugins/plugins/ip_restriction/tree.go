@@ -0,0 +1,79 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ip_restriction
+
+import (
+	"net/netip"
+)
+
+type node struct {
+	children [2]*node
+	terminal bool
+}
+
+// prefixTree is a binary radix tree which reports whether an address is covered by one of
+// the inserted prefixes. The lookup cost depends on the address length instead of the number
+// of prefixes.
+type prefixTree struct {
+	v4 node
+	v6 node
+}
+
+func bitAt(b []byte, i int) int {
+	return int(b[i/8]>>(7-i%8)) & 1
+}
+
+func (t *prefixTree) root(addr netip.Addr) *node {
+	if addr.Is4() {
+		return &t.v4
+	}
+	return &t.v6
+}
+
+func (t *prefixTree) Insert(prefix netip.Prefix) {
+	addr := prefix.Addr()
+	n := t.root(addr)
+	b := addr.AsSlice()
+	for i := 0; i < prefix.Bits(); i++ {
+		if n.terminal {
+			// covered by a shorter prefix
+			return
+		}
+		bit := bitAt(b, i)
+		if n.children[bit] == nil {
+			n.children[bit] = &node{}
+		}
+		n = n.children[bit]
+	}
+	n.terminal = true
+	// the longer prefixes under this node are redundant
+	n.children = [2]*node{}
+}
+
+func (t *prefixTree) Contains(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	n := t.root(addr)
+	b := addr.AsSlice()
+	for i := 0; n != nil; i++ {
+		if n.terminal {
+			return true
+		}
+		if i == len(b)*8 {
+			break
+		}
+		n = n.children[bitAt(b, i)]
+	}
+	return false
+}
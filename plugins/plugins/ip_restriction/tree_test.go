@@ -0,0 +1,74 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ip_restriction
+
+import (
+	"fmt"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"mosn.io/htnn/types/plugins/ip_restriction"
+)
+
+func TestPrefixTree(t *testing.T) {
+	tree := newPrefixTree([]string{
+		"10.0.0.0/8",
+		"10.1.0.0/16", // covered by the previous one
+		"192.168.1.1",
+		"172.16.0.0/12",
+		"2001:db8::/32",
+		"0.0.0.0/32",
+	})
+
+	tests := []struct {
+		addr     string
+		contains bool
+	}{
+		{"10.255.0.1", true},
+		{"11.0.0.1", false},
+		{"192.168.1.1", true},
+		{"192.168.1.2", false},
+		{"172.31.255.255", true},
+		{"172.32.0.0", false},
+		{"::ffff:10.0.0.1", true},
+		{"2001:db8:1::1", true},
+		{"2001:db9::1", false},
+		{"0.0.0.0", true},
+		{"0.0.0.1", false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.contains, tree.Contains(netip.MustParseAddr(tt.addr)), tt.addr)
+	}
+
+	tree = newPrefixTree([]string{"::/0"})
+	assert.True(t, tree.Contains(netip.MustParseAddr("2001:db8::1")))
+	assert.False(t, tree.Contains(netip.MustParseAddr("1.1.1.1")))
+}
+
+func BenchmarkPrefixTree(b *testing.B) {
+	tree := &prefixTree{}
+	for i := 0; i < 10000; i++ {
+		prefix, _ := ip_restriction.ParsePrefix(fmt.Sprintf("%d.%d.%d.0/24", 1+i/65536, i/256%256, i%256))
+		tree.Insert(prefix)
+	}
+	addr := netip.MustParseAddr("8.8.8.8")
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		tree.Contains(addr)
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package json_transform
+
+import (
+	"text/template"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/plugins"
+	"mosn.io/htnn/types/plugins/json_transform"
+)
+
+func init() {
+	plugins.RegisterHttpPlugin(json_transform.Name, &plugin{})
+}
+
+type plugin struct {
+	json_transform.Plugin
+}
+
+func (p *plugin) Factory() api.FilterFactory {
+	return factory
+}
+
+func (p *plugin) Config() api.PluginConfig {
+	return &config{}
+}
+
+type rename struct {
+	from []json_transform.Segment
+	to   []json_transform.Segment
+}
+
+type set struct {
+	path  []json_transform.Segment
+	value interface{}
+}
+
+type transformation struct {
+	remove   [][]json_transform.Segment
+	rename   []rename
+	set      []set
+	template *template.Template
+}
+
+type config struct {
+	json_transform.CustomConfig
+
+	request  *transformation
+	response *transformation
+}
+
+func buildTransformation(t *json_transform.Transformation) (*transformation, error) {
+	if t == nil {
+		return nil, nil
+	}
+
+	res := &transformation{}
+	for _, p := range t.Remove {
+		segs, err := json_transform.ParsePath(p)
+		if err != nil {
+			return nil, err
+		}
+		res.remove = append(res.remove, segs)
+	}
+	for _, r := range t.Rename {
+		from, err := json_transform.ParsePath(r.From)
+		if err != nil {
+			return nil, err
+		}
+		to, err := json_transform.ParsePath(r.To)
+		if err != nil {
+			return nil, err
+		}
+		res.rename = append(res.rename, rename{from: from, to: to})
+	}
+	for _, s := range t.Set {
+		segs, err := json_transform.ParsePath(s.Path)
+		if err != nil {
+			return nil, err
+		}
+		res.set = append(res.set, set{path: segs, value: s.Value.AsInterface()})
+	}
+	if t.Template != "" {
+		tmpl, err := template.New("").Funcs(json_transform.TemplateFuncs).Parse(t.Template)
+		if err != nil {
+			return nil, err
+		}
+		res.template = tmpl
+	}
+	return res, nil
+}
+
+func (conf *config) Init(cb api.ConfigCallbackHandler) error {
+	var err error
+	conf.request, err = buildTransformation(conf.Request)
+	if err != nil {
+		return err
+	}
+	conf.response, err = buildTransformation(conf.Response)
+	return err
+}
@@ -0,0 +1,142 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package json_transform
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"mosn.io/htnn/types/plugins/json_transform"
+)
+
+func TestConfig(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{
+			name:  "empty",
+			input: `{}`,
+			err:   "at least one of request and response is required",
+		},
+		{
+			name:  "invalid path",
+			input: `{"request":{"remove":["$.a["]}}`,
+			err:   "unclosed bracket",
+		},
+		{
+			name:  "remove root",
+			input: `{"request":{"remove":["$"]}}`,
+			err:   "can't remove the root",
+		},
+		{
+			name:  "wildcard in rename",
+			input: `{"response":{"rename":[{"from":"$.a[*]","to":"$.b"}]}}`,
+			err:   "wildcard is not allowed in rename",
+		},
+		{
+			name:  "missing value",
+			input: `{"response":{"set":[{"path":"$.a"}]}}`,
+			err:   "invalid Set.Value",
+		},
+		{
+			name:  "invalid template",
+			input: `{"response":{"template":"{{ json .Body "}}`,
+			err:   "invalid template",
+		},
+		{
+			name: "pass",
+			input: `{
+				"request":{"remove":["$.internal"],"set":[{"path":"$.meta.source","value":"gateway"}]},
+				"response":{"rename":[{"from":"$.data.id","to":"$.data.uid"}],"template":"{\"code\":0,\"data\":{{ json .Body }}}"}
+			}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := &config{}
+			err := protojson.Unmarshal([]byte(tt.input), conf)
+			if err == nil {
+				err = conf.Validate()
+			}
+			if tt.err == "" {
+				assert.Nil(t, err)
+				assert.Nil(t, conf.Init(nil))
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestParsePath(t *testing.T) {
+	tests := []struct {
+		path string
+		segs []json_transform.Segment
+		err  string
+	}{
+		{
+			path: "$",
+		},
+		{
+			path: "$.a.b",
+			segs: []json_transform.Segment{{Key: "a", Index: -1}, {Key: "b", Index: -1}},
+		},
+		{
+			path: "a.b",
+			segs: []json_transform.Segment{{Key: "a", Index: -1}, {Key: "b", Index: -1}},
+		},
+		{
+			path: "$.items[0].id",
+			segs: []json_transform.Segment{{Key: "items", Index: -1}, {Index: 0}, {Key: "id", Index: -1}},
+		},
+		{
+			path: "$.items[*].*",
+			segs: []json_transform.Segment{{Key: "items", Index: -1}, {Index: -1, Wildcard: true}, {Index: -1, Wildcard: true}},
+		},
+		{
+			path: "$['a.b']",
+			segs: []json_transform.Segment{{Key: "a.b", Index: -1}},
+		},
+		{
+			path: "$a",
+			err:  "invalid path",
+		},
+		{
+			path: "$.a..b",
+			err:  "empty field name",
+		},
+		{
+			path: "$.a[-1]",
+			err:  "bad index",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			segs, err := json_transform.ParsePath(tt.path)
+			if tt.err == "" {
+				assert.Nil(t, err)
+				assert.Equal(t, tt.segs, segs)
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,182 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package json_transform
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime"
+	"strconv"
+	"strings"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+)
+
+func factory(c interface{}, callbacks api.FilterCallbackHandler) api.Filter {
+	return &filter{
+		callbacks: callbacks,
+		config:    c.(*config),
+	}
+}
+
+type filter struct {
+	api.PassThroughFilter
+
+	callbacks api.FilterCallbackHandler
+	config    *config
+
+	reqHeaders        api.RequestHeaderMap
+	transformRequest  bool
+	transformResponse bool
+}
+
+// templateData is the data passed to the template. The body can be accessed via `{{ .Body }}`,
+// and the request headers via `{{ .Header "x-user" }}`.
+type templateData struct {
+	f *filter
+
+	Body interface{}
+}
+
+func (d *templateData) Header(name string) string {
+	if d.f.reqHeaders == nil {
+		return ""
+	}
+	v, _ := d.f.reqHeaders.Get(name)
+	return v
+}
+
+func (d *templateData) Consumer() string {
+	consumer := d.f.callbacks.GetConsumer()
+	if consumer == nil {
+		return ""
+	}
+	return consumer.Name()
+}
+
+func (d *templateData) Route() string {
+	return d.f.callbacks.StreamInfo().GetRouteName()
+}
+
+func isJSON(headers api.HeaderMap) bool {
+	ct, ok := headers.Get("content-type")
+	if !ok {
+		return false
+	}
+	mediaType, _, err := mime.ParseMediaType(ct)
+	if err != nil {
+		return false
+	}
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+func shouldTransform(headers api.HeaderMap, endStream bool) bool {
+	if endStream || !isJSON(headers) {
+		return false
+	}
+	if enc, ok := headers.Get("content-encoding"); ok && enc != "identity" {
+		api.LogDebugf("jsonTransform: skip transforming the body encoded with %s", enc)
+		return false
+	}
+	return true
+}
+
+func (f *filter) transform(t *transformation, headers api.HeaderMap, buf api.BufferInstance) {
+	if buf == nil || buf.Len() == 0 {
+		return
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(buf.Bytes()))
+	// keep the precision of numbers
+	dec.UseNumber()
+	var body interface{}
+	if err := dec.Decode(&body); err != nil {
+		api.LogInfof("jsonTransform: skip transforming invalid JSON body: %v", err)
+		return
+	}
+
+	for _, segs := range t.remove {
+		body = removeValue(body, segs)
+	}
+	for _, r := range t.rename {
+		v, ok := getValue(body, r.from)
+		if !ok {
+			continue
+		}
+		body = removeValue(body, r.from)
+		body = setValue(body, r.to, v)
+	}
+	for _, s := range t.set {
+		body = setValue(body, s.path, s.value)
+	}
+
+	var data []byte
+	if t.template != nil {
+		var out bytes.Buffer
+		err := t.template.Execute(&out, &templateData{f: f, Body: body})
+		if err != nil {
+			api.LogErrorf("jsonTransform: failed to render template: %v", err)
+			return
+		}
+		data = out.Bytes()
+		if !json.Valid(data) {
+			api.LogErrorf("jsonTransform: template output is not valid JSON: %s", data)
+			return
+		}
+	} else {
+		var err error
+		data, err = json.Marshal(body)
+		if err != nil {
+			api.LogErrorf("jsonTransform: failed to marshal body: %v", err)
+			return
+		}
+	}
+
+	_ = buf.Set(data)
+	if _, ok := headers.Get("content-length"); ok {
+		headers.Set("content-length", strconv.Itoa(len(data)))
+	}
+}
+
+func (f *filter) DecodeHeaders(headers api.RequestHeaderMap, endStream bool) api.ResultAction {
+	f.reqHeaders = headers
+	if f.config.request == nil || !shouldTransform(headers, endStream) {
+		return api.Continue
+	}
+	f.transformRequest = true
+	return api.WaitAllData
+}
+
+func (f *filter) DecodeRequest(headers api.RequestHeaderMap, buf api.BufferInstance, trailers api.RequestTrailerMap) api.ResultAction {
+	if f.transformRequest {
+		f.transform(f.config.request, headers, buf)
+	}
+	return api.Continue
+}
+
+func (f *filter) EncodeHeaders(headers api.ResponseHeaderMap, endStream bool) api.ResultAction {
+	if f.config.response == nil || !shouldTransform(headers, endStream) {
+		return api.Continue
+	}
+	f.transformResponse = true
+	return api.WaitAllData
+}
+
+func (f *filter) EncodeResponse(headers api.ResponseHeaderMap, buf api.BufferInstance, trailers api.ResponseTrailerMap) api.ResultAction {
+	if f.transformResponse {
+		f.transform(f.config.response, headers, buf)
+	}
+	return api.Continue
+}
@@ -0,0 +1,183 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package json_transform
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/plugins/tests/pkg/envoy"
+)
+
+type consumer struct {
+	name string
+}
+
+func (c *consumer) Name() string {
+	return c.name
+}
+
+func (c *consumer) PluginConfig(name string) api.PluginConsumerConfig {
+	return nil
+}
+
+type streamInfo struct {
+	envoy.StreamInfo
+}
+
+func (i *streamInfo) GetRouteName() string {
+	return "default/route"
+}
+
+func newFilter(t *testing.T, input string) api.Filter {
+	conf := &config{}
+	require.Nil(t, protojson.Unmarshal([]byte(input), conf))
+	require.Nil(t, conf.Validate())
+	require.Nil(t, conf.Init(nil))
+
+	cb := envoy.NewFilterCallbackHandler()
+	cb.SetStreamInfo(&streamInfo{})
+	cb.SetConsumer(&consumer{name: "marvin"})
+	return factory(conf, cb)
+}
+
+func TestTransformRequest(t *testing.T) {
+	tests := []struct {
+		name   string
+		config string
+		input  string
+		output string
+	}{
+		{
+			name:   "remove",
+			config: `{"request":{"remove":["$.password","$.items[*].cost","$.tags[1]","$.missing.field"]}}`,
+			input:  `{"user":"a","password":"x","items":[{"id":1,"cost":2},{"id":2,"cost":3}],"tags":["a","b","c"]}`,
+			output: `{"items":[{"id":1},{"id":2}],"tags":["a","c"],"user":"a"}`,
+		},
+		{
+			name:   "rename",
+			config: `{"request":{"rename":[{"from":"$.user.name","to":"$.username"},{"from":"$.missing","to":"$.other"}]}}`,
+			input:  `{"user":{"name":"a","age":1}}`,
+			output: `{"user":{"age":1},"username":"a"}`,
+		},
+		{
+			name: "set",
+			config: `{"request":{"set":[
+				{"path":"$.meta.source","value":"gateway"},
+				{"path":"$.items[*].currency","value":"USD"},
+				{"path":"$.count","value":2}
+			]}}`,
+			input:  `{"items":[{"price":12345678901234567890},{}]}`,
+			output: `{"count":2,"items":[{"currency":"USD","price":12345678901234567890},{"currency":"USD"}],"meta":{"source":"gateway"}}`,
+		},
+		{
+			name:   "template",
+			config: `{"request":{"remove":["$.secret"],"template":"{\"user\":\"{{ .Consumer }}\",\"route\":\"{{ .Route }}\",\"trace\":\"{{ .Header \"x-trace\" }}\",\"data\":{{ json .Body }}}"}}`,
+			input:  `{"secret":1,"a":[1]}`,
+			output: `{"user":"marvin","route":"default/route","trace":"t1","data":{"a":[1]}}`,
+		},
+		{
+			name:   "template renders invalid JSON",
+			config: `{"request":{"template":"{{ .Body }}"}}`,
+			input:  `{"a":1}`,
+			output: `{"a":1}`,
+		},
+		{
+			name:   "invalid JSON",
+			config: `{"request":{"remove":["$.a"]}}`,
+			input:  `{"a":1`,
+			output: `{"a":1`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFilter(t, tt.config)
+			hdr := envoy.NewRequestHeaderMap(http.Header{
+				"Content-Type":   []string{"application/json; charset=utf-8"},
+				"Content-Length": []string{"100"},
+				"X-Trace":        []string{"t1"},
+			})
+			assert.Equal(t, api.WaitAllData, f.DecodeHeaders(hdr, false))
+			buf := envoy.NewBufferInstance([]byte(tt.input))
+			assert.Equal(t, api.Continue, f.DecodeRequest(hdr, buf, nil))
+			assert.Equal(t, tt.output, buf.String())
+			cl, _ := hdr.Get("content-length")
+			if tt.output != tt.input {
+				assert.Equal(t, strconv.Itoa(len(tt.output)), cl)
+			} else {
+				assert.Equal(t, "100", cl)
+			}
+		})
+	}
+}
+
+func TestSkipTransform(t *testing.T) {
+	tests := []struct {
+		name      string
+		header    http.Header
+		endStream bool
+	}{
+		{
+			name:   "not JSON",
+			header: http.Header{"Content-Type": []string{"text/plain"}},
+		},
+		{
+			name:   "no content type",
+			header: http.Header{},
+		},
+		{
+			name:   "compressed",
+			header: http.Header{"Content-Type": []string{"application/json"}, "Content-Encoding": []string{"gzip"}},
+		},
+		{
+			name:      "no body",
+			header:    http.Header{"Content-Type": []string{"application/json"}},
+			endStream: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFilter(t, `{"request":{"remove":["$.a"]},"response":{"remove":["$.a"]}}`)
+			assert.Equal(t, api.Continue, f.DecodeHeaders(envoy.NewRequestHeaderMap(tt.header), tt.endStream))
+			assert.Equal(t, api.Continue, f.EncodeHeaders(envoy.NewResponseHeaderMap(tt.header), tt.endStream))
+		})
+	}
+}
+
+func TestTransformResponse(t *testing.T) {
+	f := newFilter(t, `{"response":{"rename":[{"from":"$.id","to":"$.uid"}],"template":"{\"code\":0,\"data\":{{ json .Body }}}"}}`)
+	reqHdr := envoy.NewRequestHeaderMap(http.Header{"Content-Type": []string{"application/json"}})
+	// request is not transformed
+	assert.Equal(t, api.Continue, f.DecodeHeaders(reqHdr, false))
+
+	hdr := envoy.NewResponseHeaderMap(http.Header{
+		"Content-Type":   []string{"application/problem+json"},
+		"Content-Length": []string{"8"},
+	})
+	assert.Equal(t, api.WaitAllData, f.EncodeHeaders(hdr, false))
+	buf := envoy.NewBufferInstance([]byte(`{"id":1}`))
+	assert.Equal(t, api.Continue, f.EncodeResponse(hdr, buf, nil))
+	assert.Equal(t, `{"code":0,"data":{"uid":1}}`, buf.String())
+	cl, _ := hdr.Get("content-length")
+	assert.Equal(t, "27", cl)
+}
@@ -0,0 +1,120 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package json_transform
+
+import (
+	"mosn.io/htnn/types/plugins/json_transform"
+)
+
+// The functions below modify the decoded JSON in place when possible, and return the new value
+// in case the container itself is replaced.
+
+func getValue(node interface{}, segs []json_transform.Segment) (interface{}, bool) {
+	for _, seg := range segs {
+		switch n := node.(type) {
+		case map[string]interface{}:
+			if seg.Index >= 0 {
+				return nil, false
+			}
+			v, ok := n[seg.Key]
+			if !ok {
+				return nil, false
+			}
+			node = v
+		case []interface{}:
+			if seg.Index < 0 || seg.Index >= len(n) {
+				return nil, false
+			}
+			node = n[seg.Index]
+		default:
+			return nil, false
+		}
+	}
+	return node, true
+}
+
+func removeValue(node interface{}, segs []json_transform.Segment) interface{} {
+	if len(segs) == 0 {
+		return node
+	}
+
+	seg := segs[0]
+	last := len(segs) == 1
+	switch n := node.(type) {
+	case map[string]interface{}:
+		if seg.Wildcard {
+			for k, v := range n {
+				if last {
+					delete(n, k)
+				} else {
+					n[k] = removeValue(v, segs[1:])
+				}
+			}
+		} else if seg.Index < 0 {
+			if last {
+				delete(n, seg.Key)
+			} else if v, ok := n[seg.Key]; ok {
+				n[seg.Key] = removeValue(v, segs[1:])
+			}
+		}
+	case []interface{}:
+		if seg.Wildcard {
+			if last {
+				return []interface{}{}
+			}
+			for i, v := range n {
+				n[i] = removeValue(v, segs[1:])
+			}
+		} else if seg.Index >= 0 && seg.Index < len(n) {
+			if last {
+				return append(n[:seg.Index:seg.Index], n[seg.Index+1:]...)
+			}
+			n[seg.Index] = removeValue(n[seg.Index], segs[1:])
+		}
+	}
+	return node
+}
+
+func setValue(node interface{}, segs []json_transform.Segment, value interface{}) interface{} {
+	if len(segs) == 0 {
+		return value
+	}
+
+	seg := segs[0]
+	switch n := node.(type) {
+	case map[string]interface{}:
+		if seg.Wildcard {
+			for k, v := range n {
+				n[k] = setValue(v, segs[1:], value)
+			}
+		} else if seg.Index < 0 {
+			n[seg.Key] = setValue(n[seg.Key], segs[1:], value)
+		}
+	case []interface{}:
+		if seg.Wildcard {
+			for i, v := range n {
+				n[i] = setValue(v, segs[1:], value)
+			}
+		} else if seg.Index >= 0 && seg.Index < len(n) {
+			n[seg.Index] = setValue(n[seg.Index], segs[1:], value)
+		}
+	case nil:
+		// create the missing object
+		if seg.Index < 0 && !seg.Wildcard {
+			return map[string]interface{}{seg.Key: setValue(nil, segs[1:], value)}
+		}
+	}
+	return node
+}
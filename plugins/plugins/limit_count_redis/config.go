@@ -25,6 +25,7 @@ import (
 
 	"mosn.io/htnn/api/pkg/filtermanager/api"
 	"mosn.io/htnn/api/pkg/plugins"
+	"mosn.io/htnn/plugins/pkg/redisx"
 	"mosn.io/htnn/types/pkg/expr"
 	"mosn.io/htnn/types/plugins/limit_count_redis"
 )
@@ -87,6 +88,7 @@ func (conf *config) Init(cb api.ConfigCallbackHandler) error {
 		}
 
 		conf.client = redis.NewClient(opt)
+		conf.client.AddHook(redisx.TracingHook{})
 
 	} else {
 		cluster := conf.GetCluster()
@@ -102,6 +104,7 @@ func (conf *config) Init(cb api.ConfigCallbackHandler) error {
 		}
 
 		conf.clusterClient = redis.NewClusterClient(opt)
+		conf.clusterClient.AddHook(redisx.TracingHook{})
 	}
 
 	prefix := uuid.NewString()[:8] // enough for millions configurations
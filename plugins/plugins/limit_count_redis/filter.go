@@ -24,8 +24,10 @@ import (
 	"github.com/redis/go-redis/v9"
 
 	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/tracing"
 	"mosn.io/htnn/plugins/pkg/stringx"
 	"mosn.io/htnn/types/pkg/expr"
+	"mosn.io/htnn/types/plugins/limit_count_redis"
 )
 
 func factory(c interface{}, callbacks api.FilterCallbackHandler) api.Filter {
@@ -107,15 +109,41 @@ func (f *filter) limitCountErr(err error) api.ResultAction {
 	return api.Continue
 }
 
+// publish exposes the decision via the dynamic metadata, so it can be referred in the access log
+func (f *filter) publish(keys []string) {
+	var minRemain int64 = math.MaxInt64
+	for i := range keys {
+		remain := f.ress[2*i].(int64)
+		if remain < minRemain {
+			minRemain = remain
+		}
+	}
+	if minRemain < 0 {
+		minRemain = 0
+	}
+
+	// The value is converted to protobuf Struct, which only accepts []interface{} as list
+	ks := make([]interface{}, len(keys))
+	for i, k := range keys {
+		ks[i] = k
+	}
+	f.callbacks.StreamInfo().DynamicMetadata().Set(api.DynamicMetadataNamespace, limit_count_redis.Name, map[string]interface{}{
+		"keys":      ks,
+		"remaining": minRemain,
+	})
+}
+
 func (f *filter) DecodeHeaders(headers api.RequestHeaderMap, endStream bool) api.ResultAction {
-	ctx := context.Background()
+	ctx := tracing.ContextWithRequest(context.Background(), f.callbacks, headers)
 	config := f.config
 	n := len(config.limiters)
 	keys := make([]string, n)
+	userKeys := make([]string, n)
 	args := make([]interface{}, n*2)
 	for i, limiter := range config.limiters {
 		key := f.getKey(limiter.script, headers)
 		keys[i] = limiter.prefix + "|" + key
+		userKeys[i] = key
 
 		api.LogInfof("limitCountRedis filter, key: %s", key)
 
@@ -156,6 +184,7 @@ func (f *filter) DecodeHeaders(headers api.RequestHeaderMap, endStream bool) api
 		ress = res.([]interface{})
 	}
 	f.ress = ress
+	f.publish(userKeys)
 
 	for i := range config.limiters {
 		remain := ress[2*i].(int64)
@@ -22,6 +22,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"mosn.io/htnn/api/pkg/filtermanager/api"
 	"mosn.io/htnn/api/plugins/tests/pkg/envoy"
 	"mosn.io/htnn/types/pkg/expr"
 )
@@ -65,3 +66,17 @@ func TestGetKey(t *testing.T) {
 		})
 	}
 }
+
+func TestPublishDecision(t *testing.T) {
+	cb := envoy.NewFilterCallbackHandler()
+	f := factory(&config{}, cb).(*filter)
+	// remaining and ttl of each rule
+	f.ress = []interface{}{int64(3), int64(10), int64(-1), int64(5)}
+	f.publish([]string{"alice", "183.128.130.43"})
+
+	md := cb.StreamInfo().DynamicMetadata().Get(api.DynamicMetadataNamespace)
+	assert.Equal(t, map[string]interface{}{
+		"keys":      []interface{}{"alice", "183.128.130.43"},
+		"remaining": int64(0),
+	}, md["limitCountRedis"])
+}
@@ -15,9 +15,11 @@
 package limit_req
 
 import (
+	"math"
 	"time"
 
 	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/types/plugins/limit_req"
 )
 
 func factory(c interface{}, callbacks api.FilterCallbackHandler) api.Filter {
@@ -34,6 +36,15 @@ type filter struct {
 	config    *config
 }
 
+// publish exposes the decision via the dynamic metadata, so it can be referred in the access log
+func (f *filter) publish(key string, tokens float64) {
+	remaining := int(math.Max(0, math.Floor(tokens)))
+	f.callbacks.StreamInfo().DynamicMetadata().Set(api.DynamicMetadataNamespace, limit_req.Name, map[string]interface{}{
+		"key":       key,
+		"remaining": remaining,
+	})
+}
+
 func (f *filter) DecodeHeaders(headers api.RequestHeaderMap, endStream bool) api.ResultAction {
 	config := f.config
 
@@ -56,15 +67,18 @@ func (f *filter) DecodeHeaders(headers api.RequestHeaderMap, endStream bool) api
 
 	// Get also extends the ttl
 	bucket := config.buckets.Get(key)
-	res := bucket.Value().Reserve()
+	limiter := bucket.Value()
+	res := limiter.Reserve()
 	delay := res.Delay()
 
 	api.LogInfof("limitReq filter, key: %s, delay: %s", key, delay)
 
 	if delay > config.maxDelay {
 		res.Cancel()
+		f.publish(key, limiter.Tokens())
 		return &api.LocalResponse{Code: 429}
 	}
+	f.publish(key, limiter.Tokens())
 	time.Sleep(delay)
 	return api.Continue
 }
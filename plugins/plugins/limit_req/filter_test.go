@@ -0,0 +1,50 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package limit_req
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/plugins/tests/pkg/envoy"
+)
+
+func TestPublishDecision(t *testing.T) {
+	conf := &config{}
+	require.Nil(t, protojson.Unmarshal([]byte(`{"average":1,"burst":2,"period":"60s","key":"request.header(\"x-key\")"}`), conf))
+	require.Nil(t, conf.Validate())
+	require.Nil(t, conf.Init(nil))
+
+	hdr := envoy.NewRequestHeaderMap(http.Header{"X-Key": []string{"alice"}})
+	for _, remaining := range []int{1, 0} {
+		cb := envoy.NewFilterCallbackHandler()
+		f := factory(conf, cb)
+		assert.Equal(t, api.Continue, f.DecodeHeaders(hdr, true))
+		md := cb.StreamInfo().DynamicMetadata().Get(api.DynamicMetadataNamespace)
+		assert.Equal(t, map[string]interface{}{"key": "alice", "remaining": remaining}, md["limitReq"])
+	}
+
+	cb := envoy.NewFilterCallbackHandler()
+	f := factory(conf, cb)
+	res := f.DecodeHeaders(hdr, true)
+	assert.Equal(t, 429, res.(*api.LocalResponse).Code)
+	md := cb.StreamInfo().DynamicMetadata().Get(api.DynamicMetadataNamespace)
+	assert.Equal(t, map[string]interface{}{"key": "alice", "remaining": 0}, md["limitReq"])
+}
@@ -0,0 +1,111 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package load_shedding
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/cel-go/cel"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/plugins"
+	"mosn.io/htnn/types/pkg/expr"
+	"mosn.io/htnn/types/plugins/load_shedding"
+)
+
+func init() {
+	plugins.RegisterHttpPlugin(load_shedding.Name, &plugin{})
+}
+
+type plugin struct {
+	load_shedding.Plugin
+}
+
+func (p *plugin) Factory() api.FilterFactory {
+	return factory
+}
+
+func (p *plugin) Config() api.PluginConfig {
+	return &config{}
+}
+
+type config struct {
+	load_shedding.CustomConfig
+
+	lowPriorityScript expr.Script
+	opts              limiterOptions
+
+	// The configuration may be shared by multiple routes when it's configured in the
+	// HTTP filter level, so we keep a limiter per route.
+	limiters sync.Map
+}
+
+func (conf *config) Init(cb api.ConfigCallbackHandler) error {
+	if conf.LowPriority != "" {
+		conf.lowPriorityScript, _ = expr.CompileCel(conf.LowPriority, cel.BoolType)
+	}
+
+	opts := limiterOptions{
+		initialLimit: 20,
+		minLimit:     1,
+		maxLimit:     1000,
+		window:       time.Second,
+		minSamples:   10,
+		tolerance:    1.5,
+		maxErrorRate: 0.1,
+		backoffRatio: 0.9,
+	}
+	if conf.MinLimit != 0 {
+		opts.minLimit = float64(conf.MinLimit)
+	}
+	if conf.MaxLimit != 0 {
+		opts.maxLimit = float64(conf.MaxLimit)
+	}
+	if conf.InitialLimit != 0 {
+		opts.initialLimit = float64(conf.InitialLimit)
+	}
+	if opts.initialLimit < opts.minLimit {
+		opts.initialLimit = opts.minLimit
+	}
+	if opts.initialLimit > opts.maxLimit {
+		opts.initialLimit = opts.maxLimit
+	}
+	if conf.Window != nil {
+		opts.window = conf.Window.AsDuration()
+	}
+	if conf.MinSamples != 0 {
+		opts.minSamples = int(conf.MinSamples)
+	}
+	if conf.Tolerance != 0 {
+		opts.tolerance = conf.Tolerance
+	}
+	if conf.MaxErrorRate != 0 {
+		opts.maxErrorRate = conf.MaxErrorRate
+	}
+	if conf.BackoffRatio != 0 {
+		opts.backoffRatio = conf.BackoffRatio
+	}
+	conf.opts = opts
+	return nil
+}
+
+func (conf *config) getLimiter(route string) *limiter {
+	l, ok := conf.limiters.Load(route)
+	if !ok {
+		l, _ = conf.limiters.LoadOrStore(route, newLimiter(conf.opts))
+	}
+	return l.(*limiter)
+}
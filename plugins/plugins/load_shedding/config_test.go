@@ -0,0 +1,86 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package load_shedding
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestConfig(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{
+			name:  "default",
+			input: `{}`,
+		},
+		{
+			name:  "bad lowPriority",
+			input: `{"lowPriority":"request.path()"}`,
+			err:   "got string, wanted bool",
+		},
+		{
+			name:  "bad window",
+			input: `{"window":"0.001s"}`,
+			err:   "invalid Config.Window",
+		},
+		{
+			name:  "bad tolerance",
+			input: `{"tolerance":0.5}`,
+			err:   "invalid Config.Tolerance",
+		},
+		{
+			name:  "bad backoffRatio",
+			input: `{"backoffRatio":1}`,
+			err:   "invalid Config.BackoffRatio",
+		},
+		{
+			name:  "bad limits",
+			input: `{"minLimit":10,"maxLimit":5}`,
+			err:   "min_limit should not be greater than max_limit",
+		},
+		{
+			name:  "bad initial limit",
+			input: `{"minLimit":10,"initialLimit":5}`,
+			err:   "initial_limit should not be less than min_limit",
+		},
+		{
+			name:  "pass",
+			input: `{"lowPriority":"request.header(\"x-priority\") == \"low\"","initialLimit":10,"minLimit":5,"maxLimit":100,"window":"0.5s"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := &config{}
+			err := protojson.Unmarshal([]byte(tt.input), conf)
+			if err == nil {
+				err = conf.Validate()
+			}
+			if tt.err == "" {
+				assert.Nil(t, err)
+				err = conf.Init(nil)
+				assert.Nil(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
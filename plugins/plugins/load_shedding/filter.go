@@ -0,0 +1,118 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package load_shedding
+
+import (
+	"net/http"
+	"time"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/types/plugins/load_shedding"
+)
+
+func factory(c interface{}, callbacks api.FilterCallbackHandler) api.Filter {
+	return &filter{
+		callbacks: callbacks,
+		config:    c.(*config),
+	}
+}
+
+type filter struct {
+	api.PassThroughFilter
+
+	callbacks api.FilterCallbackHandler
+	config    *config
+
+	// set when the request is admitted
+	limiter *limiter
+	start   time.Time
+	latency time.Duration
+}
+
+type report struct {
+	Limit    int64 `json:"limit"`
+	Inflight int64 `json:"inflight"`
+	Shed     bool  `json:"shed"`
+}
+
+func (f *filter) report(r *report) {
+	// This is a private API of the debugMode plugin and we don't guarantee its stability
+	state := f.callbacks.PluginState()
+	reports, _ := state.Get("debugMode", "pluginReports").(map[string]any)
+	if reports == nil {
+		reports = map[string]any{}
+		state.Set("debugMode", "pluginReports", reports)
+	}
+	reports[load_shedding.Name] = r
+}
+
+func (f *filter) isLowPriority(headers api.RequestHeaderMap) bool {
+	script := f.config.lowPriorityScript
+	if script == nil {
+		return true
+	}
+
+	res, err := script.EvalWithRequest(f.callbacks, headers)
+	if err != nil {
+		// Don't shed the request because of the bad script
+		api.LogErrorf("failed to eval script with request: %v", err)
+		return false
+	}
+	return res.(bool)
+}
+
+func (f *filter) DecodeHeaders(headers api.RequestHeaderMap, endStream bool) api.ResultAction {
+	l := f.config.getLimiter(f.callbacks.StreamInfo().GetRouteName())
+	admitted := l.Acquire(f.isLowPriority(headers))
+	f.report(&report{
+		Limit:    l.Limit(),
+		Inflight: l.Inflight(),
+		Shed:     !admitted,
+	})
+
+	if !admitted {
+		api.LogInfof("loadShedding filter sheds request, limit: %d", l.Limit())
+		hdr := http.Header{}
+		hdr.Set("x-envoy-overloaded", "true")
+		return &api.LocalResponse{Code: 503, Header: hdr}
+	}
+
+	f.limiter = l
+	f.start = time.Now()
+	return api.Continue
+}
+
+func (f *filter) EncodeHeaders(headers api.ResponseHeaderMap, endStream bool) api.ResultAction {
+	if f.limiter != nil && f.latency == 0 {
+		f.latency = time.Since(f.start)
+	}
+	return api.Continue
+}
+
+func (f *filter) OnLog(reqHeaders api.RequestHeaderMap, reqTrailers api.RequestTrailerMap,
+	respHeaders api.ResponseHeaderMap, respTrailers api.ResponseTrailerMap) {
+
+	if f.limiter == nil {
+		return
+	}
+
+	latency := f.latency
+	if latency == 0 {
+		// the response header is not received
+		latency = time.Since(f.start)
+	}
+	code, _ := f.callbacks.StreamInfo().ResponseCode()
+	f.limiter.Release(latency, code >= 500, time.Now())
+}
@@ -0,0 +1,143 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package load_shedding
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/plugins/tests/pkg/envoy"
+)
+
+func testOptions() limiterOptions {
+	return limiterOptions{
+		initialLimit: 10,
+		minLimit:     1,
+		maxLimit:     100,
+		window:       time.Second,
+		minSamples:   1,
+		tolerance:    1.5,
+		maxErrorRate: 0.1,
+		backoffRatio: 0.5,
+	}
+}
+
+func TestLimiterAcquire(t *testing.T) {
+	opts := testOptions()
+	opts.initialLimit = 2
+	l := newLimiter(opts)
+
+	assert.True(t, l.Acquire(true))
+	assert.True(t, l.Acquire(true))
+	assert.False(t, l.Acquire(true))
+	// high priority request is never shed
+	assert.True(t, l.Acquire(false))
+	assert.Equal(t, int64(3), l.Inflight())
+
+	l.Release(time.Millisecond, false, time.Now())
+	l.Release(time.Millisecond, false, time.Now())
+	assert.True(t, l.Acquire(true))
+}
+
+func TestLimiterBackoffOnErrors(t *testing.T) {
+	l := newLimiter(testOptions())
+	now := time.Now()
+	l.Acquire(true)
+	l.Release(time.Millisecond, true, now)
+	l.Acquire(true)
+	l.Release(time.Millisecond, true, now.Add(time.Second))
+	assert.Equal(t, int64(5), l.Limit())
+
+	for i := 2; i < 10; i++ {
+		l.Acquire(true)
+		l.Release(time.Millisecond, true, now.Add(time.Duration(i)*time.Second))
+	}
+	assert.Equal(t, int64(1), l.Limit())
+}
+
+func TestLimiterGradient(t *testing.T) {
+	l := newLimiter(testOptions())
+	now := time.Now()
+
+	run := func(latency time.Duration, concurrency int) {
+		for i := 0; i < concurrency; i++ {
+			l.Acquire(false)
+		}
+		for i := 0; i < concurrency-1; i++ {
+			l.Release(latency, false, now)
+		}
+		// the last one finishes the window
+		now = now.Add(time.Second)
+		l.Release(latency, false, now)
+	}
+
+	run(10*time.Millisecond, 1)
+	// establish the long-term latency
+	run(10*time.Millisecond, 1)
+	// low traffic doesn't increase the limit
+	assert.Equal(t, int64(10), l.Limit())
+
+	// saturated with stable latency
+	run(10*time.Millisecond, 10)
+	run(10*time.Millisecond, 10)
+	grown := l.Limit()
+	assert.Greater(t, grown, int64(10))
+
+	// latency grows
+	run(100*time.Millisecond, 10)
+	run(100*time.Millisecond, 10)
+	assert.Less(t, l.Limit(), grown)
+}
+
+func TestFilter(t *testing.T) {
+	conf := &config{}
+	err := protojson.Unmarshal([]byte(`{"initialLimit":1,"lowPriority":"request.header(\"x-priority\") == \"low\""}`), conf)
+	require.NoError(t, err)
+	require.NoError(t, conf.Validate())
+	require.NoError(t, conf.Init(nil))
+
+	low := envoy.NewRequestHeaderMap(http.Header{"X-Priority": []string{"low"}})
+	high := envoy.NewRequestHeaderMap(http.Header{})
+
+	cb1 := envoy.NewFilterCallbackHandler()
+	f1 := factory(conf, cb1).(*filter)
+	assert.Equal(t, api.Continue, f1.DecodeHeaders(low, true))
+
+	cb2 := envoy.NewFilterCallbackHandler()
+	f2 := factory(conf, cb2).(*filter)
+	res := f2.DecodeHeaders(low, true)
+	resp, ok := res.(*api.LocalResponse)
+	require.True(t, ok)
+	assert.Equal(t, 503, resp.Code)
+	reports := cb2.PluginState().Get("debugMode", "pluginReports").(map[string]any)
+	assert.Equal(t, &report{Limit: 1, Inflight: 1, Shed: true}, reports["loadShedding"])
+	// the shed request doesn't affect the limiter
+	f2.OnLog(low, nil, nil, nil)
+
+	cb3 := envoy.NewFilterCallbackHandler()
+	f3 := factory(conf, cb3).(*filter)
+	assert.Equal(t, api.Continue, f3.DecodeHeaders(high, true))
+	assert.Equal(t, int64(2), conf.getLimiter("").Inflight())
+
+	f1.OnLog(low, nil, nil, nil)
+	f3.OnLog(high, nil, nil, nil)
+	assert.Equal(t, int64(0), conf.getLimiter("").Inflight())
+}
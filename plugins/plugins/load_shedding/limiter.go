@@ -0,0 +1,153 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package load_shedding
+
+import (
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type limiterOptions struct {
+	initialLimit float64
+	minLimit     float64
+	maxLimit     float64
+	window       time.Duration
+	minSamples   int
+	tolerance    float64
+	maxErrorRate float64
+	backoffRatio float64
+}
+
+// limiter computes the concurrency limit with a gradient algorithm which is similar to
+// Netflix's Gradient2 limiter: the limit grows when the latency in the current window is close
+// to the long-term latency, and shrinks when the latency grows. When the error rate is too high,
+// the limit is reduced multiplicatively like AIMD.
+type limiter struct {
+	opts limiterOptions
+
+	inflight atomic.Int64
+	// store the float64 bits so that the hot path doesn't need to hold the lock
+	limit atomic.Uint64
+
+	lock sync.Mutex
+	// the stats in current window
+	windowStart time.Time
+	samples     int
+	errors      int
+	latencySum  time.Duration
+	maxInflight int64
+	// the exponential moving average of the latency, in seconds
+	longLatency float64
+}
+
+func newLimiter(opts limiterOptions) *limiter {
+	l := &limiter{
+		opts: opts,
+	}
+	l.setLimit(opts.initialLimit)
+	return l
+}
+
+func (l *limiter) Limit() int64 {
+	return int64(math.Float64frombits(l.limit.Load()))
+}
+
+func (l *limiter) setLimit(limit float64) {
+	l.limit.Store(math.Float64bits(limit))
+}
+
+func (l *limiter) Inflight() int64 {
+	return l.inflight.Load()
+}
+
+// Acquire returns false if the request should be shed. High priority requests are always
+// admitted, but they still count into the concurrency.
+func (l *limiter) Acquire(lowPriority bool) bool {
+	n := l.inflight.Add(1)
+	if lowPriority && n > l.Limit() {
+		l.inflight.Add(-1)
+		return false
+	}
+	return true
+}
+
+// Release records the result of an admitted request.
+func (l *limiter) Release(latency time.Duration, failed bool, now time.Time) {
+	n := l.inflight.Add(-1) + 1
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if l.windowStart.IsZero() {
+		l.windowStart = now
+	}
+	l.samples++
+	if failed {
+		l.errors++
+	}
+	l.latencySum += latency
+	if n > l.maxInflight {
+		l.maxInflight = n
+	}
+
+	if now.Sub(l.windowStart) < l.opts.window || l.samples < l.opts.minSamples {
+		return
+	}
+
+	l.update()
+
+	l.windowStart = now
+	l.samples = 0
+	l.errors = 0
+	l.latencySum = 0
+	l.maxInflight = 0
+}
+
+func (l *limiter) update() {
+	opts := &l.opts
+	limit := math.Float64frombits(l.limit.Load())
+	latency := l.latencySum.Seconds() / float64(l.samples)
+	errorRate := float64(l.errors) / float64(l.samples)
+
+	var newLimit float64
+	if errorRate > opts.maxErrorRate {
+		newLimit = limit * opts.backoffRatio
+	} else {
+		if l.longLatency == 0 {
+			l.longLatency = latency
+		}
+
+		gradient := 1.0
+		if latency > 0 {
+			gradient = math.Max(0.5, math.Min(1.0, opts.tolerance*l.longLatency/latency))
+		}
+		if gradient == 1.0 && float64(l.maxInflight) < limit/2 {
+			// The traffic is too low to prove the limit can be increased
+			newLimit = limit
+		} else {
+			// sqrt(limit) works as the allowed queue size
+			newLimit = limit*gradient + math.Sqrt(limit)
+			// Smooth the change to avoid oscillation
+			newLimit = limit*0.8 + newLimit*0.2
+		}
+
+		l.longLatency = l.longLatency*0.9 + latency*0.1
+	}
+
+	newLimit = math.Max(opts.minLimit, math.Min(opts.maxLimit, newLimit))
+	l.setLimit(newLimit)
+}
@@ -0,0 +1,161 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mock_response
+
+import (
+	"net/http"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/google/cel-go/cel"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/plugins"
+	"mosn.io/htnn/types/pkg/expr"
+	"mosn.io/htnn/types/plugins/mock_response"
+)
+
+func init() {
+	plugins.RegisterHttpPlugin(mock_response.Name, &plugin{})
+}
+
+type plugin struct {
+	mock_response.Plugin
+}
+
+func (p *plugin) Factory() api.FilterFactory {
+	return factory
+}
+
+func (p *plugin) Config() api.PluginConfig {
+	return &config{}
+}
+
+type headerMatcher struct {
+	name  string
+	value expr.Matcher
+}
+
+type matcher struct {
+	methods map[string]bool
+	path    expr.Matcher
+	headers []*headerMatcher
+	script  expr.Script
+}
+
+type response struct {
+	status int
+	header http.Header
+	text   string
+	tmpl   *template.Template
+	delay  time.Duration
+}
+
+type rule struct {
+	matcher  *matcher
+	response *response
+}
+
+type config struct {
+	mock_response.CustomConfig
+
+	rules []*rule
+}
+
+func buildMatcher(m *mock_response.Match) (*matcher, error) {
+	if m == nil {
+		return nil, nil
+	}
+
+	res := &matcher{}
+	if len(m.Methods) > 0 {
+		res.methods = make(map[string]bool, len(m.Methods))
+		for _, method := range m.Methods {
+			res.methods[strings.ToUpper(method)] = true
+		}
+	}
+	if m.Path != nil {
+		path, err := expr.BuildStringMatcher(m.Path)
+		if err != nil {
+			return nil, err
+		}
+		res.path = path
+	}
+	for _, h := range m.Headers {
+		hm := &headerMatcher{name: h.Name}
+		if h.Value != nil {
+			value, err := expr.BuildStringMatcher(h.Value)
+			if err != nil {
+				return nil, err
+			}
+			hm.value = value
+		}
+		res.headers = append(res.headers, hm)
+	}
+	if m.Expr != "" {
+		script, err := expr.CompileCel(m.Expr, cel.BoolType)
+		if err != nil {
+			return nil, err
+		}
+		res.script = script
+	}
+	return res, nil
+}
+
+func buildResponse(r *mock_response.Response) (*response, error) {
+	res := &response{
+		status: int(r.Status),
+		header: http.Header{},
+		text:   r.GetText(),
+	}
+	if res.status == 0 {
+		res.status = 200
+	}
+	for _, hv := range r.Headers {
+		res.header.Add(hv.Key, hv.Value)
+	}
+	if r.GetTemplate() != "" {
+		tmpl, err := mock_response.ParseTemplate(r.GetTemplate())
+		if err != nil {
+			return nil, err
+		}
+		res.tmpl = tmpl
+	}
+	if (res.text != "" || res.tmpl != nil) && res.header.Get("content-type") == "" {
+		// otherwise the body will be wrapped into a JSON by the local reply
+		res.header.Set("content-type", "text/plain")
+	}
+	if r.Delay != nil {
+		res.delay = r.Delay.AsDuration()
+	}
+	return res, nil
+}
+
+func (conf *config) Init(cb api.ConfigCallbackHandler) error {
+	conf.rules = make([]*rule, 0, len(conf.Rules))
+	for _, r := range conf.Rules {
+		m, err := buildMatcher(r.Match)
+		if err != nil {
+			return err
+		}
+		rsp, err := buildResponse(r.Response)
+		if err != nil {
+			return err
+		}
+		conf.rules = append(conf.rules, &rule{matcher: m, response: rsp})
+	}
+	return nil
+}
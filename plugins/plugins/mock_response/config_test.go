@@ -0,0 +1,94 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mock_response
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestConfig(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{
+			name:  "no rule",
+			input: `{}`,
+			err:   "invalid Config.Rules: value must contain between 1 and 64 items, inclusive",
+		},
+		{
+			name:  "no response",
+			input: `{"rules":[{"match":{"methods":["GET"]}}]}`,
+			err:   "invalid Rule.Response: value is required",
+		},
+		{
+			name:  "invalid status",
+			input: `{"rules":[{"response":{"status":100}}]}`,
+			err:   "invalid status 100 of rule 0",
+		},
+		{
+			name:  "invalid path",
+			input: `{"rules":[{"match":{"path":{"regex":"("}},"response":{}}]}`,
+			err:   "invalid match of rule 0: error parsing regexp",
+		},
+		{
+			name:  "invalid expr",
+			input: `{"rules":[{"match":{"expr":"request.path()"},"response":{}}]}`,
+			err:   "invalid match of rule 0",
+		},
+		{
+			name:  "invalid template",
+			input: `{"rules":[{"response":{"template":"{{.Header"}}]}`,
+			err:   "invalid template of rule 0",
+		},
+		{
+			name:  "pseudo header",
+			input: `{"rules":[{"response":{"headers":[{"key":":status","value":"200"}]}}]}`,
+			err:   "pseudo header :status can't be set in rule 0",
+		},
+		{
+			name:  "delay too long",
+			input: `{"rules":[{"response":{"delay":"61s"}}]}`,
+			err:   "invalid Response.Delay: value must be less than or equal to 1m0s",
+		},
+		{
+			name: "pass",
+			input: `{"rules":[{"match":{"methods":["get"],"path":{"prefix":"/api/"},
+				"headers":[{"name":"x-mock"},{"name":"x-env","value":{"exact":"dev"}}],
+				"expr":"request.query('id') != ''"},
+				"response":{"status":201,"text":"ok","delay":"1s"}}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := &config{}
+			err := protojson.Unmarshal([]byte(tt.input), conf)
+			if err == nil {
+				err = conf.Validate()
+			}
+			if tt.err == "" {
+				assert.Nil(t, err)
+				assert.Nil(t, conf.Init(nil))
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
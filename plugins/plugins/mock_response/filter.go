@@ -0,0 +1,142 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mock_response
+
+import (
+	"net/url"
+	"strings"
+	"time"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+)
+
+func factory(c interface{}, callbacks api.FilterCallbackHandler) api.Filter {
+	return &filter{
+		callbacks: callbacks,
+		config:    c.(*config),
+	}
+}
+
+type filter struct {
+	api.PassThroughFilter
+
+	callbacks  api.FilterCallbackHandler
+	config     *config
+	reqHeaders api.RequestHeaderMap
+	query      url.Values
+}
+
+// The methods below can be used in the templates, like `{{ .Header "x-user" }}`
+
+func (f *filter) Method() string {
+	return f.reqHeaders.Method()
+}
+
+func (f *filter) Path() string {
+	return f.reqHeaders.Url().Path
+}
+
+func (f *filter) Header(name string) string {
+	v, _ := f.reqHeaders.Get(name)
+	return v
+}
+
+func (f *filter) Query(name string) string {
+	if f.query == nil {
+		f.query = f.reqHeaders.Url().Query()
+	}
+	return f.query.Get(name)
+}
+
+func (f *filter) Consumer() string {
+	consumer := f.callbacks.GetConsumer()
+	if consumer == nil {
+		return ""
+	}
+	return consumer.Name()
+}
+
+func (f *filter) Route() string {
+	return f.callbacks.StreamInfo().GetRouteName()
+}
+
+func (f *filter) Property(name string) string {
+	v, err := f.callbacks.GetProperty(name)
+	if err != nil {
+		api.LogInfof("mockResponse: failed to get property %s: %v", name, err)
+		return ""
+	}
+	return v
+}
+
+func (f *filter) match(m *matcher) bool {
+	if m == nil {
+		return true
+	}
+
+	headers := f.reqHeaders
+	if m.methods != nil && !m.methods[headers.Method()] {
+		return false
+	}
+	if m.path != nil && !m.path.Match(headers.Url().Path) {
+		return false
+	}
+	for _, hm := range m.headers {
+		v, ok := headers.Get(hm.name)
+		if !ok {
+			return false
+		}
+		if hm.value != nil && !hm.value.Match(v) {
+			return false
+		}
+	}
+	if m.script != nil {
+		res, err := m.script.EvalWithRequest(f.callbacks, headers)
+		if err != nil {
+			api.LogErrorf("mockResponse: failed to eval expression: %v", err)
+			return false
+		}
+		if !res.(bool) {
+			return false
+		}
+	}
+	return true
+}
+
+func (f *filter) DecodeHeaders(headers api.RequestHeaderMap, endStream bool) api.ResultAction {
+	f.reqHeaders = headers
+	for _, r := range f.config.rules {
+		if !f.match(r.matcher) {
+			continue
+		}
+
+		rsp := r.response
+		body := rsp.text
+		if rsp.tmpl != nil {
+			var sb strings.Builder
+			err := rsp.tmpl.Execute(&sb, f)
+			if err != nil {
+				api.LogErrorf("mockResponse: failed to render the body: %v", err)
+				return &api.LocalResponse{Code: 500}
+			}
+			body = sb.String()
+		}
+		if rsp.delay > 0 {
+			time.Sleep(rsp.delay)
+		}
+		return &api.LocalResponse{Code: rsp.status, Msg: body, Header: rsp.header.Clone()}
+	}
+	return api.Continue
+}
@@ -0,0 +1,118 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mock_response
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/plugins/tests/pkg/envoy"
+)
+
+func newConfig(t *testing.T, input string) *config {
+	conf := &config{}
+	require.Nil(t, protojson.Unmarshal([]byte(input), conf))
+	require.Nil(t, conf.Validate())
+	require.Nil(t, conf.Init(nil))
+	return conf
+}
+
+func TestMatch(t *testing.T) {
+	conf := newConfig(t, `{"rules":[
+		{"match":{"methods":["POST"],"path":{"exact":"/orders"}},
+			"response":{"status":201,"headers":[{"key":"content-type","value":"application/json"}],"text":"{\"id\":1}"}},
+		{"match":{"headers":[{"name":"x-mock"},{"name":"x-env","value":{"prefix":"dev"}}]},
+			"response":{"text":"by header"}},
+		{"match":{"expr":"request.query('mock') == 'true'"},
+			"response":{"status":204}}
+	]}`)
+
+	tests := []struct {
+		name   string
+		header http.Header
+		res    api.ResultAction
+	}{
+		{
+			name:   "method and path",
+			header: http.Header{":method": []string{"POST"}, ":path": []string{"/orders?x=1"}},
+			res: &api.LocalResponse{Code: 201, Msg: `{"id":1}`,
+				Header: http.Header{"Content-Type": []string{"application/json"}}},
+		},
+		{
+			name:   "method mismatch",
+			header: http.Header{":method": []string{"GET"}, ":path": []string{"/orders"}},
+			res:    api.Continue,
+		},
+		{
+			name: "headers",
+			header: http.Header{":method": []string{"GET"}, ":path": []string{"/"},
+				"X-Mock": []string{"1"}, "X-Env": []string{"dev-1"}},
+			res: &api.LocalResponse{Code: 200, Msg: "by header",
+				Header: http.Header{"Content-Type": []string{"text/plain"}}},
+		},
+		{
+			name: "header value mismatch",
+			header: http.Header{":method": []string{"GET"}, ":path": []string{"/"},
+				"X-Mock": []string{"1"}, "X-Env": []string{"prod"}},
+			res: api.Continue,
+		},
+		{
+			name:   "expr",
+			header: http.Header{":method": []string{"GET"}, ":path": []string{"/?mock=true"}},
+			res:    &api.LocalResponse{Code: 204, Header: http.Header{}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := factory(conf, envoy.NewFilterCallbackHandler())
+			hdr := envoy.NewRequestHeaderMap(tt.header)
+			assert.Equal(t, tt.res, f.DecodeHeaders(hdr, true))
+		})
+	}
+}
+
+func TestTemplate(t *testing.T) {
+	conf := newConfig(t, `{"rules":[{"response":{
+		"headers":[{"key":"content-type","value":"application/json"}],
+		"template":"{\"method\":\"{{.Method}}\",\"path\":\"{{.Path}}\",\"user\":\"{{.Header \"x-user\"}}\",\"id\":\"{{.Query \"id\"}}\"}"}}]}`)
+	f := factory(conf, envoy.NewFilterCallbackHandler())
+	hdr := envoy.NewRequestHeaderMap(http.Header{
+		":method": []string{"GET"},
+		":path":   []string{"/users?id=42"},
+		"X-User":  []string{"alice"},
+	})
+	res := f.DecodeHeaders(hdr, true)
+	lr, ok := res.(*api.LocalResponse)
+	require.True(t, ok)
+	assert.Equal(t, `{"method":"GET","path":"/users","user":"alice","id":"42"}`, lr.Msg)
+	assert.Equal(t, "application/json", lr.Header.Get("content-type"))
+}
+
+func TestDelay(t *testing.T) {
+	conf := newConfig(t, `{"rules":[{"response":{"status":503,"text":"under maintenance","delay":"0.1s"}}]}`)
+	f := factory(conf, envoy.NewFilterCallbackHandler())
+	hdr := envoy.NewRequestHeaderMap(http.Header{":method": []string{"GET"}, ":path": []string{"/"}})
+	start := time.Now()
+	res := f.DecodeHeaders(hdr, true)
+	assert.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond)
+	assert.Equal(t, 503, res.(*api.LocalResponse).Code)
+}
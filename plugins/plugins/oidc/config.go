@@ -27,6 +27,7 @@ import (
 
 	"mosn.io/htnn/api/pkg/filtermanager/api"
 	"mosn.io/htnn/api/pkg/plugins"
+	"mosn.io/htnn/api/pkg/tracing"
 	oidctype "mosn.io/htnn/types/plugins/oidc"
 )
 
@@ -58,7 +59,10 @@ type config struct {
 }
 
 func (conf *config) ctxWithClient(ctx context.Context) context.Context {
-	httpClient := &http.Client{Timeout: conf.opTimeout}
+	httpClient := &http.Client{
+		Timeout:   conf.opTimeout,
+		Transport: tracing.NewTransport(nil),
+	}
 	return context.WithValue(ctx, oauth2.HTTPClient, httpClient)
 }
 
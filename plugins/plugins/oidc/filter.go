@@ -29,6 +29,7 @@ import (
 	"golang.org/x/oauth2"
 
 	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/tracing"
 )
 
 func factory(c interface{}, callbacks api.FilterCallbackHandler) api.Filter {
@@ -148,7 +149,7 @@ func (f *filter) refreshEnabled(token *oauth2.Token) bool {
 func (f *filter) handleCallback(headers api.RequestHeaderMap, query url.Values) api.ResultAction {
 	config := f.config
 	o2conf := config.oauth2Config
-	ctx := context.Background()
+	ctx := tracing.ContextWithRequest(context.Background(), f.callbacks, headers)
 	code := query.Get("code")
 	state := query.Get("state")
 
@@ -219,7 +220,7 @@ func (f *filter) handleCallback(headers api.RequestHeaderMap, query url.Values)
 
 func (f *filter) attachInfo(headers api.RequestHeaderMap, encodedToken string) api.ResultAction {
 	config := f.config
-	ctx := context.Background()
+	ctx := config.ctxWithClient(tracing.ContextWithRequest(context.Background(), f.callbacks, headers))
 
 	tokens := &Tokens{}
 	cookieName := f.CookieName("token")
@@ -232,7 +233,7 @@ func (f *filter) attachInfo(headers api.RequestHeaderMap, encodedToken string) a
 	oauth2Token := tokens.Oauth2Token
 	rawIDToken := tokens.IDToken
 	if f.refreshEnabled(oauth2Token) {
-		tokenSrc := config.oauth2Config.TokenSource(context.Background(), oauth2Token)
+		tokenSrc := config.oauth2Config.TokenSource(ctx, oauth2Token)
 		tokenSrc = oauth2.ReuseTokenSourceWithExpiry(oauth2Token, tokenSrc, config.refreshLeeway)
 		possibleRefreshedToken, err := tokenSrc.Token()
 		if err != nil {
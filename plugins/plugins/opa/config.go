@@ -25,6 +25,7 @@ import (
 
 	"mosn.io/htnn/api/pkg/filtermanager/api"
 	"mosn.io/htnn/api/pkg/plugins"
+	"mosn.io/htnn/api/pkg/tracing"
 	"mosn.io/htnn/types/plugins/opa"
 )
 
@@ -58,7 +59,10 @@ var (
 func (conf *config) Init(cb api.ConfigCallbackHandler) error {
 	remote := conf.GetRemote()
 	if remote != nil {
-		conf.client = &http.Client{Timeout: 200 * time.Millisecond}
+		conf.client = &http.Client{
+			Timeout:   200 * time.Millisecond,
+			Transport: tracing.NewTransport(nil),
+		}
 		return nil
 	}
 
@@ -19,11 +19,13 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"net/http"
 	"strings"
 
 	"github.com/open-policy-agent/opa/rego"
 
 	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/tracing"
 	"mosn.io/htnn/plugins/pkg/request"
 )
 
@@ -85,7 +87,7 @@ func (f *filter) buildInput(header api.RequestHeaderMap) map[string]interface{}
 	}
 }
 
-func (f *filter) isAllowed(input map[string]interface{}) (bool, error) {
+func (f *filter) isAllowed(ctx context.Context, input map[string]interface{}) (bool, error) {
 	remote := f.config.GetRemote()
 	if remote != nil {
 		params, err := json.Marshal(input)
@@ -95,7 +97,12 @@ func (f *filter) isAllowed(input map[string]interface{}) (bool, error) {
 
 		path := remote.GetUrl() + "/v1/data/" + remote.GetPolicy()
 		api.LogInfof("send request to opa: %s, param: %s", path, params)
-		resp, err := f.config.client.Post(path, "application/json", bytes.NewReader(params))
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, path, bytes.NewReader(params))
+		if err != nil {
+			return false, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := f.config.client.Do(req)
 		if err != nil {
 			return false, err
 		}
@@ -109,7 +116,6 @@ func (f *filter) isAllowed(input map[string]interface{}) (bool, error) {
 		return opaResponse.Result.Allow, nil
 	}
 
-	ctx := context.TODO()
 	results, err := f.config.query.Eval(ctx, rego.EvalInput(input["input"]))
 	if err != nil {
 		return false, err
@@ -126,7 +132,8 @@ func (f *filter) isAllowed(input map[string]interface{}) (bool, error) {
 
 func (f *filter) DecodeHeaders(headers api.RequestHeaderMap, endStream bool) api.ResultAction {
 	input := f.buildInput(headers)
-	allow, err := f.isAllowed(input)
+	ctx := tracing.ContextWithRequest(context.Background(), f.callbacks, headers)
+	allow, err := f.isAllowed(ctx, input)
 	if err != nil {
 		api.LogErrorf("failed to do OPA auth: %v", err)
 		return &api.LocalResponse{Code: 503}
@@ -101,11 +101,12 @@ func TestOpaRemote(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			resp := &http.Response{}
 			resp.Body = io.NopCloser(bytes.NewReader([]byte(tt.resp)))
-			patches := gomonkey.ApplyMethodFunc(cli, "Post",
-				func(url, contentType string, body io.Reader) (*http.Response, error) {
+			patches := gomonkey.ApplyMethodFunc(cli, "Do",
+				func(req *http.Request) (*http.Response, error) {
+					assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
 					if tt.checkInput != nil {
 						input := map[string]interface{}{}
-						data, _ := io.ReadAll(body)
+						data, _ := io.ReadAll(req.Body)
 						_ = json.Unmarshal(data, &input)
 						tt.checkInput(input)
 					}
@@ -0,0 +1,196 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin_tracing
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/protobuf/proto"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/plugins"
+	"mosn.io/htnn/types/plugins/plugin_tracing"
+)
+
+const (
+	defaultServiceName = "htnn"
+	defaultTimeout     = 10 * time.Second
+	tracerName         = "mosn.io/htnn"
+)
+
+var (
+	// The span processors are shared by the configurations with the same exporter, so the
+	// exporter is not recreated when only the sampling or the service name is updated.
+	// A processor is shut down once no configuration uses it.
+	processors     = map[string]*sharedProcessor{}
+	processorsLock sync.Mutex
+)
+
+type sharedProcessor struct {
+	sdktrace.SpanProcessor
+
+	refs int
+}
+
+func init() {
+	plugins.RegisterHttpPlugin(plugin_tracing.Name, &plugin{})
+}
+
+type plugin struct {
+	plugin_tracing.Plugin
+}
+
+func (p *plugin) Factory() api.FilterFactory {
+	return factory
+}
+
+func (p *plugin) Config() api.PluginConfig {
+	return &config{}
+}
+
+type config struct {
+	plugin_tracing.Config
+
+	once         sync.Once
+	tracer       trace.Tracer
+	processorKey string
+}
+
+func newExporter(otlp *plugin_tracing.Otlp) (sdktrace.SpanExporter, error) {
+	timeout := defaultTimeout
+	if otlp.Timeout != nil {
+		timeout = otlp.Timeout.AsDuration()
+	}
+
+	// Creating the exporter doesn't connect to the collector
+	ctx := context.Background()
+	if otlp.Protocol == plugin_tracing.Otlp_GRPC {
+		opts := []otlptracegrpc.Option{
+			otlptracegrpc.WithEndpoint(otlp.Endpoint),
+			otlptracegrpc.WithHeaders(otlp.Headers),
+			otlptracegrpc.WithTimeout(timeout),
+		}
+		if otlp.Insecure {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+		return otlptracegrpc.New(ctx, opts...)
+	}
+
+	opts := []otlptracehttp.Option{
+		otlptracehttp.WithEndpoint(otlp.Endpoint),
+		otlptracehttp.WithHeaders(otlp.Headers),
+		otlptracehttp.WithTimeout(timeout),
+	}
+	if otlp.Insecure {
+		opts = append(opts, otlptracehttp.WithInsecure())
+	}
+	return otlptracehttp.New(ctx, opts...)
+}
+
+func acquireSpanProcessor(otlp *plugin_tracing.Otlp) (string, sdktrace.SpanProcessor, error) {
+	b, err := proto.MarshalOptions{Deterministic: true}.Marshal(otlp)
+	if err != nil {
+		return "", nil, err
+	}
+	key := string(b)
+
+	processorsLock.Lock()
+	defer processorsLock.Unlock()
+
+	if sp, ok := processors[key]; ok {
+		sp.refs++
+		return key, sp, nil
+	}
+
+	exporter, err := newExporter(otlp)
+	if err != nil {
+		return "", nil, err
+	}
+
+	sp := &sharedProcessor{
+		SpanProcessor: sdktrace.NewBatchSpanProcessor(exporter),
+		refs:          1,
+	}
+	processors[key] = sp
+	return key, sp, nil
+}
+
+func releaseSpanProcessor(key string) {
+	processorsLock.Lock()
+	defer processorsLock.Unlock()
+
+	sp, ok := processors[key]
+	if !ok {
+		return
+	}
+	sp.refs--
+	if sp.refs > 0 {
+		return
+	}
+
+	delete(processors, key)
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+		defer cancel()
+		// flush the remaining spans and stop the exporter
+		if err := sp.SpanProcessor.Shutdown(ctx); err != nil {
+			api.LogErrorf("failed to shutdown span processor: %v", err)
+		}
+	}()
+}
+
+func (conf *config) tracerProvider() (*sdktrace.TracerProvider, error) {
+	key, sp, err := acquireSpanProcessor(conf.Otlp)
+	if err != nil {
+		return nil, err
+	}
+	conf.processorKey = key
+	runtime.SetFinalizer(conf, func(conf *config) {
+		releaseSpanProcessor(conf.processorKey)
+	})
+
+	serviceName := conf.ServiceName
+	if serviceName == "" {
+		serviceName = defaultServiceName
+	}
+	// The provider itself is cheap. Don't shutdown it as the processor is shared.
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSpanProcessor(sp),
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", serviceName))),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(conf.SampleRate))),
+	)
+	return tp, nil
+}
+
+func (conf *config) Tracer() trace.Tracer {
+	conf.once.Do(func() {
+		tp, err := conf.tracerProvider()
+		if err != nil {
+			api.LogErrorf("failed to create tracer provider: %v", err)
+			return
+		}
+		conf.tracer = tp.Tracer(tracerName)
+	})
+	return conf.tracer
+}
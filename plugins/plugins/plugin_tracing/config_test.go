@@ -0,0 +1,108 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin_tracing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"mosn.io/htnn/api/pkg/plugins"
+)
+
+func TestConfig(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{
+			name:  "otlp required",
+			input: `{}`,
+			err:   "invalid Config.Otlp",
+		},
+		{
+			name:  "endpoint required",
+			input: `{"otlp":{}}`,
+			err:   "invalid Otlp.Endpoint",
+		},
+		{
+			name:  "invalid sample rate",
+			input: `{"otlp":{"endpoint":"127.0.0.1:4318"},"sampleRate":1.5}`,
+			err:   "invalid Config.SampleRate",
+		},
+		{
+			name:  "invalid timeout",
+			input: `{"otlp":{"endpoint":"127.0.0.1:4318","timeout":"0s"}}`,
+			err:   "invalid Otlp.Timeout",
+		},
+		{
+			name:  "http",
+			input: `{"otlp":{"endpoint":"127.0.0.1:4318","insecure":true,"headers":{"x-token":"a"}},"sampleRate":0.5}`,
+		},
+		{
+			name:  "grpc",
+			input: `{"otlp":{"endpoint":"127.0.0.1:4317","protocol":"GRPC","timeout":"3s"},"serviceName":"gw"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := &config{}
+			err := protojson.Unmarshal([]byte(tt.input), conf)
+			if err == nil {
+				err = conf.Validate()
+			}
+			if tt.err == "" {
+				assert.Nil(t, err)
+				var tracer plugins.PluginTracer = conf
+				assert.NotNil(t, tracer.Tracer())
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestSpanProcessorShared(t *testing.T) {
+	c1 := &config{}
+	c2 := &config{}
+	c3 := &config{}
+	assert.Nil(t, protojson.Unmarshal([]byte(`{"otlp":{"endpoint":"127.0.0.1:4318"}}`), c1))
+	// only the sampling and the service name are different
+	assert.Nil(t, protojson.Unmarshal([]byte(`{"otlp":{"endpoint":"127.0.0.1:4318"},"sampleRate":0.5,"serviceName":"gw"}`), c2))
+	assert.Nil(t, protojson.Unmarshal([]byte(`{"otlp":{"endpoint":"127.0.0.1:4319"}}`), c3))
+
+	_, err := c1.tracerProvider()
+	assert.Nil(t, err)
+	_, err = c2.tracerProvider()
+	assert.Nil(t, err)
+	_, err = c3.tracerProvider()
+	assert.Nil(t, err)
+
+	assert.Equal(t, c1.processorKey, c2.processorKey)
+	assert.NotEqual(t, c1.processorKey, c3.processorKey)
+	assert.Equal(t, 2, processors[c1.processorKey].refs)
+	assert.Equal(t, 1, processors[c3.processorKey].refs)
+
+	// simulate the configurations are garbage collected
+	releaseSpanProcessor(c1.processorKey)
+	assert.Equal(t, 1, processors[c2.processorKey].refs)
+	releaseSpanProcessor(c2.processorKey)
+	releaseSpanProcessor(c3.processorKey)
+	assert.NotContains(t, processors, c1.processorKey)
+	assert.NotContains(t, processors, c3.processorKey)
+}
@@ -0,0 +1,100 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package quota
+
+import (
+	"crypto/tls"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/plugins"
+	"mosn.io/htnn/types/plugins/quota"
+)
+
+func init() {
+	plugins.RegisterHttpPlugin(quota.Name, &plugin{})
+}
+
+type plugin struct {
+	quota.Plugin
+}
+
+func (p *plugin) Factory() api.FilterFactory {
+	return factory
+}
+
+func (p *plugin) Config() api.PluginConfig {
+	return &config{}
+}
+
+type config struct {
+	quota.CustomConfig
+
+	client        *redis.Client
+	clusterClient *redis.ClusterClient
+
+	location *time.Location
+	prefix   string
+}
+
+func (conf *config) Init(cb api.ConfigCallbackHandler) error {
+	addr := conf.GetAddress()
+	if addr != "" {
+		opt := &redis.Options{
+			Addr:     addr,
+			Username: conf.Username,
+			Password: conf.Password,
+		}
+		if conf.Tls {
+			opt.TLSConfig = &tls.Config{
+				InsecureSkipVerify: conf.TlsSkipVerify,
+			}
+		}
+
+		conf.client = redis.NewClient(opt)
+
+	} else {
+		cluster := conf.GetCluster()
+		opt := &redis.ClusterOptions{
+			Addrs:    cluster.Addresses,
+			Username: conf.Username,
+			Password: conf.Password,
+		}
+		if conf.Tls {
+			opt.TLSConfig = &tls.Config{
+				InsecureSkipVerify: conf.TlsSkipVerify,
+			}
+		}
+
+		conf.clusterClient = redis.NewClusterClient(opt)
+	}
+
+	conf.location = time.UTC
+	if conf.TimeZone != "" {
+		// already validated
+		conf.location, _ = time.LoadLocation(conf.TimeZone)
+	}
+
+	// Unlike limitCountRedis, the prefix is not randomized so that the usage survives
+	// the configuration change.
+	conf.prefix = "htnn_quota"
+	if conf.Prefix != "" {
+		conf.prefix = conf.Prefix
+	}
+
+	return nil
+}
@@ -0,0 +1,91 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package quota
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestConfig(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{
+			name:  "quotas are required",
+			input: `{"address":"127.0.0.1:6379"}`,
+			err:   "invalid Config.Quotas",
+		},
+		{
+			name:  "address is required",
+			input: `{"quotas":[{"limit":1}]}`,
+			err:   "invalid Config.Source: value is required",
+		},
+		{
+			name:  "invalid address",
+			input: `{"address":"12::0:1", "quotas":[{"limit":1}]}`,
+			err:   "bad address 12::0:1",
+		},
+		{
+			name:  "bad time zone",
+			input: `{"address":"127.0.0.1:6379", "quotas":[{"limit":1}], "timeZone":"Mars/Olympus"}`,
+			err:   "bad time zone Mars/Olympus",
+		},
+		{
+			name:  "duplicate period",
+			input: `{"address":"127.0.0.1:6379", "quotas":[{"limit":1,"period":"MONTH"},{"limit":2,"period":"MONTH"}]}`,
+			err:   "duplicate period MONTH",
+		},
+		{
+			name:  "soft limit",
+			input: `{"address":"127.0.0.1:6379", "quotas":[{"limit":1,"softLimit":1}]}`,
+			err:   "soft limit should be less than limit",
+		},
+		{
+			name:  "passwd",
+			input: `{"address":"127.0.0.1:6379", "quotas":[{"limit":1}], "username":"user"}`,
+			err:   "password is required when username is set",
+		},
+		{
+			name:  "pass",
+			input: `{"address":"127.0.0.1:6379", "quotas":[{"limit":10,"softLimit":8},{"limit":100,"period":"MONTH"}], "timeZone":"Asia/Shanghai"}`,
+		},
+		{
+			name:  "cluster",
+			input: `{"cluster":{"addresses":["127.0.0.1:6379"]}, "quotas":[{"limit":10}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := &config{}
+			err := protojson.Unmarshal([]byte(tt.input), conf)
+			if err == nil {
+				err = conf.Validate()
+			}
+			if tt.err == "" {
+				assert.Nil(t, err)
+				err = conf.Init(nil)
+				assert.Nil(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,230 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package quota
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/plugins/pkg/stringx"
+	"mosn.io/htnn/types/plugins/quota"
+)
+
+func factory(c interface{}, callbacks api.FilterCallbackHandler) api.Filter {
+	return &filter{
+		callbacks: callbacks,
+		config:    c.(*config),
+	}
+}
+
+type filter struct {
+	api.PassThroughFilter
+
+	callbacks api.FilterCallbackHandler
+	config    *config
+
+	usages []*usage
+}
+
+type usage struct {
+	quota *quota.Quota
+	used  int64
+	// the end of the current calendar period
+	end    time.Time
+	window int64
+}
+
+func (u *usage) remaining() int64 {
+	remain := int64(u.quota.Limit) - u.used
+	if remain < 0 {
+		return 0
+	}
+	return remain
+}
+
+func periodName(p quota.Period) string {
+	return strings.ToLower(p.String())
+}
+
+// calendarPeriod returns the identifier, the start and the end of the calendar period
+// which contains the given time. The time is expected to be in the configured location.
+func calendarPeriod(p quota.Period, now time.Time) (string, time.Time, time.Time) {
+	var start, end time.Time
+	var id string
+	y, m, d := now.Date()
+	switch p {
+	case quota.Period_MONTH:
+		start = time.Date(y, m, 1, 0, 0, 0, 0, now.Location())
+		end = start.AddDate(0, 1, 0)
+		id = "m" + start.Format("200601")
+	default:
+		start = time.Date(y, m, d, 0, 0, 0, 0, now.Location())
+		end = start.AddDate(0, 0, 1)
+		id = "d" + start.Format("20060102")
+	}
+	return id, start, end
+}
+
+var (
+	// The counter expires at the end of the period, so we don't need to clean it up
+	redisScript = stringx.CutSpace(`
+	local res={}
+	for i=1,%d do
+		local cnt=redis.call('incr',KEYS[i])
+		if cnt==1 then
+			redis.call('expireat',KEYS[i],ARGV[i])
+		end
+		res[i]=cnt
+	end
+	return res
+	`)
+
+	redisSingleScript = stringx.CutSpace(`
+	local cnt=redis.call('incr',KEYS[1])
+	if cnt==1 then
+		redis.call('expireat',KEYS[1],ARGV[1])
+	end
+	return cnt
+	`)
+)
+
+func (f *filter) quotaErr(err error) api.ResultAction {
+	config := f.config
+	api.LogErrorf("failed to count quota: %v", err)
+
+	if config.FailureModeDeny {
+		status := 500
+		if config.StatusOnError != 0 {
+			status = int(config.StatusOnError)
+		}
+		return &api.LocalResponse{Code: status}
+	}
+	return api.Continue
+}
+
+func (f *filter) DecodeHeaders(headers api.RequestHeaderMap, endStream bool) api.ResultAction {
+	consumer := f.callbacks.GetConsumer()
+	if consumer == nil {
+		api.LogInfo("quota filter is skipped because the consumer is not found")
+		return api.Continue
+	}
+
+	ctx := context.Background()
+	config := f.config
+	now := time.Now().In(config.location)
+	n := len(config.Quotas)
+	keys := make([]string, n)
+	args := make([]interface{}, n)
+	usages := make([]*usage, n)
+	for i, q := range config.Quotas {
+		id, start, end := calendarPeriod(q.Period, now)
+		keys[i] = fmt.Sprintf("%s|%s|%s", config.prefix, consumer.Name(), id)
+		args[i] = end.Unix()
+		usages[i] = &usage{
+			quota:  q,
+			end:    end,
+			window: end.Unix() - start.Unix(),
+		}
+	}
+
+	if config.GetCluster() != nil {
+		// Like limitCountRedis, send the request one by one to avoid operation across
+		// multiple slots.
+		cmds, err := config.clusterClient.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+			for i, k := range keys {
+				pipe.Eval(ctx, redisSingleScript, []string{k}, args[i])
+			}
+			return nil
+		})
+		if err != nil {
+			return f.quotaErr(err)
+		}
+
+		for i, cmd := range cmds {
+			usages[i].used = cmd.(*redis.Cmd).Val().(int64)
+		}
+
+	} else {
+		cmd := config.client.Eval(ctx, fmt.Sprintf(redisScript, n), keys, args...)
+		res, err := cmd.Result()
+		if err != nil {
+			return f.quotaErr(err)
+		}
+
+		for i, cnt := range res.([]interface{}) {
+			usages[i].used = cnt.(int64)
+		}
+	}
+	f.usages = usages
+
+	for _, u := range usages {
+		api.LogInfof("quota filter, consumer: %s, period: %s, used: %d", consumer.Name(), u.quota.Period, u.used)
+
+		if u.used > int64(u.quota.Limit) {
+			hdr := http.Header{}
+			hdr.Set("x-envoy-ratelimited", "true")
+			status := 429
+			if config.RateLimitedStatus >= 400 {
+				status = int(config.RateLimitedStatus)
+			}
+			return &api.LocalResponse{Code: status, Header: hdr}
+		}
+	}
+
+	return api.Continue
+}
+
+func (f *filter) EncodeHeaders(headers api.ResponseHeaderMap, endStream bool) api.ResultAction {
+	if len(f.usages) == 0 {
+		// If the redis call is failed, we don't know the correct value of quota headers.
+		return api.Continue
+	}
+
+	var minUsage *usage
+	policies := make([]string, len(f.usages))
+	var warnings []string
+	for i, u := range f.usages {
+		if minUsage == nil || u.remaining() < minUsage.remaining() {
+			minUsage = u
+		}
+
+		name := periodName(u.quota.Period)
+		policies[i] = fmt.Sprintf("%d;w=%d;name=%q", u.quota.Limit, u.window, name)
+
+		if u.quota.SoftLimit > 0 && u.used > int64(u.quota.SoftLimit) && u.used <= int64(u.quota.Limit) {
+			warnings = append(warnings, fmt.Sprintf("%s;soft=%d", name, u.quota.SoftLimit))
+		}
+	}
+
+	reset := int64(time.Until(minUsage.end).Seconds())
+	if reset < 0 {
+		reset = 0
+	}
+	headers.Set("x-ratelimit-limit", strconv.FormatUint(uint64(minUsage.quota.Limit), 10))
+	headers.Set("x-ratelimit-remaining", strconv.FormatInt(minUsage.remaining(), 10))
+	headers.Set("x-ratelimit-reset", strconv.FormatInt(reset, 10))
+	headers.Set("ratelimit-policy", strings.Join(policies, ", "))
+	if len(warnings) > 0 {
+		headers.Set("x-quota-warning", strings.Join(warnings, ", "))
+	}
+	return api.Continue
+}
@@ -0,0 +1,107 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package quota
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mosn.io/htnn/api/plugins/tests/pkg/envoy"
+	"mosn.io/htnn/types/plugins/quota"
+)
+
+func TestCalendarPeriod(t *testing.T) {
+	loc, err := time.LoadLocation("Asia/Shanghai")
+	require.NoError(t, err)
+	// 2024-02-01 04:00 in Asia/Shanghai
+	now := time.Date(2024, 1, 31, 20, 0, 0, 0, time.UTC).In(loc)
+
+	tests := []struct {
+		name   string
+		period quota.Period
+		id     string
+		start  time.Time
+		end    time.Time
+	}{
+		{
+			name:   "day",
+			period: quota.Period_DAY,
+			id:     "d20240201",
+			start:  time.Date(2024, 2, 1, 0, 0, 0, 0, loc),
+			end:    time.Date(2024, 2, 2, 0, 0, 0, 0, loc),
+		},
+		{
+			name:   "month",
+			period: quota.Period_MONTH,
+			id:     "m202402",
+			start:  time.Date(2024, 2, 1, 0, 0, 0, 0, loc),
+			end:    time.Date(2024, 3, 1, 0, 0, 0, 0, loc),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, start, end := calendarPeriod(tt.period, now)
+			assert.Equal(t, tt.id, id)
+			assert.True(t, tt.start.Equal(start), start)
+			assert.True(t, tt.end.Equal(end), end)
+		})
+	}
+}
+
+func TestEncodeHeaders(t *testing.T) {
+	cb := envoy.NewFilterCallbackHandler()
+	f := factory(&config{}, cb).(*filter)
+	end := time.Now().Add(time.Hour)
+	f.usages = []*usage{
+		{
+			quota:  &quota.Quota{Period: quota.Period_DAY, Limit: 10, SoftLimit: 8},
+			used:   9,
+			end:    end,
+			window: 86400,
+		},
+		{
+			quota:  &quota.Quota{Period: quota.Period_MONTH, Limit: 100},
+			used:   20,
+			end:    end.Add(24 * time.Hour),
+			window: 2592000,
+		},
+	}
+
+	hdr := envoy.NewResponseHeaderMap(http.Header{})
+	f.EncodeHeaders(hdr, true)
+	v, _ := hdr.Get("x-ratelimit-limit")
+	assert.Equal(t, "10", v)
+	v, _ = hdr.Get("x-ratelimit-remaining")
+	assert.Equal(t, "1", v)
+	v, _ = hdr.Get("x-ratelimit-reset")
+	assert.Contains(t, []string{"3599", "3600"}, v)
+	v, _ = hdr.Get("ratelimit-policy")
+	assert.Equal(t, `10;w=86400;name="day", 100;w=2592000;name="month"`, v)
+	v, _ = hdr.Get("x-quota-warning")
+	assert.Equal(t, "day;soft=8", v)
+
+	f.usages[0].used = 11
+	hdr = envoy.NewResponseHeaderMap(http.Header{})
+	f.EncodeHeaders(hdr, true)
+	v, _ = hdr.Get("x-ratelimit-remaining")
+	assert.Equal(t, "0", v)
+	_, ok := hdr.Get("x-quota-warning")
+	assert.False(t, ok)
+}
@@ -0,0 +1,83 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request_id
+
+import (
+	"regexp"
+
+	"github.com/google/uuid"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/plugins"
+	"mosn.io/htnn/types/plugins/request_id"
+)
+
+const (
+	defaultHeader = "x-request-id"
+)
+
+var (
+	defaultPattern = regexp.MustCompile(`^[a-zA-Z0-9._-]{1,128}$`)
+)
+
+func init() {
+	plugins.RegisterHttpPlugin(request_id.Name, &plugin{})
+}
+
+type plugin struct {
+	request_id.Plugin
+}
+
+func (p *plugin) Factory() api.FilterFactory {
+	return factory
+}
+
+func (p *plugin) Config() api.PluginConfig {
+	return &config{}
+}
+
+type config struct {
+	request_id.CustomConfig
+
+	header         string
+	responseHeader string
+	pattern        *regexp.Regexp
+	generate       func() string
+}
+
+func (conf *config) Init(cb api.ConfigCallbackHandler) error {
+	conf.header = conf.Header
+	if conf.header == "" {
+		conf.header = defaultHeader
+	}
+	conf.responseHeader = conf.ResponseHeader
+	if conf.responseHeader == "" {
+		conf.responseHeader = conf.header
+	}
+
+	conf.pattern = defaultPattern
+	if conf.Pattern != "" {
+		// the pattern is checked in Validate
+		conf.pattern = regexp.MustCompile(conf.Pattern)
+	}
+
+	switch conf.Generator {
+	case request_id.Generator_ULID:
+		conf.generate = newULID
+	default:
+		conf.generate = uuid.NewString
+	}
+	return nil
+}
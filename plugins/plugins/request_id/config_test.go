@@ -0,0 +1,77 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request_id
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestConfig(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{
+			name:  "bad header",
+			input: `{"header":"x request id"}`,
+			err:   "invalid Config.Header",
+		},
+		{
+			name:  "bad response header",
+			input: `{"responseHeader":"x\nid"}`,
+			err:   "invalid Config.ResponseHeader",
+		},
+		{
+			name:  "bad pattern",
+			input: `{"pattern":"[a-z"}`,
+			err:   "bad pattern",
+		},
+		{
+			name:  "bad generator",
+			input: `{"generator":2}`,
+			err:   "invalid Config.Generator",
+		},
+		{
+			name:  "pass",
+			input: `{}`,
+		},
+		{
+			name:  "pass with all fields",
+			input: `{"header":"x-trace-id","pattern":"^[0-9A-Z]{26}$","generator":"ULID","responseHeader":"x-id"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := &config{}
+			err := protojson.Unmarshal([]byte(tt.input), conf)
+			if err == nil {
+				err = conf.Validate()
+			}
+			if tt.err == "" {
+				require.Nil(t, err)
+				err = conf.Init(nil)
+				assert.Nil(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
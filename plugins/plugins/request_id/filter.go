@@ -0,0 +1,85 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request_id
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"time"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+)
+
+func factory(c interface{}, callbacks api.FilterCallbackHandler) api.Filter {
+	return &filter{
+		callbacks: callbacks,
+		config:    c.(*config),
+	}
+}
+
+type filter struct {
+	api.PassThroughFilter
+
+	callbacks api.FilterCallbackHandler
+	config    *config
+
+	id string
+}
+
+// Crockford's Base32, which is used by ULID
+const ulidEncoding = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// newULID generates a ULID, which is composed of a 48 bits timestamp in milliseconds and
+// 80 bits randomness. See https://github.com/ulid/spec.
+func newULID() string {
+	var b [16]byte
+	binary.BigEndian.PutUint64(b[:8], uint64(time.Now().UnixMilli())<<16)
+	_, _ = rand.Read(b[6:])
+
+	// encode the 128 bits into 26 characters, 5 bits per character. The first character only
+	// takes 3 bits.
+	hi := binary.BigEndian.Uint64(b[:8])
+	lo := binary.BigEndian.Uint64(b[8:])
+	var s [26]byte
+	for i := 25; i >= 0; i-- {
+		s[i] = ulidEncoding[lo&0x1f]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+	return string(s[:])
+}
+
+func (f *filter) DecodeHeaders(headers api.RequestHeaderMap, endStream bool) api.ResultAction {
+	config := f.config
+	id, ok := headers.Get(config.header)
+	if !ok || !config.pattern.MatchString(id) {
+		id = config.generate()
+		headers.Set(config.header, id)
+	}
+	f.id = id
+
+	state := f.callbacks.PluginState()
+	state.Set(api.RequestIDStateNamespace, api.RequestIDStateKeyID, id)
+	state.Set(api.RequestIDStateNamespace, api.RequestIDStateKeyHeader, config.responseHeader)
+	return api.Continue
+}
+
+func (f *filter) EncodeHeaders(headers api.ResponseHeaderMap, endStream bool) api.ResultAction {
+	// DecodeHeaders is not run when the request is replied by the plugins before this plugin
+	if f.id != "" {
+		headers.Set(f.config.responseHeader, f.id)
+	}
+	return api.Continue
+}
@@ -0,0 +1,143 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request_id
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/plugins/tests/pkg/envoy"
+)
+
+var (
+	uuidPattern = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	ulidPattern = regexp.MustCompile(`^[0-7][0-9A-HJKMNP-TV-Z]{25}$`)
+)
+
+func newConfig(t *testing.T, input string) *config {
+	conf := &config{}
+	require.Nil(t, protojson.Unmarshal([]byte(input), conf))
+	require.Nil(t, conf.Validate())
+	require.Nil(t, conf.Init(nil))
+	return conf
+}
+
+func TestRequestID(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   string
+		header   http.Header
+		keep     bool
+		pattern  *regexp.Regexp
+		respName string
+	}{
+		{
+			name:     "generate",
+			config:   `{}`,
+			header:   http.Header{},
+			pattern:  uuidPattern,
+			respName: "x-request-id",
+		},
+		{
+			name:     "honour incoming request ID",
+			config:   `{}`,
+			header:   http.Header{"X-Request-Id": []string{"abc-123"}},
+			keep:     true,
+			respName: "x-request-id",
+		},
+		{
+			name:     "replace malformed request ID",
+			config:   `{}`,
+			header:   http.Header{"X-Request-Id": []string{"abc 123"}},
+			pattern:  uuidPattern,
+			respName: "x-request-id",
+		},
+		{
+			name:     "custom",
+			config:   `{"header":"x-trace-id","pattern":"^[0-9]+$","generator":"ULID","responseHeader":"x-id"}`,
+			header:   http.Header{"X-Trace-Id": []string{"abc-123"}},
+			pattern:  ulidPattern,
+			respName: "x-id",
+		},
+		{
+			name:     "custom, honour incoming request ID",
+			config:   `{"header":"x-trace-id","pattern":"^[0-9]+$","responseHeader":"x-id"}`,
+			header:   http.Header{"X-Trace-Id": []string{"123"}},
+			keep:     true,
+			respName: "x-id",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := newConfig(t, tt.config)
+			cb := envoy.NewFilterCallbackHandler()
+			f := factory(conf, cb)
+
+			incoming, _ := envoy.NewRequestHeaderMap(tt.header.Clone()).Get(conf.header)
+			hdr := envoy.NewRequestHeaderMap(tt.header)
+			assert.Equal(t, api.Continue, f.DecodeHeaders(hdr, true))
+			id, ok := hdr.Get(conf.header)
+			require.True(t, ok)
+			if tt.keep {
+				assert.Equal(t, incoming, id)
+			} else {
+				assert.Regexp(t, tt.pattern, id)
+			}
+
+			state := cb.PluginState()
+			assert.Equal(t, id, state.Get(api.RequestIDStateNamespace, api.RequestIDStateKeyID))
+			assert.Equal(t, tt.respName, state.Get(api.RequestIDStateNamespace, api.RequestIDStateKeyHeader))
+
+			respHdr := envoy.NewResponseHeaderMap(http.Header{})
+			assert.Equal(t, api.Continue, f.EncodeHeaders(respHdr, true))
+			v, _ := respHdr.Get(tt.respName)
+			assert.Equal(t, id, v)
+		})
+	}
+}
+
+func TestEncodeHeadersWithoutRequestID(t *testing.T) {
+	// the request is replied before this plugin runs
+	f := factory(newConfig(t, `{}`), envoy.NewFilterCallbackHandler()).(*filter)
+	respHdr := envoy.NewResponseHeaderMap(http.Header{})
+	assert.Equal(t, api.Continue, f.EncodeHeaders(respHdr, true))
+	_, ok := respHdr.Get("x-request-id")
+	assert.False(t, ok)
+}
+
+func TestNewULID(t *testing.T) {
+	start := time.Now().UnixMilli()
+	a := newULID()
+	b := newULID()
+	assert.Regexp(t, ulidPattern, a)
+	assert.NotEqual(t, a, b)
+
+	// the first 10 characters encode the timestamp in milliseconds
+	var ms int64
+	for _, c := range a[:10] {
+		ms = ms<<5 | int64(strings.IndexRune(ulidEncoding, c))
+	}
+	assert.GreaterOrEqual(t, ms, start)
+	assert.LessOrEqual(t, ms, time.Now().UnixMilli())
+}
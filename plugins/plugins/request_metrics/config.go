@@ -0,0 +1,77 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request_metrics
+
+import (
+	"github.com/google/cel-go/cel"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/plugins"
+	"mosn.io/htnn/types/pkg/expr"
+	"mosn.io/htnn/types/plugins/request_metrics"
+)
+
+const (
+	defaultCardinalityLimit = 1000
+)
+
+func init() {
+	plugins.RegisterHttpPlugin(request_metrics.Name, &plugin{})
+}
+
+type plugin struct {
+	request_metrics.Plugin
+}
+
+func (p *plugin) Factory() api.FilterFactory {
+	return factory
+}
+
+func (p *plugin) Config() api.PluginConfig {
+	return &config{}
+}
+
+type label struct {
+	name   string
+	script expr.Script
+}
+
+type config struct {
+	request_metrics.CustomConfig
+
+	labels           []*label
+	cardinalityLimit int
+	store            *store
+}
+
+func (conf *config) Init(cb api.ConfigCallbackHandler) error {
+	conf.labels = make([]*label, len(conf.Labels))
+	for i, l := range conf.Labels {
+		script, _ := expr.CompileCel(l.Expr, cel.StringType)
+		conf.labels[i] = &label{
+			name:   l.Name,
+			script: script,
+		}
+	}
+
+	conf.cardinalityLimit = int(conf.CardinalityLimit)
+	if conf.cardinalityLimit == 0 {
+		conf.cardinalityLimit = defaultCardinalityLimit
+	}
+
+	conf.store = defaultStore
+	serve()
+	return nil
+}
@@ -0,0 +1,93 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request_metrics
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func init() {
+	// avoid occupying the default port in the test
+	os.Setenv(EnvAddress, "127.0.0.1:0")
+}
+
+func TestConfig(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{
+			name:  "bad label name",
+			input: `{"labels":[{"name":"1tenant","expr":"request.header(\"x-tenant\")"}]}`,
+			err:   "invalid Label.Name",
+		},
+		{
+			name:  "expr required",
+			input: `{"labels":[{"name":"tenant"}]}`,
+			err:   "invalid Label.Expr",
+		},
+		{
+			name:  "reserved label",
+			input: `{"labels":[{"name":"route","expr":"request.path()"}]}`,
+			err:   "duplicate or reserved label name: route",
+		},
+		{
+			name:  "duplicate label",
+			input: `{"labels":[{"name":"tenant","expr":"request.path()"},{"name":"tenant","expr":"request.method()"}]}`,
+			err:   "duplicate or reserved label name: tenant",
+		},
+		{
+			name:  "bad expr",
+			input: `{"labels":[{"name":"tenant","expr":"request.header(\"x-tenant\") == \"a\""}]}`,
+			err:   "bad label tenant",
+		},
+		{
+			name:  "cardinality limit too large",
+			input: `{"cardinalityLimit":1000000}`,
+			err:   "invalid Config.CardinalityLimit",
+		},
+		{
+			name:  "pass",
+			input: `{}`,
+		},
+		{
+			name:  "pass with labels",
+			input: `{"labels":[{"name":"tenant","expr":"request.header(\"x-tenant\")"}],"cardinalityLimit":10}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := &config{}
+			err := protojson.Unmarshal([]byte(tt.input), conf)
+			if err == nil {
+				err = conf.Validate()
+			}
+			if tt.err == "" {
+				require.Nil(t, err)
+				err = conf.Init(nil)
+				assert.Nil(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request_metrics
+
+import (
+	"strconv"
+	"time"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+)
+
+func factory(c interface{}, callbacks api.FilterCallbackHandler) api.Filter {
+	return &filter{
+		callbacks: callbacks,
+		config:    c.(*config),
+	}
+}
+
+type filter struct {
+	api.PassThroughFilter
+
+	callbacks api.FilterCallbackHandler
+	config    *config
+}
+
+func (f *filter) numberProperty(name string) float64 {
+	v, err := f.callbacks.GetProperty(name)
+	if err != nil || v == "" {
+		return 0
+	}
+	n, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return 0
+	}
+	return n
+}
+
+func (f *filter) duration() float64 {
+	v, err := f.callbacks.GetProperty("request.duration")
+	if err != nil || v == "" {
+		return 0
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return 0
+	}
+	return d.Seconds()
+}
+
+func (f *filter) OnLog(reqHeaders api.RequestHeaderMap, reqTrailers api.RequestTrailerMap,
+	respHeaders api.ResponseHeaderMap, respTrailers api.ResponseTrailerMap) {
+
+	info := f.callbacks.StreamInfo()
+	r := &record{
+		route:         info.GetRouteName(),
+		code:          "0",
+		duration:      f.duration(),
+		requestBytes:  f.numberProperty("request.total_size"),
+		responseBytes: f.numberProperty("response.total_size"),
+	}
+	if c := f.callbacks.GetConsumer(); c != nil {
+		r.consumer = c.Name()
+	}
+	if code, ok := info.ResponseCode(); ok {
+		r.code = strconv.Itoa(int(code))
+	}
+
+	if len(f.config.labels) > 0 {
+		r.labels = make(map[string]string, len(f.config.labels))
+		for _, l := range f.config.labels {
+			res, err := l.script.EvalWithRequest(f.callbacks, reqHeaders)
+			if err != nil {
+				api.LogErrorf("failed to eval label %s: %v", l.name, err)
+				r.labels[l.name] = ""
+				continue
+			}
+			r.labels[l.name] = res.(string)
+		}
+	}
+
+	f.config.store.limit(r, f.config.cardinalityLimit)
+	f.config.store.record(r)
+}
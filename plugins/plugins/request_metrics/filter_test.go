@@ -0,0 +1,191 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request_metrics
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/plugins/tests/pkg/envoy"
+)
+
+type consumer struct {
+	name string
+}
+
+func (c *consumer) Name() string {
+	return c.name
+}
+
+func (c *consumer) PluginConfig(name string) api.PluginConsumerConfig {
+	return nil
+}
+
+type streamInfo struct {
+	envoy.StreamInfo
+
+	route string
+	code  uint32
+}
+
+func (i *streamInfo) GetRouteName() string {
+	return i.route
+}
+
+func (i *streamInfo) ResponseCode() (uint32, bool) {
+	return i.code, i.code != 0
+}
+
+type callbacks struct {
+	api.FilterCallbackHandler
+}
+
+func (cb *callbacks) GetProperty(key string) (string, error) {
+	switch key {
+	case "request.duration":
+		return "0.3s", nil
+	case "request.total_size":
+		return "100", nil
+	case "response.total_size":
+		return "2048", nil
+	}
+	return "", errors.New("value not found")
+}
+
+func newConfig(t *testing.T, input string) *config {
+	conf := &config{}
+	require.Nil(t, protojson.Unmarshal([]byte(input), conf))
+	require.Nil(t, conf.Validate())
+	require.Nil(t, conf.Init(nil))
+	conf.store = newStore()
+	return conf
+}
+
+func newCallbacks(route string, code uint32, consumerName string) api.FilterCallbackHandler {
+	cb := envoy.NewFilterCallbackHandler()
+	cb.SetStreamInfo(&streamInfo{route: route, code: code})
+	if consumerName != "" {
+		cb.SetConsumer(&consumer{name: consumerName})
+	}
+	return &callbacks{cb}
+}
+
+func scrape(t *testing.T, s *store) string {
+	srv := httptest.NewServer(s.handler())
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	b, err := io.ReadAll(resp.Body)
+	require.Nil(t, err)
+	return string(b)
+}
+
+func TestOnLog(t *testing.T) {
+	conf := newConfig(t, `{}`)
+	hdr := envoy.NewRequestHeaderMap(http.Header{})
+
+	f := factory(conf, newCallbacks("default/a", 200, "marvin"))
+	f.OnLog(hdr, nil, nil, nil)
+	f.OnLog(hdr, nil, nil, nil)
+	f = factory(conf, newCallbacks("default/a", 0, ""))
+	f.OnLog(hdr, nil, nil, nil)
+
+	out := scrape(t, conf.store)
+	assert.Contains(t, out, `htnn_requests_total{code="200",consumer="marvin",route="default/a"} 2`)
+	assert.Contains(t, out, `htnn_requests_total{code="0",consumer="",route="default/a"} 1`)
+	assert.Contains(t, out, `htnn_request_duration_seconds_bucket{consumer="marvin",route="default/a",le="0.25"} 0`)
+	assert.Contains(t, out, `htnn_request_duration_seconds_bucket{consumer="marvin",route="default/a",le="0.5"} 2`)
+	assert.Contains(t, out, `htnn_request_duration_seconds_sum{consumer="marvin",route="default/a"} 0.6`)
+	assert.Contains(t, out, `htnn_request_duration_seconds_count{consumer="marvin",route="default/a"} 2`)
+	assert.Contains(t, out, `htnn_request_bytes_total{consumer="marvin",route="default/a"} 200`)
+	assert.Contains(t, out, `htnn_response_bytes_total{consumer="marvin",route="default/a"} 4096`)
+}
+
+func TestExtraLabels(t *testing.T) {
+	conf := newConfig(t, `{"labels":[{"name":"tenant","expr":"request.header(\"x-tenant\")"}]}`)
+	s := conf.store
+
+	f := factory(conf, newCallbacks("default/a", 200, "marvin"))
+	f.OnLog(envoy.NewRequestHeaderMap(http.Header{"X-Tenant": []string{"t1"}}), nil, nil, nil)
+
+	// the routes without the extra labels share the same metric family
+	other := newConfig(t, `{}`)
+	other.store = s
+	f = factory(other, newCallbacks("default/b", 503, "marvin"))
+	f.OnLog(envoy.NewRequestHeaderMap(http.Header{}), nil, nil, nil)
+
+	out := scrape(t, s)
+	assert.Contains(t, out, `htnn_requests_total{code="200",consumer="marvin",route="default/a",tenant="t1"} 1`)
+	assert.Contains(t, out, `htnn_requests_total{code="503",consumer="marvin",route="default/b",tenant=""} 1`)
+}
+
+func TestCardinalityLimit(t *testing.T) {
+	conf := newConfig(t, `{"labels":[{"name":"tenant","expr":"request.header(\"x-tenant\")"}],"cardinalityLimit":2}`)
+
+	for _, c := range []struct {
+		consumer string
+		tenant   string
+	}{
+		{"marvin", "t1"},
+		{"marvin", "t2"},
+		{"marvin", "t3"},
+		{"zaphod", "t1"},
+		// the recorded combinations are still counted separately
+		{"marvin", "t1"},
+	} {
+		f := factory(conf, newCallbacks("default/a", 200, c.consumer))
+		f.OnLog(envoy.NewRequestHeaderMap(http.Header{"X-Tenant": []string{c.tenant}}), nil, nil, nil)
+	}
+	// the limit is per route
+	f := factory(conf, newCallbacks("default/b", 200, "zaphod"))
+	f.OnLog(envoy.NewRequestHeaderMap(http.Header{"X-Tenant": []string{"t1"}}), nil, nil, nil)
+
+	out := scrape(t, conf.store)
+	assert.Contains(t, out, `htnn_requests_total{code="200",consumer="marvin",route="default/a",tenant="t1"} 2`)
+	assert.Contains(t, out, `htnn_requests_total{code="200",consumer="marvin",route="default/a",tenant="t2"} 1`)
+	assert.Contains(t, out, `htnn_requests_total{code="200",consumer="other",route="default/a",tenant="other"} 2`)
+	assert.Contains(t, out, `htnn_requests_total{code="200",consumer="zaphod",route="default/b",tenant="t1"} 1`)
+}
+
+func TestLabelsChanged(t *testing.T) {
+	conf := newConfig(t, `{}`)
+	s := conf.store
+	f := factory(conf, newCallbacks("default/a", 200, "marvin"))
+	f.OnLog(envoy.NewRequestHeaderMap(http.Header{}), nil, nil, nil)
+
+	// a label is added to the route
+	conf = newConfig(t, `{"labels":[{"name":"tenant","expr":"request.header(\"x-tenant\")"}]}`)
+	conf.store = s
+	f = factory(conf, newCallbacks("default/a", 200, "marvin"))
+	f.OnLog(envoy.NewRequestHeaderMap(http.Header{}), nil, nil, nil)
+	f.OnLog(envoy.NewRequestHeaderMap(http.Header{"X-Tenant": []string{"t1"}}), nil, nil, nil)
+
+	// the series recorded before the change are merged into the ones without the label
+	out := scrape(t, s)
+	assert.Contains(t, out, `htnn_requests_total{code="200",consumer="marvin",route="default/a",tenant=""} 2`)
+	assert.Contains(t, out, `htnn_requests_total{code="200",consumer="marvin",route="default/a",tenant="t1"} 1`)
+	assert.Contains(t, out, `htnn_request_duration_seconds_count{consumer="marvin",route="default/a",tenant=""} 2`)
+	assert.Contains(t, out, `htnn_request_bytes_total{consumer="marvin",route="default/a",tenant=""} 200`)
+}
@@ -0,0 +1,291 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request_metrics
+
+import (
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+)
+
+const (
+	// EnvAddress is the environment variable to set the address of the metrics server
+	EnvAddress     = "HTNN_REQUEST_METRICS_ADDRESS"
+	defaultAddress = ":9464"
+	metricsPath    = "/metrics"
+
+	otherValue = "other"
+)
+
+var (
+	durationBuckets = prometheus.DefBuckets
+
+	defaultStore = newStore()
+	serveOnce    sync.Once
+)
+
+type series struct {
+	labels map[string]string
+
+	value float64
+	// for histogram
+	count   uint64
+	buckets []uint64
+}
+
+type family struct {
+	name      string
+	help      string
+	histogram bool
+
+	lock   sync.Mutex
+	series map[string]*series
+}
+
+func newFamily(name, help string, histogram bool) *family {
+	return &family{
+		name:      name,
+		help:      help,
+		histogram: histogram,
+		series:    map[string]*series{},
+	}
+}
+
+func seriesKey(labels map[string]string) string {
+	pairs := make([]string, 0, len(labels))
+	for k, v := range labels {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, "\xff")
+}
+
+func (f *family) observe(labels map[string]string, v float64) {
+	key := seriesKey(labels)
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	s, ok := f.series[key]
+	if !ok {
+		copied := make(map[string]string, len(labels))
+		for k, v := range labels {
+			copied[k] = v
+		}
+		s = &series{labels: copied}
+		if f.histogram {
+			s.buckets = make([]uint64, len(durationBuckets))
+		}
+		f.series[key] = s
+	}
+
+	s.value += v
+	if f.histogram {
+		s.count++
+		for i, upper := range durationBuckets {
+			if v <= upper {
+				s.buckets[i]++
+			}
+		}
+	}
+}
+
+func (f *family) collect(ch chan<- prometheus.Metric) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if len(f.series) == 0 {
+		return
+	}
+
+	// The routes may have different extra labels. As all the metrics in the same family should
+	// have the same label names, the missing labels are filled with empty value, which is the same
+	// as not having the label in Prometheus.
+	names := []string{}
+	seen := map[string]struct{}{}
+	for _, s := range f.series {
+		for k := range s.labels {
+			if _, ok := seen[k]; !ok {
+				seen[k] = struct{}{}
+				names = append(names, k)
+			}
+		}
+	}
+	sort.Strings(names)
+	desc := prometheus.NewDesc(f.name, f.help, names, nil)
+
+	// When the extra labels of a route are changed, the series recorded before the change may
+	// have the same label values as the new ones after filling. Merge them, otherwise the
+	// duplicate series fail the whole scrape.
+	type mergedSeries struct {
+		series
+		values []string
+	}
+	merged := make(map[string]*mergedSeries, len(f.series))
+	order := make([]*mergedSeries, 0, len(f.series))
+	for _, s := range f.series {
+		values := make([]string, len(names))
+		for i, name := range names {
+			values[i] = s.labels[name]
+		}
+		key := strings.Join(values, "\xff")
+
+		m, ok := merged[key]
+		if !ok {
+			m = &mergedSeries{values: values}
+			if f.histogram {
+				m.buckets = make([]uint64, len(durationBuckets))
+			}
+			merged[key] = m
+			order = append(order, m)
+		}
+		m.value += s.value
+		m.count += s.count
+		for i, n := range s.buckets {
+			m.buckets[i] += n
+		}
+	}
+
+	for _, s := range order {
+		if f.histogram {
+			buckets := make(map[float64]uint64, len(durationBuckets))
+			for i, upper := range durationBuckets {
+				buckets[upper] = s.buckets[i]
+			}
+			ch <- prometheus.MustNewConstHistogram(desc, s.count, s.value, buckets, s.values...)
+		} else {
+			ch <- prometheus.MustNewConstMetric(desc, prometheus.CounterValue, s.value, s.values...)
+		}
+	}
+}
+
+type store struct {
+	requests      *family
+	duration      *family
+	requestBytes  *family
+	responseBytes *family
+
+	lock sync.Mutex
+	// the combinations of the consumer and the extra labels recorded in each route
+	dimensions map[string]map[string]struct{}
+}
+
+func newStore() *store {
+	return &store{
+		requests:      newFamily("htnn_requests_total", "The number of the requests.", false),
+		duration:      newFamily("htnn_request_duration_seconds", "The duration of the requests in seconds.", true),
+		requestBytes:  newFamily("htnn_request_bytes_total", "The size of the requests in bytes, including the headers.", false),
+		responseBytes: newFamily("htnn_response_bytes_total", "The size of the responses in bytes, including the headers.", false),
+		dimensions:    map[string]map[string]struct{}{},
+	}
+}
+
+// Describe sends nothing, so the store is an unchecked collector and the label names can vary
+func (s *store) Describe(ch chan<- *prometheus.Desc) {
+}
+
+func (s *store) Collect(ch chan<- prometheus.Metric) {
+	s.requests.collect(ch)
+	s.duration.collect(ch)
+	s.requestBytes.collect(ch)
+	s.responseBytes.collect(ch)
+}
+
+type record struct {
+	route    string
+	consumer string
+	code     string
+	labels   map[string]string
+
+	duration      float64
+	requestBytes  float64
+	responseBytes float64
+}
+
+// limit replaces the consumer and the extra labels with `other` when there are too many
+// combinations in the route
+func (s *store) limit(r *record, limit int) {
+	key := r.consumer
+	if len(r.labels) > 0 {
+		key += "\xff" + seriesKey(r.labels)
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	dims, ok := s.dimensions[r.route]
+	if !ok {
+		dims = map[string]struct{}{}
+		s.dimensions[r.route] = dims
+	}
+	if _, ok := dims[key]; ok {
+		return
+	}
+	if len(dims) < limit {
+		dims[key] = struct{}{}
+		return
+	}
+
+	r.consumer = otherValue
+	for k := range r.labels {
+		r.labels[k] = otherValue
+	}
+}
+
+func (s *store) record(r *record) {
+	labels := make(map[string]string, len(r.labels)+3)
+	for k, v := range r.labels {
+		labels[k] = v
+	}
+	labels["route"] = r.route
+	labels["consumer"] = r.consumer
+
+	s.duration.observe(labels, r.duration)
+	s.requestBytes.observe(labels, r.requestBytes)
+	s.responseBytes.observe(labels, r.responseBytes)
+
+	labels["code"] = r.code
+	s.requests.observe(labels, 1)
+}
+
+func (s *store) handler() http.Handler {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(s)
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
+
+func serve() {
+	serveOnce.Do(func() {
+		addr := os.Getenv(EnvAddress)
+		if addr == "" {
+			addr = defaultAddress
+		}
+
+		mux := http.NewServeMux()
+		mux.Handle(metricsPath, defaultStore.handler())
+		go func() {
+			api.LogInfof("requestMetrics: serve metrics on %s%s", addr, metricsPath)
+			err := http.ListenAndServe(addr, mux)
+			api.LogErrorf("requestMetrics: failed to serve metrics: %v", err)
+		}()
+	})
+}
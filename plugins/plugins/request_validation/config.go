@@ -0,0 +1,199 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request_validation
+
+import (
+	"net/http"
+	"os"
+	"sync"
+	"sync/atomic"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/plugins"
+	"mosn.io/htnn/plugins/pkg/file"
+	"mosn.io/htnn/types/plugins/request_validation"
+)
+
+func init() {
+	plugins.RegisterHttpPlugin(request_validation.Name, &plugin{})
+}
+
+type plugin struct {
+	request_validation.Plugin
+}
+
+func (p *plugin) Factory() api.FilterFactory {
+	return factory
+}
+
+func (p *plugin) Config() api.PluginConfig {
+	return &config{}
+}
+
+type config struct {
+	request_validation.CustomConfig
+
+	lock *sync.RWMutex
+
+	// router is used when the OpenAPI document is configured
+	router   routers.Router
+	docFile  *file.File
+	updating atomic.Bool
+
+	// route is used when the JSON Schemas are configured
+	route *routers.Route
+}
+
+func newRouter(doc *openapi3.T) (routers.Router, error) {
+	// The servers in the document describe where the upstream is deployed. They are unrelated
+	// to the requests received by the gateway, so we match the request path only.
+	doc.Servers = nil
+	for _, item := range doc.Paths.Map() {
+		item.Servers = nil
+	}
+	return gorillamux.NewRouter(doc)
+}
+
+func loadRouter(path string) (routers.Router, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := request_validation.LoadOpenAPI(data)
+	if err != nil {
+		return nil, err
+	}
+	return newRouter(doc)
+}
+
+func schemaToParameters(s string, in string) ([]*openapi3.ParameterRef, error) {
+	schema, err := request_validation.ParseSchema(s)
+	if err != nil {
+		return nil, err
+	}
+
+	required := make(map[string]bool, len(schema.Required))
+	for _, name := range schema.Required {
+		required[name] = true
+	}
+	params := make([]*openapi3.ParameterRef, 0, len(schema.Properties))
+	for name, prop := range schema.Properties {
+		params = append(params, &openapi3.ParameterRef{
+			Value: &openapi3.Parameter{
+				Name:     name,
+				In:       in,
+				Required: required[name],
+				Schema:   prop,
+			},
+		})
+	}
+	return params, nil
+}
+
+// newRoute builds a route which matches all the requests from the JSON Schemas
+func newRoute(js *request_validation.JSONSchema) (*routers.Route, error) {
+	op := openapi3.NewOperation()
+	op.Responses = openapi3.NewResponses()
+	if js.Query != "" {
+		params, err := schemaToParameters(js.Query, openapi3.ParameterInQuery)
+		if err != nil {
+			return nil, err
+		}
+		op.Parameters = append(op.Parameters, params...)
+	}
+	if js.Headers != "" {
+		params, err := schemaToParameters(js.Headers, openapi3.ParameterInHeader)
+		if err != nil {
+			return nil, err
+		}
+		op.Parameters = append(op.Parameters, params...)
+	}
+	if js.Body != "" {
+		schema, err := request_validation.ParseSchema(js.Body)
+		if err != nil {
+			return nil, err
+		}
+		op.RequestBody = &openapi3.RequestBodyRef{
+			Value: openapi3.NewRequestBody().WithJSONSchema(schema),
+		}
+	}
+	if js.ResponseBody != "" {
+		schema, err := request_validation.ParseSchema(js.ResponseBody)
+		if err != nil {
+			return nil, err
+		}
+		op.Responses = openapi3.NewResponses(
+			openapi3.WithName("default", openapi3.NewResponse().WithJSONSchema(schema)),
+		)
+	}
+
+	pathItem := &openapi3.PathItem{}
+	return &routers.Route{
+		Spec: &openapi3.T{
+			OpenAPI: "3.0.3",
+			Paths:   openapi3.NewPaths(openapi3.WithPath("/", pathItem)),
+		},
+		Path:      "/",
+		PathItem:  pathItem,
+		Operation: op,
+	}, nil
+}
+
+func (conf *config) Init(cb api.ConfigCallbackHandler) error {
+	conf.lock = &sync.RWMutex{}
+
+	if js := conf.GetJsonSchema(); js != nil {
+		route, err := newRoute(js)
+		if err != nil {
+			return err
+		}
+		conf.route = route
+		return nil
+	}
+
+	oa := conf.GetOpenapi()
+	if oa.GetInline() != "" {
+		doc, err := request_validation.LoadOpenAPI([]byte(oa.GetInline()))
+		if err != nil {
+			return err
+		}
+		conf.router, err = newRouter(doc)
+		return err
+	}
+
+	f, err := file.Stat(oa.GetPath())
+	if err != nil {
+		return err
+	}
+	conf.docFile = f
+
+	conf.router, err = loadRouter(oa.GetPath())
+	return err
+}
+
+func (conf *config) findRoute(req *http.Request) (*routers.Route, map[string]string, error) {
+	if conf.route != nil {
+		return conf.route, nil, nil
+	}
+
+	conf.lock.RLock()
+	router := conf.router
+	conf.lock.RUnlock()
+	return router.FindRoute(req)
+}
@@ -0,0 +1,92 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request_validation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestConfig(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{
+			name:  "source required",
+			input: `{}`,
+			err:   "invalid Config.Source",
+		},
+		{
+			name:  "invalid document",
+			input: `{"openapi":{"inline":"openapi: 3.0.3\npaths: {}"}}`,
+			err:   "invalid OpenAPI document",
+		},
+		{
+			name:  "malformed document",
+			input: `{"openapi":{"inline":"{"}}`,
+			err:   "failed to load OpenAPI document",
+		},
+		{
+			name:  "document not found",
+			input: `{"openapi":{"path":"./testdata/nonexistent.yaml"}}`,
+			err:   "no such file or directory",
+		},
+		{
+			name:  "document from file",
+			input: `{"openapi":{"path":"./testdata/petstore.yaml"}}`,
+		},
+		{
+			name:  "schema required",
+			input: `{"jsonSchema":{}}`,
+			err:   "at least one schema is required",
+		},
+		{
+			name:  "invalid schema",
+			input: `{"jsonSchema":{"body":"{\"type\":\"unknown\"}"}}`,
+			err:   "invalid body schema",
+		},
+		{
+			name:  "query schema should be an object",
+			input: `{"jsonSchema":{"query":"{\"type\":\"string\"}"}}`,
+			err:   "query schema should be an object",
+		},
+		{
+			name:  "json schema",
+			input: `{"jsonSchema":{"body":"{\"type\":\"object\"}","headers":"{\"type\":\"object\",\"properties\":{\"x-id\":{\"type\":\"string\"}}}"}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := &config{}
+			err := protojson.Unmarshal([]byte(tt.input), conf)
+			if err == nil {
+				err = conf.Validate()
+			}
+			if err == nil {
+				err = conf.Init(nil)
+			}
+			if tt.err == "" {
+				assert.Nil(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
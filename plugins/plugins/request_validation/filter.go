@@ -0,0 +1,302 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request_validation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/plugins/pkg/file"
+)
+
+var (
+	validationOptions = &openapi3filter.Options{
+		MultiError: true,
+		// the body is validated separately after it is received
+		ExcludeRequestBody: true,
+		// we only validate the request, not modify it
+		SkipSettingDefaults: true,
+		// authentication is done by other plugins
+		AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+	}
+)
+
+func factory(c interface{}, callbacks api.FilterCallbackHandler) api.Filter {
+	return &filter{
+		callbacks: callbacks,
+		config:    c.(*config),
+	}
+}
+
+type filter struct {
+	api.PassThroughFilter
+
+	callbacks api.FilterCallbackHandler
+	config    *config
+
+	input *openapi3filter.RequestValidationInput
+
+	status    int
+	rspHeader http.Header
+}
+
+type violation struct {
+	// In is where the violation is found, like "query", "header", "path" or "body"
+	In      string `json:"in"`
+	Name    string `json:"name,omitempty"`
+	Path    string `json:"path,omitempty"`
+	Message string `json:"message"`
+}
+
+func schemaViolations(err error, v violation) ([]violation, bool) {
+	switch e := err.(type) {
+	case openapi3.MultiError:
+		var violations []violation
+		for _, inner := range e {
+			vs, ok := schemaViolations(inner, v)
+			if !ok {
+				return nil, false
+			}
+			violations = append(violations, vs...)
+		}
+		return violations, true
+	case *openapi3.SchemaError:
+		ptr := e.JSONPointer()
+		if len(ptr) > 0 {
+			v.Path = "/" + strings.Join(ptr, "/")
+		}
+		v.Message = e.Reason
+		return []violation{v}, true
+	}
+	return nil, false
+}
+
+func errorViolations(v violation, reason string, err error) []violation {
+	if err != nil {
+		if vs, ok := schemaViolations(err, v); ok {
+			return vs
+		}
+
+		if reason == "" || reason == err.Error() {
+			reason = err.Error()
+		} else {
+			reason += ": " + err.Error()
+		}
+	}
+	v.Message = reason
+	return []violation{v}
+}
+
+func toViolations(err error) []violation {
+	switch e := err.(type) {
+	case openapi3.MultiError:
+		var violations []violation
+		for _, inner := range e {
+			violations = append(violations, toViolations(inner)...)
+		}
+		return violations
+	case *openapi3filter.RequestError:
+		v := violation{In: "body"}
+		if e.Parameter != nil {
+			v.In = e.Parameter.In
+			v.Name = e.Parameter.Name
+		}
+		return errorViolations(v, e.Reason, e.Err)
+	case *openapi3filter.ResponseError:
+		return errorViolations(violation{In: "response"}, e.Reason, e.Err)
+	}
+	return []violation{{Message: err.Error()}}
+}
+
+func (f *filter) reject(code int, violations []violation) api.ResultAction {
+	body, _ := json.Marshal(map[string]interface{}{
+		"message":    "request validation failed",
+		"violations": violations,
+	})
+	return &api.LocalResponse{
+		Code:   code,
+		Msg:    string(body),
+		Header: http.Header{"Content-Type": []string{"application/json"}},
+	}
+}
+
+func (f *filter) reloadIfChanged() {
+	conf := f.config
+	if conf.docFile == nil || !file.IsChanged(conf.docFile) || conf.updating.Load() {
+		return
+	}
+
+	conf.updating.Store(true)
+	api.LogWarnf("OpenAPI document %s changed, reload it", conf.docFile.Name)
+
+	go func() {
+		defer conf.updating.Store(false)
+		defer f.callbacks.RecoverPanic()
+
+		router, err := loadRouter(conf.docFile.Name)
+		if err != nil {
+			api.LogErrorf("failed to reload OpenAPI document: %v", err)
+			// next request will retry
+			return
+		}
+
+		conf.lock.Lock()
+		conf.router = router
+		conf.lock.Unlock()
+
+		file.Update(conf.docFile)
+		api.LogWarnf("OpenAPI document %s changed, reloaded", conf.docFile.Name)
+	}()
+}
+
+func toHTTPHeader(headers api.HeaderMap) http.Header {
+	hdr := http.Header{}
+	headers.Range(func(k, v string) bool {
+		if k[0] != ':' {
+			hdr.Add(k, v)
+		}
+		return true
+	})
+	return hdr
+}
+
+func (f *filter) DecodeHeaders(headers api.RequestHeaderMap, endStream bool) api.ResultAction {
+	f.reloadIfChanged()
+
+	req := &http.Request{
+		Method: headers.Method(),
+		URL:    headers.Url(),
+		Host:   headers.Host(),
+		Header: toHTTPHeader(headers),
+		Body:   http.NoBody,
+	}
+	route, pathParams, err := f.config.findRoute(req)
+	if err != nil {
+		if !f.config.GetOpenapi().GetRejectUnknownOperation() {
+			return api.Continue
+		}
+
+		code := http.StatusNotFound
+		if errors.Is(err, routers.ErrMethodNotAllowed) {
+			code = http.StatusMethodNotAllowed
+		}
+		return f.reject(code, []violation{{In: "path", Message: err.Error()}})
+	}
+
+	input := &openapi3filter.RequestValidationInput{
+		Request:    req,
+		PathParams: pathParams,
+		Route:      route,
+		Options:    validationOptions,
+	}
+	err = openapi3filter.ValidateRequest(context.Background(), input)
+	if err != nil {
+		return f.reject(http.StatusBadRequest, toViolations(err))
+	}
+	f.input = input
+
+	if route.Operation.RequestBody != nil {
+		if !endStream {
+			return api.WaitAllData
+		}
+		return f.validateRequestBody(nil)
+	}
+	return api.Continue
+}
+
+func (f *filter) validateRequestBody(data []byte) api.ResultAction {
+	req := f.input.Request
+	if len(data) > 0 {
+		req.Body = io.NopCloser(bytes.NewReader(data))
+	}
+
+	err := openapi3filter.ValidateRequestBody(context.Background(), f.input, f.input.Route.Operation.RequestBody.Value)
+	if err != nil {
+		return f.reject(http.StatusBadRequest, toViolations(err))
+	}
+	return api.Continue
+}
+
+func (f *filter) DecodeRequest(headers api.RequestHeaderMap, buf api.BufferInstance, trailers api.RequestTrailerMap) api.ResultAction {
+	if f.input == nil {
+		return api.Continue
+	}
+
+	var data []byte
+	if buf != nil {
+		data = buf.Bytes()
+	}
+	return f.validateRequestBody(data)
+}
+
+func (f *filter) EncodeHeaders(headers api.ResponseHeaderMap, endStream bool) api.ResultAction {
+	if !f.config.ValidateResponse || f.input == nil {
+		return api.Continue
+	}
+
+	if enc, ok := headers.Get("content-encoding"); ok && enc != "identity" {
+		api.LogDebugf("requestValidation: skip validating the response encoded with %s", enc)
+		return api.Continue
+	}
+
+	f.status, _ = headers.Status()
+	f.rspHeader = toHTTPHeader(headers)
+	if !endStream {
+		return api.WaitAllData
+	}
+	f.validateResponse(nil)
+	return api.Continue
+}
+
+func (f *filter) validateResponse(data []byte) {
+	input := &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: f.input,
+		Status:                 f.status,
+		Header:                 f.rspHeader,
+		Options:                validationOptions,
+	}
+	input.SetBodyBytes(data)
+
+	err := openapi3filter.ValidateResponse(context.Background(), input)
+	if err != nil {
+		violations, _ := json.Marshal(toViolations(err))
+		req := f.input.Request
+		api.LogWarnf("requestValidation: response of %s %s doesn't match the schema, violations: %s",
+			req.Method, req.URL.Path, violations)
+	}
+}
+
+func (f *filter) EncodeResponse(headers api.ResponseHeaderMap, buf api.BufferInstance, trailers api.ResponseTrailerMap) api.ResultAction {
+	if f.rspHeader == nil {
+		return api.Continue
+	}
+
+	var data []byte
+	if buf != nil {
+		data = buf.Bytes()
+	}
+	f.validateResponse(data)
+	return api.Continue
+}
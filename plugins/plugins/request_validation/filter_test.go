@@ -0,0 +1,330 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request_validation
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/plugins/tests/pkg/envoy"
+	"mosn.io/htnn/plugins/pkg/file"
+)
+
+func newConfig(t *testing.T, input string) *config {
+	conf := &config{}
+	require.Nil(t, protojson.Unmarshal([]byte(input), conf))
+	require.Nil(t, conf.Validate())
+	require.Nil(t, conf.Init(nil))
+	return conf
+}
+
+type rejection struct {
+	Message    string      `json:"message"`
+	Violations []violation `json:"violations"`
+}
+
+func assertRejected(t *testing.T, res api.ResultAction, code int, violations []violation) {
+	resp, ok := res.(*api.LocalResponse)
+	require.True(t, ok, "result: %v", res)
+	assert.Equal(t, code, resp.Code)
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+	var body rejection
+	require.Nil(t, json.Unmarshal([]byte(resp.Msg), &body))
+	assert.Equal(t, "request validation failed", body.Message)
+	assert.ElementsMatch(t, violations, body.Violations)
+}
+
+func TestOpenAPI(t *testing.T) {
+	conf := newConfig(t, `{"openapi":{"path":"./testdata/petstore.yaml"}}`)
+
+	tests := []struct {
+		name       string
+		header     http.Header
+		body       string
+		code       int
+		violations []violation
+	}{
+		{
+			name:   "pass",
+			header: http.Header{":path": []string{"/pets?limit=10"}},
+		},
+		{
+			name:   "unknown operation",
+			header: http.Header{":path": []string{"/users"}},
+		},
+		{
+			name:   "invalid query",
+			header: http.Header{":path": []string{"/pets?limit=1000"}},
+			code:   400,
+			violations: []violation{
+				{In: "query", Name: "limit", Message: "number must be at most 100"},
+			},
+		},
+		{
+			name:   "malformed query",
+			header: http.Header{":path": []string{"/pets?limit=abc"}},
+			code:   400,
+		},
+		{
+			name:   "invalid path param",
+			header: http.Header{":path": []string{"/pets/abc"}},
+			code:   400,
+		},
+		{
+			name: "pass with body",
+			header: http.Header{
+				":path":        []string{"/pets"},
+				":method":      []string{"POST"},
+				"X-Request-Id": []string{"1"},
+				"Content-Type": []string{"application/json"},
+			},
+			body: `{"name":"kitty","age":1}`,
+		},
+		{
+			name: "invalid body",
+			header: http.Header{
+				":path":        []string{"/pets"},
+				":method":      []string{"POST"},
+				"Content-Type": []string{"application/json"},
+			},
+			body: `{"name":"","age":-1}`,
+			code: 400,
+			violations: []violation{
+				{In: "header", Name: "x-request-id", Message: "value is required but missing"},
+			},
+		},
+		{
+			name: "invalid body fields",
+			header: http.Header{
+				":path":        []string{"/pets"},
+				":method":      []string{"POST"},
+				"X-Request-Id": []string{"1"},
+				"Content-Type": []string{"application/json"},
+			},
+			body: `{"name":"","age":-1}`,
+			code: 400,
+			violations: []violation{
+				{In: "body", Path: "/name", Message: "minimum string length is 1"},
+				{In: "body", Path: "/age", Message: "number must be at least 0"},
+			},
+		},
+		{
+			name: "body required",
+			header: http.Header{
+				":path":        []string{"/pets"},
+				":method":      []string{"POST"},
+				"X-Request-Id": []string{"1"},
+				"Content-Type": []string{"application/json"},
+			},
+			code: 400,
+			violations: []violation{
+				{In: "body", Message: "value is required but missing"},
+			},
+		},
+		{
+			name: "unexpected content type",
+			header: http.Header{
+				":path":        []string{"/pets"},
+				":method":      []string{"POST"},
+				"X-Request-Id": []string{"1"},
+				"Content-Type": []string{"text/plain"},
+			},
+			body: "kitty",
+			code: 400,
+			violations: []violation{
+				{In: "body", Message: `header Content-Type has unexpected value "text/plain"`},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cb := envoy.NewFilterCallbackHandler()
+			f := factory(conf, cb)
+			hdr := envoy.NewRequestHeaderMap(tt.header)
+			res := f.DecodeHeaders(hdr, tt.body == "" && tt.header.Get(":method") != "POST")
+			if res == api.WaitAllData {
+				res = f.DecodeRequest(hdr, envoy.NewBufferInstance([]byte(tt.body)), nil)
+			}
+
+			if tt.code == 0 {
+				assert.Equal(t, api.Continue, res)
+			} else if tt.violations == nil {
+				resp, ok := res.(*api.LocalResponse)
+				require.True(t, ok)
+				assert.Equal(t, tt.code, resp.Code)
+			} else {
+				assertRejected(t, res, tt.code, tt.violations)
+			}
+		})
+	}
+}
+
+func TestRejectUnknownOperation(t *testing.T) {
+	conf := newConfig(t, `{"openapi":{"path":"./testdata/petstore.yaml","rejectUnknownOperation":true}}`)
+	f := factory(conf, envoy.NewFilterCallbackHandler())
+	res := f.DecodeHeaders(envoy.NewRequestHeaderMap(http.Header{":path": []string{"/users"}}), true)
+	assertRejected(t, res, 404, []violation{{In: "path", Message: "no matching operation was found"}})
+
+	res = f.DecodeHeaders(envoy.NewRequestHeaderMap(http.Header{
+		":path":   []string{"/pets"},
+		":method": []string{"DELETE"},
+	}), true)
+	assertRejected(t, res, 405, []violation{{In: "path", Message: "method not allowed"}})
+}
+
+func TestJSONSchema(t *testing.T) {
+	conf := newConfig(t, `{"jsonSchema":{
+		"body":"{\"type\":\"object\",\"required\":[\"id\"],\"properties\":{\"id\":{\"type\":\"integer\"}}}",
+		"query":"{\"type\":\"object\",\"required\":[\"page\"],\"properties\":{\"page\":{\"type\":\"integer\",\"minimum\":1}}}",
+		"headers":"{\"type\":\"object\",\"properties\":{\"x-tenant\":{\"type\":\"string\",\"enum\":[\"a\",\"b\"]}}}"
+	}}`)
+
+	tests := []struct {
+		name       string
+		header     http.Header
+		body       string
+		violations []violation
+	}{
+		{
+			name:   "pass",
+			header: http.Header{":path": []string{"/any?page=1"}, "X-Tenant": []string{"a"}},
+			body:   `{"id":1}`,
+		},
+		{
+			name:   "no body",
+			header: http.Header{":path": []string{"/any?page=1"}},
+		},
+		{
+			name:   "invalid query and header",
+			header: http.Header{":path": []string{"/any?page=0"}, "X-Tenant": []string{"c"}},
+			violations: []violation{
+				{In: "query", Name: "page", Message: "number must be at least 1"},
+				{In: "header", Name: "x-tenant", Message: `value is not one of the allowed values ["a","b"]`},
+			},
+		},
+		{
+			name:   "missing query",
+			header: http.Header{":path": []string{"/any"}},
+			violations: []violation{
+				{In: "query", Name: "page", Message: "value is required but missing"},
+			},
+		},
+		{
+			name:   "invalid body",
+			header: http.Header{":path": []string{"/any?page=1"}},
+			body:   `{"id":"1"}`,
+			violations: []violation{
+				{In: "body", Path: "/id", Message: `value must be an integer`},
+			},
+		},
+		{
+			name:   "malformed body",
+			header: http.Header{":path": []string{"/any?page=1"}},
+			body:   `{`,
+			violations: []violation{
+				{In: "body", Message: "failed to decode request body: unexpected EOF"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cb := envoy.NewFilterCallbackHandler()
+			f := factory(conf, cb)
+			tt.header.Set("Content-Type", "application/json")
+			hdr := envoy.NewRequestHeaderMap(tt.header)
+			res := f.DecodeHeaders(hdr, tt.body == "")
+			if res == api.WaitAllData {
+				res = f.DecodeRequest(hdr, envoy.NewBufferInstance([]byte(tt.body)), nil)
+			}
+
+			if tt.violations == nil {
+				assert.Equal(t, api.Continue, res)
+			} else {
+				assertRejected(t, res, 400, tt.violations)
+			}
+		})
+	}
+}
+
+func TestValidateResponse(t *testing.T) {
+	conf := newConfig(t, `{"openapi":{"path":"./testdata/petstore.yaml"},"validateResponse":true}`)
+	f := factory(conf, envoy.NewFilterCallbackHandler())
+	res := f.DecodeHeaders(envoy.NewRequestHeaderMap(http.Header{":path": []string{"/pets"}}), true)
+	assert.Equal(t, api.Continue, res)
+
+	rspHdr := envoy.NewResponseHeaderMap(http.Header{"Content-Type": []string{"application/json"}})
+	res = f.EncodeHeaders(rspHdr, false)
+	assert.Equal(t, api.WaitAllData, res)
+	// the invalid response is only reported
+	res = f.EncodeResponse(rspHdr, envoy.NewBufferInstance([]byte(`[{"age":1}]`)), nil)
+	assert.Equal(t, api.Continue, res)
+
+	rspHdr = envoy.NewResponseHeaderMap(http.Header{"Content-Encoding": []string{"gzip"}})
+	f = factory(conf, envoy.NewFilterCallbackHandler())
+	f.DecodeHeaders(envoy.NewRequestHeaderMap(http.Header{":path": []string{"/pets"}}), true)
+	assert.Equal(t, api.Continue, f.EncodeHeaders(rspHdr, false))
+
+	conf = newConfig(t, `{"openapi":{"path":"./testdata/petstore.yaml"}}`)
+	f = factory(conf, envoy.NewFilterCallbackHandler())
+	f.DecodeHeaders(envoy.NewRequestHeaderMap(http.Header{":path": []string{"/pets"}}), true)
+	assert.Equal(t, api.Continue, f.EncodeHeaders(rspHdr, false))
+}
+
+func TestReloadDocument(t *testing.T) {
+	data, err := os.ReadFile("./testdata/petstore.yaml")
+	require.Nil(t, err)
+	path := filepath.Join(t.TempDir(), "openapi.yaml")
+	require.Nil(t, os.WriteFile(path, data, 0644))
+
+	conf := newConfig(t, `{"openapi":{"path":"`+path+`","rejectUnknownOperation":true}}`)
+	hdr := envoy.NewRequestHeaderMap(http.Header{":path": []string{"/users"}})
+	res := factory(conf, envoy.NewFilterCallbackHandler()).DecodeHeaders(hdr, true)
+	assert.NotEqual(t, api.Continue, res)
+
+	doc := `
+openapi: 3.0.3
+info:
+  title: Users
+  version: 1.0.0
+paths:
+  /users:
+    get:
+      responses:
+        "200":
+          description: users
+`
+	require.Nil(t, os.WriteFile(path, []byte(doc), 0644))
+	future := time.Now().Add(time.Minute)
+	require.Nil(t, os.Chtimes(path, future, future))
+	// force the file info to be refreshed
+	_, err = file.Stat(path)
+	require.Nil(t, err)
+
+	assert.Eventually(t, func() bool {
+		res := factory(conf, envoy.NewFilterCallbackHandler()).DecodeHeaders(hdr, true)
+		return res == api.Continue
+	}, 3*time.Second, 50*time.Millisecond)
+}
@@ -0,0 +1,119 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package response_cache
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type cacheControl map[string]string
+
+func parseCacheControl(values []string) cacheControl {
+	cc := cacheControl{}
+	for _, value := range values {
+		for _, directive := range strings.Split(value, ",") {
+			directive = strings.TrimSpace(directive)
+			if directive == "" {
+				continue
+			}
+			name, val, _ := strings.Cut(directive, "=")
+			cc[strings.ToLower(strings.TrimSpace(name))] = strings.Trim(strings.TrimSpace(val), `"`)
+		}
+	}
+	return cc
+}
+
+func (cc cacheControl) has(name string) bool {
+	_, ok := cc[name]
+	return ok
+}
+
+// seconds returns the value of the directive in seconds, or -1 if it's absent or invalid
+func (cc cacheControl) seconds(name string) int64 {
+	v, ok := cc[name]
+	if !ok {
+		return -1
+	}
+	n, err := strconv.ParseInt(v, 10, 64)
+	if err != nil || n < 0 {
+		return -1
+	}
+	return n
+}
+
+// freshnessLifetime returns how long the response can be cached according to RFC 9111.
+// The defaultTTL is used when the response doesn't specify it explicitly.
+func freshnessLifetime(cc cacheControl, header http.Header, now time.Time, defaultTTL time.Duration) time.Duration {
+	if n := cc.seconds("s-maxage"); n >= 0 {
+		return time.Duration(n) * time.Second
+	}
+	if n := cc.seconds("max-age"); n >= 0 {
+		return time.Duration(n) * time.Second
+	}
+
+	if v := header.Get("Expires"); v != "" {
+		expires, err := http.ParseTime(v)
+		if err != nil {
+			// invalid Expires means the response is already expired
+			return 0
+		}
+		date := now
+		if v := header.Get("Date"); v != "" {
+			if t, err := http.ParseTime(v); err == nil {
+				date = t
+			}
+		}
+		return expires.Sub(date)
+	}
+
+	return defaultTTL
+}
+
+func opaqueTag(etag string) string {
+	// use the weak comparison
+	return strings.TrimPrefix(strings.TrimSpace(etag), "W/")
+}
+
+func etagMatch(ifNoneMatch string, etag string) bool {
+	if etag == "" {
+		return false
+	}
+	target := opaqueTag(etag)
+	for _, tag := range strings.Split(ifNoneMatch, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" || opaqueTag(tag) == target {
+			return true
+		}
+	}
+	return false
+}
+
+func notModifiedSince(ifModifiedSince string, lastModified string) bool {
+	if lastModified == "" {
+		return false
+	}
+	since, err := http.ParseTime(ifModifiedSince)
+	if err != nil {
+		return false
+	}
+	modified, err := http.ParseTime(lastModified)
+	if err != nil {
+		return false
+	}
+	return !modified.After(since)
+}
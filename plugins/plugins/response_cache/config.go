@@ -0,0 +1,152 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package response_cache
+
+import (
+	"crypto/tls"
+	"runtime"
+	"time"
+
+	"github.com/google/cel-go/cel"
+	"github.com/jellydator/ttlcache/v3"
+	"github.com/redis/go-redis/v9"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/plugins"
+	"mosn.io/htnn/types/pkg/expr"
+	"mosn.io/htnn/types/plugins/response_cache"
+)
+
+func init() {
+	plugins.RegisterHttpPlugin(response_cache.Name, &plugin{})
+}
+
+type plugin struct {
+	response_cache.Plugin
+}
+
+func (p *plugin) Factory() api.FilterFactory {
+	return factory
+}
+
+func (p *plugin) Config() api.PluginConfig {
+	return &config{}
+}
+
+type config struct {
+	response_cache.CustomConfig
+
+	store store
+
+	keyScript   expr.Script
+	methods     map[string]struct{}
+	statuses    map[uint32]struct{}
+	defaultTTL  time.Duration
+	maxTTL      time.Duration
+	maxBodySize int
+}
+
+func (conf *config) Init(cb api.ConfigCallbackHandler) error {
+	if r := conf.GetRedis(); r != nil {
+		var client redis.UniversalClient
+		var tlsConfig *tls.Config
+		if r.Tls {
+			tlsConfig = &tls.Config{
+				InsecureSkipVerify: r.TlsSkipVerify,
+			}
+		}
+
+		addr := r.GetAddress()
+		if addr != "" {
+			client = redis.NewClient(&redis.Options{
+				Addr:      addr,
+				Username:  r.Username,
+				Password:  r.Password,
+				TLSConfig: tlsConfig,
+			})
+		} else {
+			client = redis.NewClusterClient(&redis.ClusterOptions{
+				Addrs:     r.GetCluster().Addresses,
+				Username:  r.Username,
+				Password:  r.Password,
+				TLSConfig: tlsConfig,
+			})
+		}
+
+		prefix := "htnn_response_cache"
+		if r.Prefix != "" {
+			prefix = r.Prefix
+		}
+		conf.store = &redisStore{
+			client: client,
+			prefix: prefix,
+		}
+
+	} else {
+		capacity := uint64(1000)
+		if m := conf.GetMemory(); m != nil && m.MaxEntries != 0 {
+			capacity = uint64(m.MaxEntries)
+		}
+		cache := ttlcache.New(
+			ttlcache.WithCapacity[string, *entry](capacity),
+			// the expiration time of the cached response should not be extended
+			ttlcache.WithDisableTouchOnHit[string, *entry](),
+		)
+		conf.store = &memoryStore{
+			cache: cache,
+		}
+		go cache.Start()
+		runtime.SetFinalizer(conf, func(conf *config) {
+			api.LogInfof("stop cache in responseCache conf: %+v", conf)
+			cache.Stop()
+		})
+	}
+
+	if conf.Key != nil && conf.Key.Expr != "" {
+		conf.keyScript, _ = expr.CompileCel(conf.Key.Expr, cel.StringType)
+	}
+
+	methods := conf.Methods
+	if len(methods) == 0 {
+		methods = []string{"GET", "HEAD"}
+	}
+	conf.methods = make(map[string]struct{}, len(methods))
+	for _, m := range methods {
+		conf.methods[m] = struct{}{}
+	}
+
+	statuses := conf.Statuses
+	if len(statuses) == 0 {
+		statuses = []uint32{200}
+	}
+	conf.statuses = make(map[uint32]struct{}, len(statuses))
+	for _, s := range statuses {
+		conf.statuses[s] = struct{}{}
+	}
+
+	if conf.DefaultTtl != nil {
+		conf.defaultTTL = conf.DefaultTtl.AsDuration()
+	}
+	if conf.MaxTtl != nil {
+		conf.maxTTL = conf.MaxTtl.AsDuration()
+	}
+
+	conf.maxBodySize = 1 << 20
+	if conf.MaxBodySize != 0 {
+		conf.maxBodySize = int(conf.MaxBodySize)
+	}
+
+	return nil
+}
@@ -0,0 +1,85 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package response_cache
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestConfig(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{
+			name:  "default",
+			input: `{}`,
+		},
+		{
+			name:  "bad redis address",
+			input: `{"redis":{"address":"127.0.0.1"}}`,
+			err:   "bad address 127.0.0.1",
+		},
+		{
+			name:  "redis password required",
+			input: `{"redis":{"address":"127.0.0.1:6379","username":"user"}}`,
+			err:   "password is required when username is set",
+		},
+		{
+			name:  "bad key expr",
+			input: `{"key":{"expr":"request.method() == \"GET\""}}`,
+			err:   "got bool, wanted string",
+		},
+		{
+			name:  "bad method",
+			input: `{"methods":["POST"]}`,
+			err:   "invalid Config.Methods",
+		},
+		{
+			name:  "bad ttl",
+			input: `{"defaultTtl":"60s","maxTtl":"10s"}`,
+			err:   "default_ttl should not be greater than max_ttl",
+		},
+		{
+			name:  "memory",
+			input: `{"memory":{"maxEntries":10},"key":{"queryParams":["page"],"headers":["accept-language"]},"defaultTtl":"10s","maxTtl":"60s"}`,
+		},
+		{
+			name:  "redis",
+			input: `{"redis":{"cluster":{"addresses":["127.0.0.1:6379"]},"prefix":"cache"},"key":{"expr":"request.path()"},"statuses":[200,404]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := &config{}
+			err := protojson.Unmarshal([]byte(tt.input), conf)
+			if err == nil {
+				err = conf.Validate()
+			}
+			if tt.err == "" {
+				assert.Nil(t, err)
+				err = conf.Init(nil)
+				assert.Nil(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,356 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package response_cache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+)
+
+const (
+	cacheStatusHeader = "x-cache"
+
+	cacheStatusHit    = "HIT"
+	cacheStatusMiss   = "MISS"
+	cacheStatusBypass = "BYPASS"
+)
+
+var (
+	// the headers which are not stored in the cache
+	excludedHeaders = map[string]struct{}{
+		"Connection":          {},
+		"Keep-Alive":          {},
+		"Proxy-Authenticate":  {},
+		"Proxy-Authorization": {},
+		"Proxy-Connection":    {},
+		"Te":                  {},
+		"Trailer":             {},
+		"Transfer-Encoding":   {},
+		"Upgrade":             {},
+		// the length is set by Envoy when sending the cached response
+		"Content-Length": {},
+		"X-Cache":        {},
+		"Age":            {},
+	}
+
+	// the headers sent with 304 Not Modified, see RFC 9110 15.4.5
+	notModifiedHeaders = []string{
+		"Cache-Control",
+		"Content-Location",
+		"Date",
+		"Etag",
+		"Expires",
+		"Last-Modified",
+		"Vary",
+	}
+)
+
+func factory(c interface{}, callbacks api.FilterCallbackHandler) api.Filter {
+	return &filter{
+		callbacks: callbacks,
+		config:    c.(*config),
+	}
+}
+
+type filter struct {
+	api.PassThroughFilter
+
+	callbacks api.FilterCallbackHandler
+	config    *config
+
+	cacheStatus string
+	// the primary key, set when the response can be stored
+	key        string
+	reqHeaders api.RequestHeaderMap
+
+	pending *entry
+	ttl     time.Duration
+}
+
+func hashKey(parts ...string) string {
+	h := sha256.New()
+	for _, p := range parts {
+		h.Write([]byte(p))
+		h.Write([]byte{'\n'})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func (f *filter) computeKey(headers api.RequestHeaderMap) (string, error) {
+	conf := f.config
+	if conf.keyScript != nil {
+		res, err := conf.keyScript.EvalWithRequest(f.callbacks, headers)
+		if err != nil {
+			return "", err
+		}
+		return hashKey(res.(string)), nil
+	}
+
+	path, query, _ := strings.Cut(headers.Path(), "?")
+	parts := []string{headers.Method(), headers.Host(), path}
+	if conf.Key == nil || len(conf.Key.QueryParams) == 0 {
+		parts = append(parts, query)
+	} else {
+		args := headers.Url().Query()
+		for _, name := range conf.Key.QueryParams {
+			parts = append(parts, name+"="+strings.Join(args[name], "&"))
+		}
+	}
+	if conf.Key != nil {
+		for _, name := range conf.Key.Headers {
+			parts = append(parts, name+":"+strings.Join(headers.Values(name), ","))
+		}
+	}
+	return hashKey(parts...), nil
+}
+
+func varyKey(key string, vary []string, headers api.RequestHeaderMap) string {
+	parts := make([]string, 0, len(vary)+1)
+	parts = append(parts, key)
+	for _, name := range vary {
+		parts = append(parts, name+":"+strings.Join(headers.Values(name), ","))
+	}
+	return hashKey(parts...)
+}
+
+func (f *filter) lookup(ctx context.Context, headers api.RequestHeaderMap) (*entry, error) {
+	e, err := f.config.store.Get(ctx, f.key)
+	if err != nil || e == nil || !e.isVaryIndex() {
+		return e, err
+	}
+	return f.config.store.Get(ctx, varyKey(f.key, e.Vary, headers))
+}
+
+func (f *filter) serve(e *entry, headers api.RequestHeaderMap) api.ResultAction {
+	age := time.Now().Unix() - e.StoredAt
+	if age < 0 {
+		age = 0
+	}
+
+	code := e.Status
+	var hdr http.Header
+	var msg string
+	inm, hasINM := headers.Get("if-none-match")
+	ims, hasIMS := headers.Get("if-modified-since")
+	if (hasINM && etagMatch(inm, e.Header.Get("Etag"))) ||
+		(!hasINM && hasIMS && notModifiedSince(ims, e.Header.Get("Last-Modified"))) {
+
+		code = http.StatusNotModified
+		hdr = http.Header{}
+		for _, name := range notModifiedHeaders {
+			if v, ok := e.Header[name]; ok {
+				hdr[name] = v
+			}
+		}
+	} else {
+		hdr = e.Header.Clone()
+		msg = string(e.Body)
+	}
+	hdr.Set("Age", strconv.FormatInt(age, 10))
+	hdr.Set(cacheStatusHeader, cacheStatusHit)
+	return &api.LocalResponse{Code: code, Msg: msg, Header: hdr}
+}
+
+func (f *filter) DecodeHeaders(headers api.RequestHeaderMap, endStream bool) api.ResultAction {
+	conf := f.config
+	if _, ok := conf.methods[headers.Method()]; !ok {
+		return api.Continue
+	}
+
+	cc := parseCacheControl(headers.Values("cache-control"))
+	if cc.has("no-store") {
+		f.cacheStatus = cacheStatusBypass
+		return api.Continue
+	}
+
+	key, err := f.computeKey(headers)
+	if err != nil {
+		api.LogErrorf("failed to compute cache key: %v", err)
+		f.cacheStatus = cacheStatusBypass
+		return api.Continue
+	}
+	f.key = key
+	f.reqHeaders = headers
+	f.cacheStatus = cacheStatusMiss
+
+	// the client requires the response to be validated by the upstream
+	if cc.has("no-cache") || cc.seconds("max-age") == 0 {
+		return api.Continue
+	}
+
+	e, err := f.lookup(context.Background(), headers)
+	if err != nil {
+		api.LogErrorf("failed to lookup cache: %v", err)
+		return api.Continue
+	}
+	if e == nil {
+		return api.Continue
+	}
+
+	f.cacheStatus = cacheStatusHit
+	return f.serve(e, headers)
+}
+
+// cacheable returns the TTL of the response. The response is not cacheable if the TTL is zero.
+func (f *filter) cacheable(headers api.ResponseHeaderMap) time.Duration {
+	conf := f.config
+	status, _ := headers.Status()
+	if _, ok := conf.statuses[uint32(status)]; !ok {
+		return 0
+	}
+
+	cc := parseCacheControl(headers.Values("cache-control"))
+	if cc.has("no-store") || cc.has("private") || cc.has("no-cache") {
+		return 0
+	}
+	if _, ok := headers.Get("set-cookie"); ok {
+		return 0
+	}
+	for _, v := range headers.Values("vary") {
+		if strings.TrimSpace(v) == "*" {
+			return 0
+		}
+	}
+	if _, ok := f.reqHeaders.Get("authorization"); ok {
+		// shared cache can't store the response to authenticated request unless it's explicitly allowed
+		if !cc.has("public") && !cc.has("s-maxage") && !cc.has("must-revalidate") {
+			return 0
+		}
+	}
+
+	hdr := http.Header{}
+	for _, name := range []string{"Expires", "Date"} {
+		if v, ok := headers.Get(name); ok {
+			hdr.Set(name, v)
+		}
+	}
+	ttl := freshnessLifetime(cc, hdr, time.Now(), conf.defaultTTL)
+	if conf.maxTTL > 0 && ttl > conf.maxTTL {
+		ttl = conf.maxTTL
+	}
+	return ttl
+}
+
+func (f *filter) EncodeHeaders(headers api.ResponseHeaderMap, endStream bool) api.ResultAction {
+	if f.cacheStatus == "" || f.cacheStatus == cacheStatusHit {
+		return api.Continue
+	}
+
+	if f.key != "" {
+		f.prepare(headers, endStream)
+	}
+	headers.Set(cacheStatusHeader, f.cacheStatus)
+
+	if f.pending == nil {
+		return api.Continue
+	}
+	if endStream {
+		f.save()
+		return api.Continue
+	}
+	return api.WaitAllData
+}
+
+func (f *filter) prepare(headers api.ResponseHeaderMap, endStream bool) {
+	ttl := f.cacheable(headers)
+	if ttl <= 0 {
+		return
+	}
+
+	if !endStream {
+		if _, ok := headers.Get("content-type"); !ok {
+			// the cached body will be wrapped as JSON if the content type is missing
+			return
+		}
+		if cl, ok := headers.Get("content-length"); ok {
+			n, err := strconv.Atoi(cl)
+			if err != nil || n > f.config.maxBodySize {
+				return
+			}
+		}
+	}
+
+	status, _ := headers.Status()
+	hdr := http.Header{}
+	headers.Range(func(k, v string) bool {
+		if strings.HasPrefix(k, ":") {
+			return true
+		}
+		hdr.Add(k, v)
+		return true
+	})
+	for k := range excludedHeaders {
+		hdr.Del(k)
+	}
+
+	f.pending = &entry{
+		Status: status,
+		Header: hdr,
+	}
+	f.ttl = ttl
+}
+
+func (f *filter) EncodeResponse(headers api.ResponseHeaderMap, data api.BufferInstance, trailers api.ResponseTrailerMap) api.ResultAction {
+	if f.pending == nil {
+		return api.Continue
+	}
+
+	if data != nil {
+		if data.Len() > f.config.maxBodySize {
+			return api.Continue
+		}
+		f.pending.Body = append([]byte{}, data.Bytes()...)
+	}
+	f.save()
+	return api.Continue
+}
+
+func (f *filter) save() {
+	ctx := context.Background()
+	store := f.config.store
+	e := f.pending
+	e.StoredAt = time.Now().Unix()
+
+	key := f.key
+	var vary []string
+	for _, v := range e.Header.Values("Vary") {
+		for _, name := range strings.Split(v, ",") {
+			name = strings.ToLower(strings.TrimSpace(name))
+			if name != "" {
+				vary = append(vary, name)
+			}
+		}
+	}
+	if len(vary) > 0 {
+		err := store.Set(ctx, key, &entry{Vary: vary}, f.ttl)
+		if err != nil {
+			api.LogErrorf("failed to store cache: %v", err)
+			return
+		}
+		key = varyKey(key, vary, f.reqHeaders)
+	}
+
+	err := store.Set(ctx, key, e, f.ttl)
+	if err != nil {
+		api.LogErrorf("failed to store cache: %v", err)
+	}
+}
@@ -0,0 +1,318 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package response_cache
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/plugins/tests/pkg/envoy"
+)
+
+func TestFreshnessLifetime(t *testing.T) {
+	now := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		cc     string
+		header http.Header
+		ttl    time.Duration
+	}{
+		{
+			name: "s-maxage first",
+			cc:   "max-age=10, s-maxage=20",
+			ttl:  20 * time.Second,
+		},
+		{
+			name: "max-age",
+			cc:   "public, max-age=10",
+			ttl:  10 * time.Second,
+		},
+		{
+			name: "expires",
+			header: http.Header{
+				"Date":    []string{"Wed, 01 May 2024 00:00:00 GMT"},
+				"Expires": []string{"Wed, 01 May 2024 00:01:00 GMT"},
+			},
+			ttl: time.Minute,
+		},
+		{
+			name: "bad expires",
+			header: http.Header{
+				"Expires": []string{"0"},
+			},
+			ttl: 0,
+		},
+		{
+			name: "default",
+			ttl:  5 * time.Second,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hdr := tt.header
+			if hdr == nil {
+				hdr = http.Header{}
+			}
+			cc := parseCacheControl([]string{tt.cc})
+			assert.Equal(t, tt.ttl, freshnessLifetime(cc, hdr, now, 5*time.Second))
+		})
+	}
+}
+
+func TestConditional(t *testing.T) {
+	assert.True(t, etagMatch(`"a", W/"b"`, `"b"`))
+	assert.True(t, etagMatch(`*`, `"b"`))
+	assert.False(t, etagMatch(`"a"`, `"b"`))
+	assert.False(t, etagMatch(`"a"`, ``))
+
+	assert.True(t, notModifiedSince("Wed, 01 May 2024 00:00:00 GMT", "Wed, 01 May 2024 00:00:00 GMT"))
+	assert.False(t, notModifiedSince("Wed, 01 May 2024 00:00:00 GMT", "Wed, 01 May 2024 00:00:01 GMT"))
+	assert.False(t, notModifiedSince("Wed, 01 May 2024 00:00:00 GMT", ""))
+}
+
+func newConfig(t *testing.T, input string) *config {
+	conf := &config{}
+	err := protojson.Unmarshal([]byte(input), conf)
+	require.NoError(t, err)
+	require.NoError(t, conf.Validate())
+	require.NoError(t, conf.Init(nil))
+	return conf
+}
+
+type result struct {
+	status int
+	body   string
+	header http.Header
+}
+
+// do runs a request through the filter. The upstream response is used when the cache is missed.
+func do(t *testing.T, conf *config, reqHdr http.Header, upstream *result) *result {
+	cb := envoy.NewFilterCallbackHandler()
+	f := factory(conf, cb).(*filter)
+	res := f.DecodeHeaders(envoy.NewRequestHeaderMap(reqHdr), true)
+	if resp, ok := res.(*api.LocalResponse); ok {
+		return &result{status: resp.Code, body: resp.Msg, header: resp.Header}
+	}
+	require.Equal(t, api.Continue, res)
+
+	hdr := upstream.header.Clone()
+	if hdr == nil {
+		hdr = http.Header{}
+	}
+	respHdr := envoy.NewResponseHeaderMap(hdr)
+	res = f.EncodeHeaders(respHdr, upstream.body == "")
+	if res == api.WaitAllData {
+		res = f.EncodeResponse(respHdr, envoy.NewBufferInstance([]byte(upstream.body)), nil)
+	}
+	require.Equal(t, api.Continue, res)
+	return &result{status: upstream.status, body: upstream.body, header: hdr}
+}
+
+func TestFilter(t *testing.T) {
+	conf := newConfig(t, `{"key":{"queryParams":["page"]},"defaultTtl":"60s"}`)
+	upstream := &result{
+		status: 200,
+		body:   "hello",
+		header: http.Header{
+			"Content-Type": []string{"text/plain"},
+			"Etag":         []string{`"v1"`},
+		},
+	}
+	req := http.Header{":path": []string{"/a?page=1&ts=1"}}
+
+	res := do(t, conf, req, upstream)
+	assert.Equal(t, "MISS", res.header.Get("x-cache"))
+
+	res = do(t, conf, req, upstream)
+	assert.Equal(t, 200, res.status)
+	assert.Equal(t, "hello", res.body)
+	assert.Equal(t, "HIT", res.header.Get("x-cache"))
+	assert.Equal(t, "text/plain", res.header.Get("content-type"))
+	assert.Equal(t, "0", res.header.Get("age"))
+
+	// only the selected query params are used
+	res = do(t, conf, http.Header{":path": []string{"/a?page=1&ts=2"}}, upstream)
+	assert.Equal(t, "HIT", res.header.Get("x-cache"))
+	res = do(t, conf, http.Header{":path": []string{"/a?page=2"}}, upstream)
+	assert.Equal(t, "MISS", res.header.Get("x-cache"))
+	res = do(t, conf, http.Header{":path": []string{"/a?page=1"}, ":method": []string{"HEAD"}}, upstream)
+	assert.Equal(t, "MISS", res.header.Get("x-cache"))
+
+	// conditional request
+	res = do(t, conf, http.Header{":path": []string{"/a?page=1"}, "If-None-Match": []string{`"v1"`}}, upstream)
+	assert.Equal(t, 304, res.status)
+	assert.Equal(t, "", res.body)
+	assert.Equal(t, `"v1"`, res.header.Get("etag"))
+	assert.Equal(t, "", res.header.Get("content-type"))
+	res = do(t, conf, http.Header{":path": []string{"/a?page=1"}, "If-None-Match": []string{`"v0"`}}, upstream)
+	assert.Equal(t, 200, res.status)
+	assert.Equal(t, "HIT", res.header.Get("x-cache"))
+
+	// the client requires revalidation
+	res = do(t, conf, http.Header{":path": []string{"/a?page=1"}, "Cache-Control": []string{"no-cache"}}, upstream)
+	assert.Equal(t, "MISS", res.header.Get("x-cache"))
+	res = do(t, conf, http.Header{":path": []string{"/a?page=1"}, "Cache-Control": []string{"no-store"}}, upstream)
+	assert.Equal(t, "BYPASS", res.header.Get("x-cache"))
+
+	// methods not configured
+	res = do(t, conf, http.Header{":path": []string{"/a?page=1"}, ":method": []string{"POST"}}, upstream)
+	assert.Equal(t, "", res.header.Get("x-cache"))
+}
+
+func TestFilterNotCacheable(t *testing.T) {
+	tests := []struct {
+		name   string
+		req    http.Header
+		header http.Header
+		status string
+		body   string
+	}{
+		{
+			name:   "status",
+			status: "404",
+		},
+		{
+			name:   "private",
+			header: http.Header{"Cache-Control": []string{"private, max-age=60"}},
+		},
+		{
+			name:   "no-store",
+			header: http.Header{"Cache-Control": []string{"no-store"}},
+		},
+		{
+			name:   "set-cookie",
+			header: http.Header{"Set-Cookie": []string{"a=b"}},
+		},
+		{
+			name:   "vary all",
+			header: http.Header{"Vary": []string{"*"}},
+		},
+		{
+			name:   "max-age=0",
+			header: http.Header{"Cache-Control": []string{"max-age=0"}},
+		},
+		{
+			name: "authorization",
+			req:  http.Header{"Authorization": []string{"Basic xxx"}},
+		},
+		{
+			name: "too large",
+			header: http.Header{
+				"Content-Length": []string{"100"},
+				"Content-Type":   []string{"text/plain"},
+			},
+			body: "large",
+		},
+		{
+			name:   "too large without content-length",
+			header: http.Header{"Content-Type": []string{"text/plain"}},
+			body:   "0123456789",
+		},
+		{
+			name: "without content-type",
+			body: "hello",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := newConfig(t, `{"defaultTtl":"60s","maxBodySize":8}`)
+			req := http.Header{":path": []string{"/"}}
+			for k, v := range tt.req {
+				req[k] = v
+			}
+			hdr := http.Header{}
+			for k, v := range tt.header {
+				hdr[k] = v
+			}
+			if tt.status != "" {
+				hdr.Set(":status", tt.status)
+			}
+			upstream := &result{header: hdr, body: tt.body}
+
+			res := do(t, conf, req, upstream)
+			assert.Equal(t, "MISS", res.header.Get("x-cache"))
+			res = do(t, conf, req, upstream)
+			assert.Equal(t, "MISS", res.header.Get("x-cache"))
+		})
+	}
+
+	// the response to authenticated request can be cached when it's explicitly allowed
+	conf := newConfig(t, `{}`)
+	req := http.Header{":path": []string{"/"}, "Authorization": []string{"Basic xxx"}}
+	upstream := &result{header: http.Header{"Cache-Control": []string{"public, max-age=60"}}}
+	do(t, conf, req, upstream)
+	res := do(t, conf, req, upstream)
+	assert.Equal(t, "HIT", res.header.Get("x-cache"))
+}
+
+func TestFilterVary(t *testing.T) {
+	conf := newConfig(t, `{}`)
+	upstream := func(lang string) *result {
+		return &result{
+			body: lang,
+			header: http.Header{
+				"Cache-Control": []string{"max-age=60"},
+				"Content-Type":  []string{"text/plain"},
+				"Vary":          []string{"Accept-Language"},
+			},
+		}
+	}
+	en := http.Header{"Accept-Language": []string{"en"}}
+	zh := http.Header{"Accept-Language": []string{"zh"}}
+
+	do(t, conf, en, upstream("en"))
+	res := do(t, conf, zh, upstream("zh"))
+	assert.Equal(t, "MISS", res.header.Get("x-cache"))
+
+	res = do(t, conf, en, nil)
+	assert.Equal(t, "HIT", res.header.Get("x-cache"))
+	assert.Equal(t, "en", res.body)
+	res = do(t, conf, zh, nil)
+	assert.Equal(t, "HIT", res.header.Get("x-cache"))
+	assert.Equal(t, "zh", res.body)
+}
+
+func TestFilterKeyExpr(t *testing.T) {
+	conf := newConfig(t, `{"key":{"expr":"request.header(\"x-tenant\")"}}`)
+	upstream := &result{header: http.Header{"Cache-Control": []string{"max-age=60"}}}
+
+	do(t, conf, http.Header{":path": []string{"/a"}, "X-Tenant": []string{"a"}}, upstream)
+	res := do(t, conf, http.Header{":path": []string{"/b"}, "X-Tenant": []string{"a"}}, upstream)
+	assert.Equal(t, "HIT", res.header.Get("x-cache"))
+	res = do(t, conf, http.Header{":path": []string{"/a"}, "X-Tenant": []string{"b"}}, upstream)
+	assert.Equal(t, "MISS", res.header.Get("x-cache"))
+}
+
+func TestFilterExpired(t *testing.T) {
+	conf := newConfig(t, `{"maxTtl":"1s"}`)
+	upstream := &result{header: http.Header{"Cache-Control": []string{"max-age=60"}}}
+	req := http.Header{":path": []string{"/"}}
+
+	do(t, conf, req, upstream)
+	res := do(t, conf, req, upstream)
+	assert.Equal(t, "HIT", res.header.Get("x-cache"))
+
+	time.Sleep(1100 * time.Millisecond)
+	res = do(t, conf, req, upstream)
+	assert.Equal(t, "MISS", res.header.Get("x-cache"))
+}
@@ -0,0 +1,99 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package response_cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/jellydator/ttlcache/v3"
+	"github.com/redis/go-redis/v9"
+)
+
+// entry is the cached response. When the response has Vary header, an extra entry which only
+// contains the Vary header names is stored under the primary key, and the response is stored
+// under the key computed from the primary key and the request headers listed in Vary.
+type entry struct {
+	Status   int         `json:"status,omitempty"`
+	Header   http.Header `json:"header,omitempty"`
+	Body     []byte      `json:"body,omitempty"`
+	StoredAt int64       `json:"stored_at,omitempty"`
+
+	Vary []string `json:"vary,omitempty"`
+}
+
+func (e *entry) isVaryIndex() bool {
+	return e.Status == 0
+}
+
+type store interface {
+	// Get returns nil if the entry is not found
+	Get(ctx context.Context, key string) (*entry, error)
+	Set(ctx context.Context, key string, e *entry, ttl time.Duration) error
+}
+
+type memoryStore struct {
+	cache *ttlcache.Cache[string, *entry]
+}
+
+func (s *memoryStore) Get(_ context.Context, key string) (*entry, error) {
+	item := s.cache.Get(key)
+	if item == nil {
+		return nil, nil
+	}
+	return item.Value(), nil
+}
+
+func (s *memoryStore) Set(_ context.Context, key string, e *entry, ttl time.Duration) error {
+	s.cache.Set(key, e, ttl)
+	return nil
+}
+
+type redisStore struct {
+	client redis.UniversalClient
+	prefix string
+}
+
+func (s *redisStore) key(key string) string {
+	return s.prefix + "|" + key
+}
+
+func (s *redisStore) Get(ctx context.Context, key string) (*entry, error) {
+	data, err := s.client.Get(ctx, s.key(key)).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	e := &entry{}
+	err = json.Unmarshal(data, e)
+	if err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+func (s *redisStore) Set(ctx context.Context, key string, e *entry, ttl time.Duration) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	return s.client.Set(ctx, s.key(key), data, ttl).Err()
+}
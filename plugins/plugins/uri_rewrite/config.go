@@ -0,0 +1,119 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package uri_rewrite
+
+import (
+	"regexp"
+
+	"github.com/google/cel-go/cel"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/plugins"
+	"mosn.io/htnn/types/pkg/expr"
+	"mosn.io/htnn/types/plugins/uri_rewrite"
+)
+
+func init() {
+	plugins.RegisterHttpPlugin(uri_rewrite.Name, &plugin{})
+}
+
+type plugin struct {
+	uri_rewrite.Plugin
+}
+
+func (p *plugin) Factory() api.FilterFactory {
+	return factory
+}
+
+func (p *plugin) Config() api.PluginConfig {
+	return &config{}
+}
+
+type rule struct {
+	path   *regexp.Regexp
+	host   expr.Matcher
+	scheme string
+	script expr.Script
+
+	// status is 0 when the rule is a rewrite
+	status     int
+	newScheme  string
+	newHost    string
+	newPath    string
+	stripQuery bool
+}
+
+type config struct {
+	uri_rewrite.CustomConfig
+
+	rules []*rule
+}
+
+func buildRule(r *uri_rewrite.Rule) (*rule, error) {
+	res := &rule{
+		scheme:     r.Match.Scheme,
+		stripQuery: r.StripQuery,
+	}
+
+	m := r.Match
+	if m.Path != "" {
+		re, err := uri_rewrite.CompilePath(m.Path)
+		if err != nil {
+			return nil, err
+		}
+		res.path = re
+	}
+	if m.Host != nil {
+		host, err := expr.BuildStringMatcher(m.Host)
+		if err != nil {
+			return nil, err
+		}
+		res.host = host
+	}
+	if m.Expr != "" {
+		script, err := expr.CompileCel(m.Expr, cel.BoolType)
+		if err != nil {
+			return nil, err
+		}
+		res.script = script
+	}
+
+	if redirect := r.GetRedirect(); redirect != nil {
+		res.status = int(redirect.Status)
+		if res.status == 0 {
+			res.status = 302
+		}
+		res.newScheme = redirect.Scheme
+		res.newHost = redirect.Host
+		res.newPath = redirect.Path
+	} else {
+		rewrite := r.GetRewrite()
+		res.newHost = rewrite.Host
+		res.newPath = rewrite.Path
+	}
+	return res, nil
+}
+
+func (conf *config) Init(cb api.ConfigCallbackHandler) error {
+	conf.rules = make([]*rule, 0, len(conf.Rules))
+	for _, r := range conf.Rules {
+		rule, err := buildRule(r)
+		if err != nil {
+			return err
+		}
+		conf.rules = append(conf.rules, rule)
+	}
+	return nil
+}
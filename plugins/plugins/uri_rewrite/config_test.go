@@ -0,0 +1,89 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package uri_rewrite
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestConfig(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{
+			name:  "no match",
+			input: `{"rules":[{"redirect":{"scheme":"https"}}]}`,
+			err:   "invalid Rule.Match: value is required",
+		},
+		{
+			name:  "no action",
+			input: `{"rules":[{"match":{"scheme":"http"}}]}`,
+			err:   "invalid Rule.Action: value is required",
+		},
+		{
+			name:  "invalid status",
+			input: `{"rules":[{"match":{},"redirect":{"status":200,"scheme":"https"}}]}`,
+			err:   "invalid rule 0: invalid redirect status 200",
+		},
+		{
+			name:  "redirect loop",
+			input: `{"rules":[{"match":{},"redirect":{"status":301}}]}`,
+			err:   "invalid rule 0: redirect to the same URL",
+		},
+		{
+			name:  "empty rewrite",
+			input: `{"rules":[{"match":{},"rewrite":{}}]}`,
+			err:   "invalid rule 0: nothing to rewrite",
+		},
+		{
+			name:  "invalid path",
+			input: `{"rules":[{"match":{"path":"/("},"rewrite":{"path":"/"}}]}`,
+			err:   "invalid rule 0: error parsing regexp",
+		},
+		{
+			name:  "invalid expr",
+			input: `{"rules":[{"match":{"expr":"request.path()"},"rewrite":{"path":"/"}}]}`,
+			err:   "invalid rule 0",
+		},
+		{
+			name: "pass",
+			input: `{"rules":[
+				{"match":{"scheme":"http"},"redirect":{"status":308,"scheme":"https"}},
+				{"match":{"path":"/old/(.*)","host":{"exact":"example.com"}},"rewrite":{"path":"/new/$1"},"stripQuery":true}
+			]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := &config{}
+			err := protojson.Unmarshal([]byte(tt.input), conf)
+			if err == nil {
+				err = conf.Validate()
+			}
+			if tt.err == "" {
+				assert.Nil(t, err)
+				assert.Nil(t, conf.Init(nil))
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
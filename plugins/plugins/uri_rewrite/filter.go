@@ -0,0 +1,126 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package uri_rewrite
+
+import (
+	"net/http"
+	"strings"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+)
+
+func factory(c interface{}, callbacks api.FilterCallbackHandler) api.Filter {
+	return &filter{
+		callbacks: callbacks,
+		config:    c.(*config),
+	}
+}
+
+type filter struct {
+	api.PassThroughFilter
+
+	callbacks api.FilterCallbackHandler
+	config    *config
+}
+
+// match returns the capture groups of the path if the rule is matched
+func (f *filter) match(r *rule, headers api.RequestHeaderMap, path string) ([]int, bool) {
+	if r.scheme != "" && !strings.EqualFold(r.scheme, headers.Scheme()) {
+		return nil, false
+	}
+	if r.host != nil && !r.host.Match(headers.Host()) {
+		return nil, false
+	}
+
+	var captures []int
+	if r.path != nil {
+		captures = r.path.FindStringSubmatchIndex(path)
+		if captures == nil {
+			return nil, false
+		}
+	}
+
+	if r.script != nil {
+		res, err := r.script.EvalWithRequest(f.callbacks, headers)
+		if err != nil {
+			api.LogErrorf("uriRewrite: failed to eval expression: %v", err)
+			return nil, false
+		}
+		if !res.(bool) {
+			return nil, false
+		}
+	}
+	return captures, true
+}
+
+func expand(r *rule, template string, path string, captures []int) string {
+	if r.path == nil {
+		return template
+	}
+	return string(r.path.ExpandString(nil, template, path, captures))
+}
+
+func (f *filter) DecodeHeaders(headers api.RequestHeaderMap, endStream bool) api.ResultAction {
+	// match the path as is, so the escaped characters are kept in the new path
+	path, query, _ := strings.Cut(headers.Path(), "?")
+	for _, r := range f.config.rules {
+		captures, ok := f.match(r, headers, path)
+		if !ok {
+			continue
+		}
+
+		newPath := path
+		if r.newPath != "" {
+			newPath = expand(r, r.newPath, path, captures)
+		}
+		if !r.stripQuery && query != "" {
+			if strings.Contains(newPath, "?") {
+				newPath += "&" + query
+			} else {
+				newPath += "?" + query
+			}
+		}
+		newHost := ""
+		if r.newHost != "" {
+			newHost = expand(r, r.newHost, path, captures)
+		}
+
+		if r.status != 0 {
+			location := newPath
+			if r.newScheme != "" || newHost != "" {
+				scheme := r.newScheme
+				if scheme == "" {
+					scheme = headers.Scheme()
+				}
+				if newHost == "" {
+					newHost = headers.Host()
+				}
+				location = scheme + "://" + newHost + newPath
+			}
+			return &api.LocalResponse{Code: r.status, Header: http.Header{"Location": []string{location}}}
+		}
+
+		if !strings.HasPrefix(newPath, "/") {
+			api.LogErrorf("uriRewrite: invalid path %q after rewriting", newPath)
+			return &api.LocalResponse{Code: 500}
+		}
+		headers.Set(":path", newPath)
+		if newHost != "" {
+			headers.Set(":authority", newHost)
+		}
+		return api.Continue
+	}
+	return api.Continue
+}
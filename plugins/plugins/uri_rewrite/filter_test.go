@@ -0,0 +1,164 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package uri_rewrite
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/plugins/tests/pkg/envoy"
+)
+
+func newConfig(t *testing.T, input string) *config {
+	conf := &config{}
+	require.Nil(t, protojson.Unmarshal([]byte(input), conf))
+	require.Nil(t, conf.Validate())
+	require.Nil(t, conf.Init(nil))
+	return conf
+}
+
+func redirectTo(status int, location string) api.ResultAction {
+	return &api.LocalResponse{Code: status, Header: http.Header{"Location": []string{location}}}
+}
+
+func TestRedirect(t *testing.T) {
+	conf := newConfig(t, `{"rules":[
+		{"match":{"scheme":"http"},"redirect":{"status":301,"scheme":"https"}},
+		{"match":{"path":"/docs/(?P<page>.+)\\.html"},"redirect":{"status":308,"path":"/documents/${page}"}},
+		{"match":{"path":"/(v1|v2)/(.*)","host":{"prefix":"old."}},"redirect":{"host":"api.example.com","path":"/$2?version=$1"}},
+		{"match":{"path":"/","expr":"request.header('accept-language').startsWith('zh')"},"redirect":{"path":"/zh/"},"stripQuery":true}
+	]}`)
+
+	tests := []struct {
+		name   string
+		header http.Header
+		res    api.ResultAction
+	}{
+		{
+			name:   "https",
+			header: http.Header{":scheme": []string{"http"}, ":authority": []string{"example.com"}, ":path": []string{"/a?b=c"}},
+			res:    redirectTo(301, "https://example.com/a?b=c"),
+		},
+		{
+			name:   "named capture",
+			header: http.Header{":scheme": []string{"https"}, ":authority": []string{"example.com"}, ":path": []string{"/docs/intro.html?x=1"}},
+			res:    redirectTo(308, "/documents/intro?x=1"),
+		},
+		{
+			name:   "host and query",
+			header: http.Header{":scheme": []string{"https"}, ":authority": []string{"old.example.com"}, ":path": []string{"/v2/users?id=1"}},
+			res:    redirectTo(302, "https://api.example.com/users?version=v2&id=1"),
+		},
+		{
+			name:   "host mismatch",
+			header: http.Header{":scheme": []string{"https"}, ":authority": []string{"example.com"}, ":path": []string{"/v2/users?id=1"}},
+			res:    api.Continue,
+		},
+		{
+			name: "locale",
+			header: http.Header{":scheme": []string{"https"}, ":authority": []string{"example.com"}, ":path": []string{"/?utm=x"},
+				"Accept-Language": []string{"zh-CN,zh;q=0.9"}},
+			res: redirectTo(302, "/zh/"),
+		},
+		{
+			name: "locale mismatch",
+			header: http.Header{":scheme": []string{"https"}, ":authority": []string{"example.com"}, ":path": []string{"/"},
+				"Accept-Language": []string{"en-US"}},
+			res: api.Continue,
+		},
+		{
+			name:   "the whole path should match",
+			header: http.Header{":scheme": []string{"https"}, ":authority": []string{"example.com"}, ":path": []string{"/docs/intro.html/x"}},
+			res:    api.Continue,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := factory(conf, envoy.NewFilterCallbackHandler())
+			hdr := envoy.NewRequestHeaderMap(tt.header)
+			assert.Equal(t, tt.res, f.DecodeHeaders(hdr, true))
+		})
+	}
+}
+
+func TestRewrite(t *testing.T) {
+	conf := newConfig(t, `{"rules":[
+		{"match":{"path":"/legacy/([^/]+)/(.*)"},"rewrite":{"host":"$1.internal","path":"/$2"}},
+		{"match":{"path":"/search"},"rewrite":{"path":"/query"},"stripQuery":true},
+		{"match":{"path":"/bad/(.*)"},"rewrite":{"path":"$1"}}
+	]}`)
+
+	tests := []struct {
+		name string
+		path string
+		host string
+		res  api.ResultAction
+		// the expected path and host after rewriting
+		newPath string
+		newHost string
+	}{
+		{
+			name:    "path and host",
+			path:    "/legacy/orders/list%20all?page=2",
+			host:    "example.com",
+			res:     api.Continue,
+			newPath: "/list%20all?page=2",
+			newHost: "orders.internal",
+		},
+		{
+			name:    "strip query",
+			path:    "/search?q=1",
+			host:    "example.com",
+			res:     api.Continue,
+			newPath: "/query",
+			newHost: "example.com",
+		},
+		{
+			name:    "not matched",
+			path:    "/search/x?q=1",
+			host:    "example.com",
+			res:     api.Continue,
+			newPath: "/search/x?q=1",
+			newHost: "example.com",
+		},
+		{
+			name:    "invalid path",
+			path:    "/bad/x",
+			host:    "example.com",
+			res:     &api.LocalResponse{Code: 500},
+			newPath: "/bad/x",
+			newHost: "example.com",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := factory(conf, envoy.NewFilterCallbackHandler())
+			hdr := envoy.NewRequestHeaderMap(http.Header{
+				":path":      []string{tt.path},
+				":authority": []string{tt.host},
+			})
+			assert.Equal(t, tt.res, f.DecodeHeaders(hdr, true))
+			assert.Equal(t, tt.newPath, hdr.Path())
+			assert.Equal(t, tt.newHost, hdr.Host())
+		})
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package waf
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	coreruleset "github.com/corazawaf/coraza-coreruleset"
+	"github.com/corazawaf/coraza/v3"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/plugins"
+	"mosn.io/htnn/types/plugins/waf"
+)
+
+func init() {
+	plugins.RegisterHttpPlugin(waf.Name, &plugin{})
+}
+
+type plugin struct {
+	waf.Plugin
+}
+
+func (p *plugin) Factory() api.FilterFactory {
+	return factory
+}
+
+func (p *plugin) Config() api.PluginConfig {
+	return &config{}
+}
+
+// rootFS serves the bundled OWASP CRS files whose names start with `@`, and the other files
+// from the OS filesystem.
+type rootFS struct{}
+
+func bundledName(name string) (string, bool) {
+	idx := strings.Index(name, "@")
+	if idx == -1 {
+		return "", false
+	}
+	return name[idx:], true
+}
+
+func (rootFS) Open(name string) (fs.File, error) {
+	if n, ok := bundledName(name); ok {
+		return coreruleset.FS.Open(n)
+	}
+	return os.Open(name)
+}
+
+func (rootFS) ReadFile(name string) ([]byte, error) {
+	if n, ok := bundledName(name); ok {
+		return fs.ReadFile(coreruleset.FS, n)
+	}
+	return os.ReadFile(name)
+}
+
+func (rootFS) Glob(pattern string) ([]string, error) {
+	if n, ok := bundledName(pattern); ok {
+		return fs.Glob(coreruleset.FS, n)
+	}
+	return filepath.Glob(pattern)
+}
+
+type config struct {
+	waf.Config
+
+	waf coraza.WAF
+}
+
+func (conf *config) Init(cb api.ConfigCallbackHandler) error {
+	directives := strings.Join(conf.Directives, "\n")
+	if conf.DetectionOnly {
+		// the last one wins
+		directives += "\nSecRuleEngine DetectionOnly"
+	}
+
+	w, err := coraza.NewWAF(coraza.NewWAFConfig().
+		WithRootFS(rootFS{}).
+		WithDirectives(directives))
+	if err != nil {
+		return err
+	}
+	conf.waf = w
+	return nil
+}
@@ -0,0 +1,76 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package waf
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestConfig(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{
+			name:  "empty",
+			input: `{}`,
+			err:   "invalid Config.Directives: value must contain at least 1 item(s)",
+		},
+		{
+			name:  "invalid directive",
+			input: `{"directives":["SecUnknown On"]}`,
+			err:   "unknown directive",
+		},
+		{
+			name:  "missing file",
+			input: `{"directives":["Include /nonexistent/rules.conf"]}`,
+			err:   "failed to readfile",
+		},
+		{
+			name:  "custom rule",
+			input: `{"directives":["SecRuleEngine On","SecRule ARGS:id \"@eq 0\" \"id:1,phase:1,deny,status:403\""]}`,
+		},
+		{
+			name: "crs",
+			input: `{"directives":[
+				"Include @coraza.conf-recommended",
+				"Include @crs-setup.conf.example",
+				"Include @owasp_crs/*.conf"
+			],"detectionOnly":true}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := &config{}
+			err := protojson.Unmarshal([]byte(tt.input), conf)
+			if err == nil {
+				err = conf.Validate()
+			}
+			if err == nil {
+				err = conf.Init(nil)
+			}
+			if tt.err == "" {
+				assert.Nil(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
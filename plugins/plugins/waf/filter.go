@@ -0,0 +1,201 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package waf
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/corazawaf/coraza/v3/types"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+)
+
+func factory(c interface{}, callbacks api.FilterCallbackHandler) api.Filter {
+	return &filter{
+		callbacks: callbacks,
+		config:    c.(*config),
+	}
+}
+
+type filter struct {
+	api.PassThroughFilter
+
+	callbacks api.FilterCallbackHandler
+	config    *config
+
+	tx     types.Transaction
+	method string
+	uri    string
+}
+
+type matchedRule struct {
+	ID       int      `json:"id"`
+	Severity string   `json:"severity,omitempty"`
+	Message  string   `json:"message"`
+	Data     string   `json:"data,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
+}
+
+type report struct {
+	TransactionID string        `json:"transaction_id"`
+	ClientIP      string        `json:"client_ip"`
+	Method        string        `json:"method"`
+	URI           string        `json:"uri"`
+	Interrupted   bool          `json:"interrupted"`
+	DetectionOnly bool          `json:"detection_only,omitempty"`
+	RuleIDs       []int         `json:"rule_ids"`
+	Rules         []matchedRule `json:"rules"`
+}
+
+// finish logs the matched rules and releases the transaction
+func (f *filter) finish() {
+	tx := f.tx
+	if tx == nil {
+		return
+	}
+	f.tx = nil
+
+	rep := report{
+		TransactionID: tx.ID(),
+		ClientIP:      f.callbacks.StreamInfo().DownstreamRemoteParsedAddress().IP,
+		Method:        f.method,
+		URI:           f.uri,
+		Interrupted:   tx.IsInterrupted(),
+		DetectionOnly: f.config.DetectionOnly,
+	}
+	for _, mr := range tx.MatchedRules() {
+		// skip the rules marked as `nolog` or without message, like the rules which only set variables
+		if l, ok := mr.(interface{ Log() bool }); ok && !l.Log() {
+			continue
+		}
+		if mr.Message() == "" {
+			continue
+		}
+		rule := mr.Rule()
+		rep.RuleIDs = append(rep.RuleIDs, rule.ID())
+		rep.Rules = append(rep.Rules, matchedRule{
+			ID:       rule.ID(),
+			Severity: rule.Severity().String(),
+			Message:  mr.Message(),
+			Data:     mr.Data(),
+			Tags:     rule.Tags(),
+		})
+	}
+	if len(rep.Rules) > 0 {
+		b, _ := json.Marshal(rep)
+		api.LogWarnf("waf: matched rules: %s", b)
+	}
+
+	tx.ProcessLogging()
+	if err := tx.Close(); err != nil {
+		api.LogErrorf("waf: failed to close transaction: %v", err)
+	}
+}
+
+func (f *filter) interrupt(it *types.Interruption) api.ResultAction {
+	f.finish()
+
+	code := it.Status
+	if it.Action == "redirect" {
+		if code < 300 || code >= 400 {
+			code = http.StatusFound
+		}
+		return &api.LocalResponse{
+			Code:   code,
+			Header: http.Header{"Location": []string{it.Data}},
+		}
+	}
+
+	if code == 0 {
+		code = http.StatusForbidden
+	}
+	return &api.LocalResponse{Code: code}
+}
+
+func (f *filter) DecodeHeaders(headers api.RequestHeaderMap, endStream bool) api.ResultAction {
+	var tx types.Transaction
+	if id, ok := headers.Get("x-request-id"); ok {
+		tx = f.config.waf.NewTransactionWithID(id)
+	} else {
+		tx = f.config.waf.NewTransaction()
+	}
+	if tx.IsRuleEngineOff() {
+		_ = tx.Close()
+		return api.Continue
+	}
+	f.tx = tx
+	f.method = headers.Method()
+	f.uri = headers.Path()
+
+	addr := f.callbacks.StreamInfo().DownstreamRemoteParsedAddress()
+	tx.ProcessConnection(addr.IP, addr.Port, "", 0)
+	proto, _ := f.callbacks.StreamInfo().Protocol()
+	tx.ProcessURI(f.uri, f.method, proto)
+	host := headers.Host()
+	tx.AddRequestHeader("Host", host)
+	tx.SetServerName(host)
+	headers.Range(func(k, v string) bool {
+		if k[0] != ':' {
+			tx.AddRequestHeader(k, v)
+		}
+		return true
+	})
+
+	if it := tx.ProcessRequestHeaders(); it != nil {
+		return f.interrupt(it)
+	}
+
+	if !endStream && tx.IsRequestBodyAccessible() {
+		return api.WaitAllData
+	}
+	return f.processRequestBody()
+}
+
+func (f *filter) processRequestBody() api.ResultAction {
+	// the rules in phase 2 are evaluated even if there is no body, for example, the blocking
+	// evaluation of the OWASP CRS
+	it, err := f.tx.ProcessRequestBody()
+	if err != nil {
+		api.LogErrorf("waf: failed to process request body: %v", err)
+	} else if it != nil {
+		return f.interrupt(it)
+	}
+
+	f.finish()
+	return api.Continue
+}
+
+func (f *filter) DecodeRequest(headers api.RequestHeaderMap, buf api.BufferInstance, trailers api.RequestTrailerMap) api.ResultAction {
+	if f.tx == nil {
+		return api.Continue
+	}
+
+	if buf != nil && buf.Len() > 0 {
+		it, _, err := f.tx.WriteRequestBody(buf.Bytes())
+		if err != nil {
+			api.LogErrorf("waf: failed to write request body: %v", err)
+		} else if it != nil {
+			return f.interrupt(it)
+		}
+	}
+	return f.processRequestBody()
+}
+
+func (f *filter) OnLog(reqHeaders api.RequestHeaderMap, reqTrailers api.RequestTrailerMap,
+	respHeaders api.ResponseHeaderMap, respTrailers api.ResponseTrailerMap) {
+	// release the transaction if the request is terminated before the body is received
+	f.finish()
+}
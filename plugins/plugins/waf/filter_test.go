@@ -0,0 +1,249 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package waf
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/agiledragon/gomonkey/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/plugins/tests/pkg/envoy"
+)
+
+const crsDirectives = `
+	"Include @coraza.conf-recommended",
+	"Include @crs-setup.conf.example",
+	"Include @owasp_crs/*.conf",
+	"SecRuleEngine On"
+`
+
+func newFilter(t *testing.T, input string) api.Filter {
+	conf := &config{}
+	require.Nil(t, protojson.Unmarshal([]byte(input), conf))
+	require.Nil(t, conf.Validate())
+	require.Nil(t, conf.Init(nil))
+
+	cb := envoy.NewFilterCallbackHandler()
+	cb.SetStreamInfo(&streamInfo{})
+	return factory(conf, cb)
+}
+
+type streamInfo struct {
+	envoy.StreamInfo
+}
+
+func (i *streamInfo) Protocol() (string, bool) {
+	return "HTTP/1.1", true
+}
+
+func captureReports(t *testing.T) (*[]report, *gomonkey.Patches) {
+	reports := []report{}
+	patches := gomonkey.ApplyFunc(api.LogWarnf, func(format string, args ...any) {
+		msg := fmt.Sprintf(format, args...)
+		prefix := "waf: matched rules: "
+		if strings.HasPrefix(msg, prefix) {
+			var rep report
+			require.Nil(t, json.Unmarshal([]byte(msg[len(prefix):]), &rep))
+			reports = append(reports, rep)
+		}
+	})
+	return &reports, patches
+}
+
+func TestCustomRules(t *testing.T) {
+	conf := `{"directives":[
+		"SecRuleEngine On",
+		"SecRequestBodyAccess On",
+		"SecRule REQUEST_HEADERS:x-attack \"@streq 1\" \"id:101,phase:1,log,deny,status:401,msg:'attack header',severity:CRITICAL,tag:'test'\"",
+		"SecRule ARGS:debug \"@streq 1\" \"id:102,phase:1,log,redirect:https://example.com/,msg:'debug'\"",
+		"SecRule ARGS_POST \"@contains evil\" \"id:103,phase:2,deny,msg:'evil body'\"",
+		"SecRule REQUEST_METHOD \"@streq DELETE\" \"id:104,phase:2,deny,status:405,msg:'no delete'\""
+	]}`
+
+	tests := []struct {
+		name    string
+		header  http.Header
+		method  string
+		path    string
+		body    string
+		code    int
+		ruleIDs []int
+	}{
+		{
+			name:   "pass",
+			header: http.Header{},
+			path:   "/",
+		},
+		{
+			name:    "deny by header",
+			header:  http.Header{"X-Attack": []string{"1"}},
+			path:    "/",
+			code:    401,
+			ruleIDs: []int{101},
+		},
+		{
+			name:    "redirect",
+			header:  http.Header{},
+			path:    "/?debug=1",
+			code:    302,
+			ruleIDs: []int{102},
+		},
+		{
+			name:    "deny by body",
+			header:  http.Header{"Content-Type": []string{"application/x-www-form-urlencoded"}},
+			method:  "POST",
+			path:    "/",
+			body:    "a=evil",
+			code:    403,
+			ruleIDs: []int{103},
+		},
+		{
+			name:    "phase 2 without body",
+			header:  http.Header{},
+			method:  "DELETE",
+			path:    "/",
+			code:    405,
+			ruleIDs: []int{104},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reports, patches := captureReports(t)
+			defer patches.Reset()
+
+			f := newFilter(t, conf)
+			method := tt.method
+			if method == "" {
+				method = "GET"
+			}
+			tt.header.Set(":method", method)
+			tt.header.Set(":path", tt.path)
+			tt.header.Set(":authority", "localhost")
+			hdr := envoy.NewRequestHeaderMap(tt.header)
+
+			res := f.DecodeHeaders(hdr, tt.body == "")
+			if tt.body != "" {
+				require.Equal(t, api.WaitAllData, res)
+				res = f.DecodeRequest(hdr, envoy.NewBufferInstance([]byte(tt.body)), nil)
+			}
+
+			if tt.code == 0 {
+				assert.Equal(t, api.Continue, res)
+				assert.Empty(t, *reports)
+				return
+			}
+
+			lr, ok := res.(*api.LocalResponse)
+			require.True(t, ok, res)
+			assert.Equal(t, tt.code, lr.Code)
+			if tt.code == 302 {
+				assert.Equal(t, "https://example.com/", lr.Header.Get("Location"))
+			}
+
+			require.Len(t, *reports, 1)
+			rep := (*reports)[0]
+			assert.True(t, rep.Interrupted)
+			assert.Equal(t, tt.ruleIDs, rep.RuleIDs)
+			assert.Equal(t, method, rep.Method)
+			assert.Equal(t, tt.path, rep.URI)
+			assert.Equal(t, "183.128.130.43", rep.ClientIP)
+
+			// the transaction is released only once
+			f.OnLog(hdr, nil, nil, nil)
+			assert.Len(t, *reports, 1)
+		})
+	}
+}
+
+func TestCRS(t *testing.T) {
+	reports, patches := captureReports(t)
+	defer patches.Reset()
+
+	f := newFilter(t, `{"directives":[`+crsDirectives+`]}`)
+	hdr := envoy.NewRequestHeaderMap(http.Header{
+		":method":      []string{"GET"},
+		":path":        []string{"/?id=1%27%20OR%20%271%27=%271"},
+		":authority":   []string{"localhost"},
+		"User-Agent":   []string{"curl/8.0"},
+		"Accept":       []string{"*/*"},
+		"X-Request-Id": []string{"req-1"},
+	})
+	res := f.DecodeHeaders(hdr, true)
+	lr, ok := res.(*api.LocalResponse)
+	require.True(t, ok, res)
+	assert.Equal(t, 403, lr.Code)
+
+	require.Len(t, *reports, 1)
+	rep := (*reports)[0]
+	assert.Equal(t, "req-1", rep.TransactionID)
+	// SQL injection
+	assert.Contains(t, rep.RuleIDs, 942100)
+	// blocking evaluation
+	assert.Contains(t, rep.RuleIDs, 949110)
+
+	// legal request
+	*reports = (*reports)[:0]
+	f = newFilter(t, `{"directives":[`+crsDirectives+`]}`)
+	hdr = envoy.NewRequestHeaderMap(http.Header{
+		":method":    []string{"GET"},
+		":path":      []string{"/?id=1"},
+		":authority": []string{"localhost"},
+		"User-Agent": []string{"curl/8.0"},
+		"Accept":     []string{"*/*"},
+	})
+	assert.Equal(t, api.Continue, f.DecodeHeaders(hdr, true))
+	assert.Empty(t, *reports)
+}
+
+func TestDetectionOnly(t *testing.T) {
+	reports, patches := captureReports(t)
+	defer patches.Reset()
+
+	f := newFilter(t, `{"directives":[`+crsDirectives+`],"detectionOnly":true}`)
+	hdr := envoy.NewRequestHeaderMap(http.Header{
+		":method":    []string{"GET"},
+		":path":      []string{"/?q=%3Cscript%3Ealert(1)%3C/script%3E"},
+		":authority": []string{"localhost"},
+		"User-Agent": []string{"curl/8.0"},
+		"Accept":     []string{"*/*"},
+	})
+	assert.Equal(t, api.Continue, f.DecodeHeaders(hdr, true))
+
+	require.Len(t, *reports, 1)
+	rep := (*reports)[0]
+	assert.False(t, rep.Interrupted)
+	assert.True(t, rep.DetectionOnly)
+	// XSS
+	assert.Contains(t, rep.RuleIDs, 941100)
+}
+
+func TestRuleEngineOff(t *testing.T) {
+	f := newFilter(t, `{"directives":["SecRuleEngine Off","SecRule ARGS \"@rx .\" \"id:1,phase:1,deny\""]}`)
+	hdr := envoy.NewRequestHeaderMap(http.Header{
+		":method": []string{"POST"},
+		":path":   []string{"/?a=1"},
+	})
+	assert.Equal(t, api.Continue, f.DecodeHeaders(hdr, false))
+	assert.Equal(t, api.Continue, f.DecodeRequest(hdr, envoy.NewBufferInstance([]byte("a")), nil))
+}
@@ -0,0 +1,228 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook_signature
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"os"
+	"strings"
+	"text/template"
+	"time"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/plugins"
+	"mosn.io/htnn/types/plugins/webhook_signature"
+)
+
+const (
+	defaultTolerance = 5 * time.Minute
+)
+
+func init() {
+	plugins.RegisterHttpPlugin(webhook_signature.Name, &plugin{})
+}
+
+type plugin struct {
+	webhook_signature.Plugin
+}
+
+func (p *plugin) Factory() api.FilterFactory {
+	return factory
+}
+
+func (p *plugin) Config() api.PluginConfig {
+	return &config{}
+}
+
+type payloadData struct {
+	Timestamp string
+	Body      string
+}
+
+type config struct {
+	webhook_signature.CustomConfig
+
+	secrets   [][]byte
+	tolerance time.Duration
+
+	hash            func() hash.Hash
+	signatureHeader string
+	timestampHeader string
+	// parseSignature extracts the timestamp and the signatures from the signature header
+	parseSignature func(s string) (string, [][]byte)
+	payload        func(timestamp string, body []byte) ([]byte, error)
+}
+
+func readSecret(s *webhook_signature.Secret) ([]byte, error) {
+	switch src := s.Source.(type) {
+	case *webhook_signature.Secret_Inline:
+		return []byte(src.Inline), nil
+	case *webhook_signature.Secret_Env:
+		v := os.Getenv(src.Env)
+		if v == "" {
+			return nil, fmt.Errorf("secret not found in the environment variable %s", src.Env)
+		}
+		return []byte(v), nil
+	case *webhook_signature.Secret_File:
+		b, err := os.ReadFile(src.File)
+		if err != nil {
+			return nil, err
+		}
+		// the trailing newline is likely added by the editor or `echo`
+		b = bytes.TrimRight(b, "\r\n")
+		if len(b) == 0 {
+			return nil, fmt.Errorf("empty secret in the file %s", src.File)
+		}
+		return b, nil
+	}
+	return nil, errors.New("unknown secret source")
+}
+
+func decoder(encoding webhook_signature.Encoding) func(s string) ([]byte, error) {
+	if encoding == webhook_signature.Encoding_BASE64 {
+		return base64.StdEncoding.DecodeString
+	}
+	return hex.DecodeString
+}
+
+// prefixedSignature parses the signature like `sha256=<hex>`
+func prefixedSignature(prefix string, decode func(s string) ([]byte, error)) func(s string) (string, [][]byte) {
+	return func(s string) (string, [][]byte) {
+		sig, ok := strings.CutPrefix(s, prefix)
+		if !ok {
+			return "", nil
+		}
+		b, err := decode(sig)
+		if err != nil || len(b) == 0 {
+			return "", nil
+		}
+		return "", [][]byte{b}
+	}
+}
+
+// stripeSignature parses the signature like `t=<timestamp>,v1=<hex>,v1=<hex>`.
+// There may be multiple v1 signatures during the secret rotation.
+func stripeSignature(s string) (string, [][]byte) {
+	var ts string
+	var sigs [][]byte
+	for _, item := range strings.Split(s, ",") {
+		k, v, _ := strings.Cut(strings.TrimSpace(item), "=")
+		switch k {
+		case "t":
+			ts = v
+		case "v1":
+			b, err := hex.DecodeString(v)
+			if err == nil && len(b) > 0 {
+				sigs = append(sigs, b)
+			}
+		}
+	}
+	return ts, sigs
+}
+
+func joinPayload(prefix string, sep string) func(timestamp string, body []byte) ([]byte, error) {
+	return func(timestamp string, body []byte) ([]byte, error) {
+		b := make([]byte, 0, len(prefix)+len(timestamp)+len(sep)+len(body))
+		b = append(b, prefix...)
+		b = append(b, timestamp...)
+		b = append(b, sep...)
+		return append(b, body...), nil
+	}
+}
+
+func bodyPayload(_ string, body []byte) ([]byte, error) {
+	return body, nil
+}
+
+func templatePayload(tmpl *template.Template) func(timestamp string, body []byte) ([]byte, error) {
+	return func(timestamp string, body []byte) ([]byte, error) {
+		var buf bytes.Buffer
+		err := tmpl.Execute(&buf, &payloadData{
+			Timestamp: timestamp,
+			Body:      string(body),
+		})
+		if err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+}
+
+func (conf *config) initGeneric(g *webhook_signature.Generic) error {
+	switch g.Algorithm {
+	case webhook_signature.Algorithm_HMAC_SHA1:
+		conf.hash = sha1.New
+	case webhook_signature.Algorithm_HMAC_SHA512:
+		conf.hash = sha512.New
+	default:
+		conf.hash = sha256.New
+	}
+	conf.signatureHeader = g.SignatureHeader
+	conf.timestampHeader = g.TimestampHeader
+	conf.parseSignature = prefixedSignature(g.SignaturePrefix, decoder(g.Encoding))
+	conf.payload = bodyPayload
+	if g.Payload != "" {
+		tmpl, err := template.New("").Parse(g.Payload)
+		if err != nil {
+			return err
+		}
+		conf.payload = templatePayload(tmpl)
+	}
+	return nil
+}
+
+func (conf *config) Init(cb api.ConfigCallbackHandler) error {
+	conf.secrets = make([][]byte, 0, len(conf.Secrets))
+	for _, s := range conf.Secrets {
+		secret, err := readSecret(s)
+		if err != nil {
+			return err
+		}
+		conf.secrets = append(conf.secrets, secret)
+	}
+
+	conf.tolerance = defaultTolerance
+	if conf.Tolerance != nil {
+		conf.tolerance = conf.Tolerance.AsDuration()
+	}
+
+	conf.hash = sha256.New
+	switch conf.Provider {
+	case webhook_signature.Provider_GITHUB:
+		conf.signatureHeader = "x-hub-signature-256"
+		conf.parseSignature = prefixedSignature("sha256=", hex.DecodeString)
+		conf.payload = bodyPayload
+	case webhook_signature.Provider_STRIPE:
+		conf.signatureHeader = "stripe-signature"
+		conf.parseSignature = stripeSignature
+		conf.payload = joinPayload("", ".")
+	case webhook_signature.Provider_SLACK:
+		conf.signatureHeader = "x-slack-signature"
+		conf.timestampHeader = "x-slack-request-timestamp"
+		conf.parseSignature = prefixedSignature("v0=", hex.DecodeString)
+		conf.payload = joinPayload("v0:", ":")
+	default:
+		return conf.initGeneric(conf.Generic)
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook_signature
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestConfig(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{
+			name:  "no secret",
+			input: `{"provider":"GITHUB"}`,
+			err:   "invalid Config.Secrets: value must contain between 1 and 8 items, inclusive",
+		},
+		{
+			name:  "empty secret",
+			input: `{"provider":"GITHUB","secrets":[{}]}`,
+			err:   "value is required",
+		},
+		{
+			name:  "generic required",
+			input: `{"secrets":[{"inline":"secret"}]}`,
+			err:   "generic is required with the GENERIC provider",
+		},
+		{
+			name:  "generic with preset",
+			input: `{"provider":"SLACK","secrets":[{"inline":"secret"}],"generic":{"signatureHeader":"x-signature"}}`,
+			err:   "generic should only be configured with the GENERIC provider",
+		},
+		{
+			name:  "invalid template",
+			input: `{"secrets":[{"inline":"secret"}],"generic":{"signatureHeader":"x-signature","payload":"{{.Body"}}`,
+			err:   "invalid payload template",
+		},
+		{
+			name:  "bad tolerance",
+			input: `{"provider":"STRIPE","secrets":[{"inline":"secret"}],"tolerance":"0.5s"}`,
+			err:   "invalid Config.Tolerance: value must be greater than or equal to 1s",
+		},
+		{
+			name:  "pass",
+			input: `{"secrets":[{"inline":"secret"}],"generic":{"signatureHeader":"x-signature","payload":"{{.Timestamp}}.{{.Body}}"}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := &config{}
+			err := protojson.Unmarshal([]byte(tt.input), conf)
+			if err == nil {
+				err = conf.Validate()
+			}
+			if tt.err == "" {
+				assert.Nil(t, err)
+				assert.Nil(t, conf.Init(nil))
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestSecretSource(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "secret")
+	require.Nil(t, os.WriteFile(file, []byte("from-file\n"), 0600))
+	t.Setenv("WEBHOOK_SECRET", "from-env")
+
+	conf := &config{}
+	input := `{"provider":"GITHUB","tolerance":"60s","secrets":[{"inline":"inline"},{"env":"WEBHOOK_SECRET"},{"file":"` + file + `"}]}`
+	require.Nil(t, protojson.Unmarshal([]byte(input), conf))
+	require.Nil(t, conf.Validate())
+	require.Nil(t, conf.Init(nil))
+	assert.Equal(t, [][]byte{[]byte("inline"), []byte("from-env"), []byte("from-file")}, conf.secrets)
+	assert.Equal(t, time.Minute, conf.tolerance)
+
+	for _, input := range []string{
+		`{"provider":"GITHUB","secrets":[{"env":"WEBHOOK_SECRET_NOT_FOUND"}]}`,
+		`{"provider":"GITHUB","secrets":[{"file":"` + filepath.Join(dir, "not_found") + `"}]}`,
+	} {
+		conf := &config{}
+		require.Nil(t, protojson.Unmarshal([]byte(input), conf))
+		require.Nil(t, conf.Validate())
+		assert.NotNil(t, conf.Init(nil))
+	}
+}
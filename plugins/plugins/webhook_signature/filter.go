@@ -0,0 +1,115 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook_signature
+
+import (
+	"crypto/hmac"
+	"strconv"
+	"time"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/types/plugins/webhook_signature"
+)
+
+func factory(c interface{}, callbacks api.FilterCallbackHandler) api.Filter {
+	return &filter{
+		callbacks: callbacks,
+		config:    c.(*config),
+	}
+}
+
+type filter struct {
+	api.PassThroughFilter
+
+	callbacks api.FilterCallbackHandler
+	config    *config
+
+	timestamp  string
+	signatures [][]byte
+}
+
+func reject(msg string) api.ResultAction {
+	return &api.LocalResponse{Code: 401, Msg: msg}
+}
+
+func (f *filter) checkTimestamp() bool {
+	ts, err := strconv.ParseInt(f.timestamp, 10, 64)
+	if err != nil {
+		return false
+	}
+	diff := time.Since(time.Unix(ts, 0))
+	if diff < 0 {
+		diff = -diff
+	}
+	return diff <= f.config.tolerance
+}
+
+func (f *filter) DecodeHeaders(headers api.RequestHeaderMap, endStream bool) api.ResultAction {
+	config := f.config
+	sig, ok := headers.Get(config.signatureHeader)
+	if !ok {
+		return reject("missing signature")
+	}
+
+	f.timestamp, f.signatures = config.parseSignature(sig)
+	if len(f.signatures) == 0 {
+		return reject("invalid signature")
+	}
+
+	if config.timestampHeader != "" {
+		f.timestamp, _ = headers.Get(config.timestampHeader)
+	}
+	if config.timestampHeader != "" || config.Provider == webhook_signature.Provider_STRIPE {
+		// check the timestamp before reading the body, so the replayed requests are rejected early
+		if !f.checkTimestamp() {
+			api.LogInfof("webhookSignature: invalid timestamp %q", f.timestamp)
+			return reject("invalid timestamp")
+		}
+	}
+
+	if endStream {
+		return f.verify(nil)
+	}
+	return api.WaitAllData
+}
+
+func (f *filter) verify(body []byte) api.ResultAction {
+	config := f.config
+	payload, err := config.payload(f.timestamp, body)
+	if err != nil {
+		api.LogErrorf("webhookSignature: failed to build the payload: %v", err)
+		return &api.LocalResponse{Code: 500}
+	}
+
+	for _, secret := range config.secrets {
+		mac := hmac.New(config.hash, secret)
+		mac.Write(payload)
+		expected := mac.Sum(nil)
+		for _, sig := range f.signatures {
+			if hmac.Equal(sig, expected) {
+				return api.Continue
+			}
+		}
+	}
+	return reject("invalid signature")
+}
+
+func (f *filter) DecodeRequest(headers api.RequestHeaderMap, buf api.BufferInstance, trailers api.RequestTrailerMap) api.ResultAction {
+	var body []byte
+	if buf != nil {
+		body = buf.Bytes()
+	}
+	return f.verify(body)
+}
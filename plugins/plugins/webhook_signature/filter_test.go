@@ -0,0 +1,218 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook_signature
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"hash"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/plugins/tests/pkg/envoy"
+)
+
+func newConfig(t *testing.T, input string) *config {
+	conf := &config{}
+	require.Nil(t, protojson.Unmarshal([]byte(input), conf))
+	require.Nil(t, conf.Validate())
+	require.Nil(t, conf.Init(nil))
+	return conf
+}
+
+func sign(h func() hash.Hash, secret string, payload string) []byte {
+	mac := hmac.New(h, []byte(secret))
+	mac.Write([]byte(payload))
+	return mac.Sum(nil)
+}
+
+func hexSign(secret string, payload string) string {
+	return hex.EncodeToString(sign(sha256.New, secret, payload))
+}
+
+func run(conf *config, header http.Header, body string) api.ResultAction {
+	f := factory(conf, envoy.NewFilterCallbackHandler())
+	hdr := envoy.NewRequestHeaderMap(header)
+	if body == "" {
+		return f.DecodeHeaders(hdr, true)
+	}
+	res := f.DecodeHeaders(hdr, false)
+	if res != api.WaitAllData {
+		return res
+	}
+	return f.DecodeRequest(hdr, envoy.NewBufferInstance([]byte(body)), nil)
+}
+
+func TestGitHub(t *testing.T) {
+	conf := newConfig(t, `{"provider":"GITHUB","secrets":[{"inline":"old"},{"inline":"new"}]}`)
+	body := `{"action":"opened"}`
+
+	tests := []struct {
+		name   string
+		header http.Header
+		body   string
+		res    api.ResultAction
+	}{
+		{
+			name:   "missing signature",
+			header: http.Header{},
+			body:   body,
+			res:    &api.LocalResponse{Code: 401, Msg: "missing signature"},
+		},
+		{
+			name:   "bad format",
+			header: http.Header{"X-Hub-Signature-256": []string{hexSign("new", body)}},
+			body:   body,
+			res:    &api.LocalResponse{Code: 401, Msg: "invalid signature"},
+		},
+		{
+			name:   "wrong secret",
+			header: http.Header{"X-Hub-Signature-256": []string{"sha256=" + hexSign("other", body)}},
+			body:   body,
+			res:    &api.LocalResponse{Code: 401, Msg: "invalid signature"},
+		},
+		{
+			name:   "body changed",
+			header: http.Header{"X-Hub-Signature-256": []string{"sha256=" + hexSign("new", body)}},
+			body:   `{"action":"closed"}`,
+			res:    &api.LocalResponse{Code: 401, Msg: "invalid signature"},
+		},
+		{
+			name:   "pass",
+			header: http.Header{"X-Hub-Signature-256": []string{"sha256=" + hexSign("new", body)}},
+			body:   body,
+			res:    api.Continue,
+		},
+		{
+			name:   "pass with the old secret",
+			header: http.Header{"X-Hub-Signature-256": []string{"sha256=" + hexSign("old", body)}},
+			body:   body,
+			res:    api.Continue,
+		},
+		{
+			name:   "empty body",
+			header: http.Header{"X-Hub-Signature-256": []string{"sha256=" + hexSign("new", "")}},
+			res:    api.Continue,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.res, run(conf, tt.header, tt.body))
+		})
+	}
+}
+
+func TestStripe(t *testing.T) {
+	conf := newConfig(t, `{"provider":"STRIPE","secrets":[{"inline":"whsec_test"}]}`)
+	body := `{"type":"charge.succeeded"}`
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+	old := strconv.FormatInt(time.Now().Add(-10*time.Minute).Unix(), 10)
+
+	tests := []struct {
+		name string
+		sig  string
+		res  api.ResultAction
+	}{
+		{
+			name: "missing timestamp",
+			sig:  "v1=" + hexSign("whsec_test", now+"."+body),
+			res:  &api.LocalResponse{Code: 401, Msg: "invalid timestamp"},
+		},
+		{
+			name: "expired",
+			sig:  "t=" + old + ",v1=" + hexSign("whsec_test", old+"."+body),
+			res:  &api.LocalResponse{Code: 401, Msg: "invalid timestamp"},
+		},
+		{
+			name: "timestamp changed",
+			sig:  "t=" + now + ",v1=" + hexSign("whsec_test", old+"."+body),
+			res:  &api.LocalResponse{Code: 401, Msg: "invalid signature"},
+		},
+		{
+			name: "only v0",
+			sig:  "t=" + now + ",v0=" + hexSign("whsec_test", now+"."+body),
+			res:  &api.LocalResponse{Code: 401, Msg: "invalid signature"},
+		},
+		{
+			name: "pass",
+			sig:  "t=" + now + ",v1=" + hexSign("whsec_test", now+"."+body),
+			res:  api.Continue,
+		},
+		{
+			name: "multiple signatures",
+			sig:  "t=" + now + ",v1=" + hexSign("whsec_other", now+"."+body) + ",v1=" + hexSign("whsec_test", now+"."+body),
+			res:  api.Continue,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.res, run(conf, http.Header{"Stripe-Signature": []string{tt.sig}}, body))
+		})
+	}
+}
+
+func TestSlack(t *testing.T) {
+	conf := newConfig(t, `{"provider":"SLACK","secrets":[{"inline":"slack"}],"tolerance":"60s"}`)
+	body := "token=xyz&team_id=T1"
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+	future := strconv.FormatInt(time.Now().Add(2*time.Minute).Unix(), 10)
+
+	res := run(conf, http.Header{
+		"X-Slack-Signature":         []string{"v0=" + hexSign("slack", "v0:"+now+":"+body)},
+		"X-Slack-Request-Timestamp": []string{now},
+	}, body)
+	assert.Equal(t, api.Continue, res)
+
+	res = run(conf, http.Header{
+		"X-Slack-Signature":         []string{"v0=" + hexSign("slack", "v0:"+future+":"+body)},
+		"X-Slack-Request-Timestamp": []string{future},
+	}, body)
+	assert.Equal(t, &api.LocalResponse{Code: 401, Msg: "invalid timestamp"}, res)
+
+	res = run(conf, http.Header{
+		"X-Slack-Signature": []string{"v0=" + hexSign("slack", "v0:"+now+":"+body)},
+	}, body)
+	assert.Equal(t, &api.LocalResponse{Code: 401, Msg: "invalid timestamp"}, res)
+}
+
+func TestGeneric(t *testing.T) {
+	conf := newConfig(t, `{"secrets":[{"inline":"generic"}],"generic":{
+		"signatureHeader":"x-signature","algorithm":"HMAC_SHA1","encoding":"BASE64",
+		"timestampHeader":"x-timestamp","payload":"{{.Timestamp}}\n{{.Body}}"}}`)
+	body := "hello"
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+	sig := base64.StdEncoding.EncodeToString(sign(sha1.New, "generic", now+"\n"+body))
+
+	res := run(conf, http.Header{"X-Signature": []string{sig}, "X-Timestamp": []string{now}}, body)
+	assert.Equal(t, api.Continue, res)
+
+	res = run(conf, http.Header{"X-Signature": []string{sig}, "X-Timestamp": []string{"yesterday"}}, body)
+	assert.Equal(t, &api.LocalResponse{Code: 401, Msg: "invalid timestamp"}, res)
+
+	res = run(conf, http.Header{"X-Signature": []string{"!" + sig}, "X-Timestamp": []string{now}}, body)
+	assert.Equal(t, &api.LocalResponse{Code: 401, Msg: "invalid signature"}, res)
+}
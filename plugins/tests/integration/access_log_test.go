@@ -0,0 +1,104 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mosn.io/htnn/api/pkg/filtermanager"
+	"mosn.io/htnn/api/plugins/tests/integration/control_plane"
+	"mosn.io/htnn/api/plugins/tests/integration/data_plane"
+)
+
+func TestAccessLog(t *testing.T) {
+	dp, err := data_plane.StartDataPlane(t, &data_plane.Option{})
+	if err != nil {
+		t.Fatalf("failed to start data plane: %v", err)
+		return
+	}
+	defer dp.Stop()
+
+	// /tmp is shared with the data plane
+	dir, err := os.MkdirTemp("/tmp", "htnn-access-log")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "access.log")
+
+	tests := []struct {
+		name   string
+		config *filtermanager.FilterManagerConfig
+		run    func(t *testing.T)
+	}{
+		{
+			name: "file",
+			config: control_plane.NewSinglePluinConfig("accessLog", map[string]interface{}{
+				"fields": []interface{}{
+					map[string]interface{}{"name": "method", "builtin": "METHOD"},
+					map[string]interface{}{"name": "path", "builtin": "PATH"},
+					map[string]interface{}{"name": "code", "builtin": "RESPONSE_CODE"},
+					map[string]interface{}{"name": "duration", "builtin": "DURATION"},
+					map[string]interface{}{"name": "auth", "requestHeader": "authorization"},
+					map[string]interface{}{"name": "user", "requestHeader": "x-user"},
+				},
+				"file": map[string]interface{}{
+					"path": path,
+				},
+				"batch": map[string]interface{}{
+					"flushInterval": "0.1s",
+				},
+			}),
+			run: func(t *testing.T) {
+				hdr := http.Header{}
+				hdr.Set("authorization", "Basic xxx")
+				hdr.Set("x-user", "alice")
+				resp, err := dp.Get("/echo", hdr)
+				require.Nil(t, err)
+				assert.Equal(t, 200, resp.StatusCode)
+
+				var b []byte
+				require.Eventually(t, func() bool {
+					b, _ = os.ReadFile(path)
+					return len(b) > 0
+				}, 5*time.Second, 100*time.Millisecond)
+
+				lines := strings.Split(strings.TrimSpace(string(b)), "\n")
+				record := map[string]interface{}{}
+				require.Nil(t, json.Unmarshal([]byte(lines[len(lines)-1]), &record))
+				assert.Equal(t, "GET", record["method"])
+				assert.Equal(t, "/echo", record["path"])
+				assert.Equal(t, float64(200), record["code"])
+				assert.Equal(t, "***", record["auth"])
+				assert.Equal(t, "alice", record["user"])
+				assert.Greater(t, record["duration"], float64(0))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controlPlane.UseGoPluginConfig(t, tt.config, dp)
+			tt.run(t)
+		})
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"mosn.io/htnn/api/pkg/filtermanager"
+	"mosn.io/htnn/api/plugins/tests/integration/control_plane"
+	"mosn.io/htnn/api/plugins/tests/integration/data_plane"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	dp, err := data_plane.StartDataPlane(t, &data_plane.Option{})
+	if err != nil {
+		t.Fatalf("failed to start data plane: %v", err)
+		return
+	}
+	defer dp.Stop()
+
+	tests := []struct {
+		name   string
+		config *filtermanager.FilterManagerConfig
+		run    func(t *testing.T)
+	}{
+		{
+			name: "open and half-open",
+			config: control_plane.NewSinglePluinConfig("circuitBreaker", map[string]interface{}{
+				"key":              `request.path()`,
+				"failureThreshold": 2,
+				"failureStatus":    []int{404},
+				"openDuration":     "1s",
+				"localReply": map[string]interface{}{
+					"status": 429,
+					"body":   "circuit open",
+					"headers": []interface{}{
+						map[string]interface{}{
+							"key":   "content-type",
+							"value": "text/plain",
+						},
+					},
+				},
+			}),
+			run: func(t *testing.T) {
+				for i := 0; i < 2; i++ {
+					resp, _ := dp.Get("/not_found", nil)
+					assert.Equal(t, 404, resp.StatusCode)
+				}
+				resp, _ := dp.Get("/not_found", nil)
+				assert.Equal(t, 429, resp.StatusCode)
+				body, _ := io.ReadAll(resp.Body)
+				assert.Equal(t, "circuit open", string(body))
+
+				// other keys are not affected
+				resp, _ = dp.Get("/echo", nil)
+				assert.Equal(t, 200, resp.StatusCode)
+
+				time.Sleep(1 * time.Second)
+				// the probe fails and the breaker is open again
+				resp, _ = dp.Get("/not_found", nil)
+				assert.Equal(t, 404, resp.StatusCode)
+				resp, _ = dp.Get("/not_found", nil)
+				assert.Equal(t, 429, resp.StatusCode)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controlPlane.UseGoPluginConfig(t, tt.config, dp)
+			tt.run(t)
+		})
+	}
+}
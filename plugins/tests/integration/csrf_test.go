@@ -0,0 +1,99 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mosn.io/htnn/api/pkg/filtermanager"
+	"mosn.io/htnn/api/plugins/tests/integration/control_plane"
+	"mosn.io/htnn/api/plugins/tests/integration/data_plane"
+)
+
+func TestCSRF(t *testing.T) {
+	dp, err := data_plane.StartDataPlane(t, &data_plane.Option{})
+	if err != nil {
+		t.Fatalf("failed to start data plane: %v", err)
+		return
+	}
+	defer dp.Stop()
+
+	tests := []struct {
+		name   string
+		config *filtermanager.FilterManagerConfig
+		run    func(t *testing.T)
+	}{
+		{
+			name: "sanity",
+			config: control_plane.NewSinglePluinConfig("csrf", map[string]interface{}{
+				"secret":         "0123456789abcdef",
+				"trustedOrigins": []string{"https://app.example.com"},
+				"exemptPaths": []interface{}{
+					map[string]interface{}{"prefix": "/echo/webhook"},
+				},
+			}),
+			run: func(t *testing.T) {
+				resp, err := dp.Get("/echo", nil)
+				require.Nil(t, err)
+				assert.Equal(t, 200, resp.StatusCode)
+				cookies := resp.Cookies()
+				require.Len(t, cookies, 1)
+				token := cookies[0].Value
+
+				hdr := http.Header{}
+				hdr.Set("Origin", "https://app.example.com")
+				hdr.Set("Cookie", "htnn_csrf_token="+token)
+				hdr.Set("X-Csrf-Token", token)
+				resp, err = dp.Post("/echo", hdr, strings.NewReader("a"))
+				require.Nil(t, err)
+				assert.Equal(t, 200, resp.StatusCode)
+
+				hdr.Del("X-Csrf-Token")
+				hdr.Set("Content-Type", "application/x-www-form-urlencoded")
+				form := url.Values{"csrf_token": []string{token}}
+				resp, err = dp.Post("/echo", hdr, strings.NewReader(form.Encode()))
+				require.Nil(t, err)
+				assert.Equal(t, 200, resp.StatusCode)
+
+				resp, err = dp.Post("/echo", hdr, strings.NewReader("a=b"))
+				require.Nil(t, err)
+				assert.Equal(t, 403, resp.StatusCode)
+
+				hdr.Set("X-Csrf-Token", token)
+				hdr.Set("Origin", "https://evil.com")
+				resp, err = dp.Post("/echo", hdr, strings.NewReader("a"))
+				require.Nil(t, err)
+				assert.Equal(t, 403, resp.StatusCode)
+
+				resp, err = dp.Post("/echo/webhook", nil, strings.NewReader("a"))
+				require.Nil(t, err)
+				assert.NotEqual(t, 403, resp.StatusCode)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controlPlane.UseGoPluginConfig(t, tt.config, dp)
+			tt.run(t)
+		})
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mosn.io/htnn/api/pkg/filtermanager"
+	"mosn.io/htnn/api/pkg/filtermanager/model"
+	"mosn.io/htnn/api/plugins/tests/integration/control_plane"
+	"mosn.io/htnn/api/plugins/tests/integration/data_plane"
+)
+
+const dataMaskingRoute = `
+match:
+  path: /user
+direct_response:
+  status: 200
+  body:
+    inline_string: '{"name":"rick","phone":"13812345678","note":"id 110101199003077777"}'
+response_headers_to_add:
+  - header:
+      key: content-type
+      value: application/json
+`
+
+func TestDataMasking(t *testing.T) {
+	dp, err := data_plane.StartDataPlane(t, &data_plane.Option{
+		Bootstrap: data_plane.Bootstrap().AddBackendRoute(dataMaskingRoute).
+			AddConsumer("rick", map[string]interface{}{
+				"auth": map[string]interface{}{
+					"keyAuth": `{"key":"rick"}`,
+				},
+			}).
+			AddConsumer("morty", map[string]interface{}{
+				"auth": map[string]interface{}{
+					"keyAuth": `{"key":"morty"}`,
+				},
+				"filters": map[string]interface{}{
+					"dataMasking": map[string]interface{}{
+						"config": `{"exempt":true}`,
+					},
+				},
+			}),
+	})
+	if err != nil {
+		t.Fatalf("failed to start data plane: %v", err)
+		return
+	}
+	defer dp.Stop()
+
+	rules := []interface{}{
+		map[string]interface{}{"path": "$.phone", "keepPrefix": 3, "keepSuffix": 4},
+		map[string]interface{}{"regex": `\d{17}[\dX]`, "action": "REMOVE"},
+	}
+	keyAuth := &model.FilterConfig{
+		Name: "keyAuth",
+		Config: map[string]interface{}{
+			"keys": []interface{}{
+				map[string]interface{}{
+					"name": "Authorization",
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		config *filtermanager.FilterManagerConfig
+		run    func(t *testing.T)
+	}{
+		{
+			name: "buffered",
+			config: control_plane.NewPluinConfig([]*model.FilterConfig{
+				{
+					Name: "dataMasking",
+					Config: map[string]interface{}{
+						"rules": rules,
+					},
+				},
+				keyAuth,
+			}),
+			run: func(t *testing.T) {
+				resp, err := dp.Get("/user", http.Header{"Authorization": []string{"rick"}})
+				require.Nil(t, err)
+				assert.Equal(t, 200, resp.StatusCode)
+				body, _ := io.ReadAll(resp.Body)
+				assert.Equal(t, `{"name":"rick","note":"id ","phone":"138****5678"}`, string(body))
+
+				// exempted consumer
+				resp, err = dp.Get("/user", http.Header{"Authorization": []string{"morty"}})
+				require.Nil(t, err)
+				body, _ = io.ReadAll(resp.Body)
+				assert.Equal(t, `{"name":"rick","phone":"13812345678","note":"id 110101199003077777"}`, string(body))
+			},
+		},
+		{
+			name: "streaming",
+			config: control_plane.NewPluinConfig([]*model.FilterConfig{
+				{
+					Name: "dataMasking",
+					Config: map[string]interface{}{
+						"rules": []interface{}{
+							map[string]interface{}{"regex": `1[3-9]\d{9}`, "action": "HASH"},
+						},
+						"streaming": map[string]interface{}{},
+					},
+				},
+				keyAuth,
+			}),
+			run: func(t *testing.T) {
+				resp, err := dp.Get("/user", http.Header{"Authorization": []string{"rick"}})
+				require.Nil(t, err)
+				assert.Equal(t, 200, resp.StatusCode)
+				body, _ := io.ReadAll(resp.Body)
+				assert.Equal(t, `{"name":"rick","phone":"38aed9048140b0e437ea81461d9ea4524169f6795004da120bcf7d41894e4d15","note":"id 110101199003077777"}`, string(body))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controlPlane.UseGoPluginConfig(t, tt.config, dp)
+			tt.run(t)
+		})
+	}
+}
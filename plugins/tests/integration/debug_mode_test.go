@@ -15,11 +15,20 @@
 package integration
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"mosn.io/htnn/api/pkg/filtermanager"
 	"mosn.io/htnn/api/pkg/filtermanager/model"
@@ -218,3 +227,132 @@ func TestDebugModeSlowLogWithFiltersFromConsumer(t *testing.T) {
 		})
 	}
 }
+
+func TestDebugModeTrigger(t *testing.T) {
+	dp, err := data_plane.StartDataPlane(t, &data_plane.Option{
+		NoErrorLogCheck: true,
+		ExpectLogPattern: []string{
+			`slow log report:.+"consumer":"rick".+"executed_plugins":\[.+"name":"keyAuth"`,
+		},
+		Bootstrap: data_plane.Bootstrap().AddConsumer("rick", map[string]interface{}{
+			"auth": map[string]interface{}{
+				"keyAuth": `{"key":"rick"}`,
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("failed to start data plane: %v", err)
+		return
+	}
+	defer dp.Stop()
+
+	sign := func(secret string) string {
+		ts := strconv.FormatInt(time.Now().Unix(), 10)
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write([]byte(ts))
+		return ts + ":" + hex.EncodeToString(mac.Sum(nil))
+	}
+
+	config := control_plane.NewPluinConfig([]*model.FilterConfig{
+		{
+			Name: "debugMode",
+			Config: map[string]interface{}{
+				"slowLog": map[string]interface{}{
+					"threshold": "0.0001s",
+				},
+				"trigger": map[string]interface{}{
+					"hmac": map[string]interface{}{
+						"secret": "xxx",
+					},
+				},
+				"report": map[string]interface{}{},
+			},
+		},
+		{
+			Name: "keyAuth",
+			Config: map[string]interface{}{
+				"keys": []interface{}{
+					map[string]interface{}{
+						"name": "Authorization",
+					},
+				},
+			},
+		},
+	})
+	controlPlane.UseGoPluginConfig(t, config, dp)
+
+	hdr := http.Header{"Authorization": []string{"rick"}}
+	resp, err := dp.Head("/echo", hdr)
+	require.Nil(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, "", resp.Header.Get("x-htnn-debug-report"))
+
+	hdr.Set("x-htnn-debug", sign("yyy"))
+	resp, err = dp.Head("/echo", hdr)
+	require.Nil(t, err)
+	assert.Equal(t, "", resp.Header.Get("x-htnn-debug-report"))
+
+	hdr.Set("x-htnn-debug", sign("xxx"))
+	resp, err = dp.Get("/echo", hdr)
+	require.Nil(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	report := map[string]interface{}{}
+	require.Nil(t, json.Unmarshal([]byte(resp.Header.Get("x-htnn-debug-report")), &report))
+	assert.Equal(t, "rick", report["consumer"])
+	assert.Equal(t, []interface{}{"debugMode", "keyAuth"}, report["plugins"])
+	assert.NotEmpty(t, report["executed_plugins"])
+	// the signature is not sent to the upstream
+	assert.Equal(t, "", resp.Header.Get("Echo-X-Htnn-Debug"))
+}
+
+func TestDebugModeSlowLogExport(t *testing.T) {
+	dp, err := data_plane.StartDataPlane(t, &data_plane.Option{
+		NoErrorLogCheck: true,
+	})
+	if err != nil {
+		t.Fatalf("failed to start data plane: %v", err)
+		return
+	}
+	defer dp.Stop()
+
+	// /tmp is shared with the data plane
+	dir, err := os.MkdirTemp("/tmp", "htnn-slow-log")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "slow.log")
+
+	config := control_plane.NewSinglePluinConfig("debugMode", map[string]interface{}{
+		"slowLog": map[string]interface{}{
+			"threshold":   "0.0001s",
+			"maxBodySize": 4,
+			"sink": map[string]interface{}{
+				"file": map[string]interface{}{
+					"path": path,
+				},
+			},
+			"batch": map[string]interface{}{
+				"flushInterval": "0.1s",
+			},
+		},
+	})
+	controlPlane.UseGoPluginConfig(t, config, dp)
+
+	hdr := http.Header{}
+	hdr.Set("authorization", "Basic xxx")
+	resp, err := dp.Post("/echo", hdr, strings.NewReader("hello"))
+	require.Nil(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+
+	var b []byte
+	require.Eventually(t, func() bool {
+		b, _ = os.ReadFile(path)
+		return len(b) > 0
+	}, 5*time.Second, 100*time.Millisecond)
+
+	lines := strings.Split(strings.TrimSpace(string(b)), "\n")
+	report := map[string]interface{}{}
+	require.Nil(t, json.Unmarshal([]byte(lines[len(lines)-1]), &report))
+	req := report["request"].(map[string]interface{})
+	assert.Equal(t, "hell", req["body"])
+	assert.Equal(t, []interface{}{"***"}, req["headers"].(map[string]interface{})["authorization"])
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"mosn.io/htnn/api/pkg/filtermanager"
+	"mosn.io/htnn/api/pkg/filtermanager/model"
 	"mosn.io/htnn/api/plugins/tests/integration/control_plane"
 	"mosn.io/htnn/api/plugins/tests/integration/data_plane"
 )
@@ -113,6 +114,49 @@ func TestExtAuth(t *testing.T) {
 				assert.Equal(t, "", resp.Header.Get("body"))
 			},
 		},
+		{
+			name: "with body, limited",
+			config: control_plane.NewSinglePluinConfig("extAuth", map[string]interface{}{
+				"httpService": map[string]interface{}{
+					"url":             "http://127.0.0.1:10001/ext_auth",
+					"withRequestBody": true,
+					"maxRequestBytes": 3,
+				},
+			}),
+			run: func(t *testing.T) {
+				resp, _ := dp.Post("/echo", nil, strings.NewReader("anything"))
+				assert.Equal(t, 413, resp.StatusCode)
+				resp, _ = dp.Post("/echo", nil, strings.NewReader("any"))
+				assert.Equal(t, 403, resp.StatusCode)
+				assert.Equal(t, "any", resp.Header.Get("body"))
+			},
+		},
+		{
+			name: "with partial body",
+			config: control_plane.NewPluinConfig([]*model.FilterConfig{
+				{
+					Name: "bufferLimit",
+					Config: map[string]interface{}{
+						"maxRequestBytes":     3,
+						"allowPartialMessage": true,
+					},
+				},
+				{
+					Name: "extAuth",
+					Config: map[string]interface{}{
+						"httpService": map[string]interface{}{
+							"url":             "http://127.0.0.1:10001/ext_auth",
+							"withRequestBody": true,
+						},
+					},
+				},
+			}),
+			run: func(t *testing.T) {
+				resp, _ := dp.Post("/echo", nil, strings.NewReader("anything"))
+				assert.Equal(t, 403, resp.StatusCode)
+				assert.Equal(t, "any", resp.Header.Get("body"))
+			},
+		},
 	}
 
 	for _, tt := range tests {
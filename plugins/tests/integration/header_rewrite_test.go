@@ -0,0 +1,88 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"mosn.io/htnn/api/pkg/filtermanager"
+	"mosn.io/htnn/api/plugins/tests/integration/control_plane"
+	"mosn.io/htnn/api/plugins/tests/integration/data_plane"
+)
+
+func TestHeaderRewrite(t *testing.T) {
+	dp, err := data_plane.StartDataPlane(t, &data_plane.Option{})
+	if err != nil {
+		t.Fatalf("failed to start data plane: %v", err)
+		return
+	}
+	defer dp.Stop()
+
+	tests := []struct {
+		name   string
+		config *filtermanager.FilterManagerConfig
+		run    func(t *testing.T)
+	}{
+		{
+			name: "rewrite",
+			config: control_plane.NewSinglePluinConfig("headerRewrite", map[string]interface{}{
+				"requestHeaders": map[string]interface{}{
+					"remove": []interface{}{
+						map[string]interface{}{"prefix": "x-internal-"},
+					},
+					"rename": []interface{}{
+						map[string]interface{}{"from": "x-token", "to": "authorization"},
+					},
+					"set": []interface{}{
+						map[string]interface{}{"key": "x-method", "value": `{{ .Property "request.method" }}`},
+					},
+				},
+				"queryParams": map[string]interface{}{
+					"add": []interface{}{
+						map[string]interface{}{"key": "token", "value": `{{ .Header "x-token" }}`},
+					},
+				},
+				"responseHeaders": map[string]interface{}{
+					"set": []interface{}{
+						map[string]interface{}{"key": "x-path", "value": `{{ .Header ":path" }}`},
+					},
+				},
+			}),
+			run: func(t *testing.T) {
+				hdr := http.Header{}
+				hdr.Set("X-Internal-Id", "1")
+				hdr.Set("X-Token", "t")
+				resp, _ := dp.Get("/echo?a=1", hdr)
+				assert.Equal(t, 200, resp.StatusCode)
+				assert.Equal(t, "", resp.Header.Get("Echo-X-Internal-Id"))
+				assert.Equal(t, "", resp.Header.Get("Echo-X-Token"))
+				assert.Equal(t, "t", resp.Header.Get("Echo-Authorization"))
+				assert.Equal(t, "GET", resp.Header.Get("Echo-X-Method"))
+				assert.Equal(t, "/echo?a=1&token=t", resp.Header.Get("Echo-Path"))
+				assert.Equal(t, "/echo?a=1&token=t", resp.Header.Get("X-Path"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controlPlane.UseGoPluginConfig(t, tt.config, dp)
+			tt.run(t)
+		})
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"mosn.io/htnn/api/pkg/filtermanager"
+	"mosn.io/htnn/api/plugins/tests/integration/control_plane"
+	"mosn.io/htnn/api/plugins/tests/integration/data_plane"
+)
+
+func TestIPRestriction(t *testing.T) {
+	dp, err := data_plane.StartDataPlane(t, &data_plane.Option{})
+	if err != nil {
+		t.Fatalf("failed to start data plane: %v", err)
+		return
+	}
+	defer dp.Stop()
+
+	tests := []struct {
+		name   string
+		config *filtermanager.FilterManagerConfig
+		run    func(t *testing.T)
+	}{
+		{
+			name: "deny remote address",
+			config: control_plane.NewSinglePluinConfig("ipRestriction", map[string]interface{}{
+				"deny":    []interface{}{"0.0.0.0/0", "::/0"},
+				"message": "ip denied",
+			}),
+			run: func(t *testing.T) {
+				resp, _ := dp.Get("/echo", nil)
+				assert.Equal(t, 403, resp.StatusCode)
+			},
+		},
+		{
+			name: "x-forwarded-for",
+			config: control_plane.NewSinglePluinConfig("ipRestriction", map[string]interface{}{
+				"allow": []interface{}{"1.2.3.0/24"},
+				"xForwardedFor": map[string]interface{}{
+					"trustedProxyCount": 1,
+				},
+				"status": 401,
+			}),
+			run: func(t *testing.T) {
+				hdr := http.Header{}
+				hdr.Set("X-Forwarded-For", "1.2.3.4")
+				resp, _ := dp.Get("/echo", hdr)
+				assert.Equal(t, 200, resp.StatusCode)
+
+				hdr.Set("X-Forwarded-For", "1.2.3.4, 5.6.7.8")
+				resp, _ = dp.Get("/echo", hdr)
+				assert.Equal(t, 401, resp.StatusCode)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controlPlane.UseGoPluginConfig(t, tt.config, dp)
+			tt.run(t)
+		})
+	}
+}
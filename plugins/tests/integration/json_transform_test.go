@@ -0,0 +1,119 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mosn.io/htnn/api/pkg/filtermanager"
+	"mosn.io/htnn/api/plugins/tests/integration/control_plane"
+	"mosn.io/htnn/api/plugins/tests/integration/data_plane"
+)
+
+const jsonTransformRoute = `
+match:
+  path: /json
+direct_response:
+  status: 200
+  body:
+    inline_string: '{"id":1,"password":"secret"}'
+response_headers_to_add:
+  - header:
+      key: content-type
+      value: application/json
+`
+
+func TestJSONTransform(t *testing.T) {
+	dp, err := data_plane.StartDataPlane(t, &data_plane.Option{
+		Bootstrap: data_plane.Bootstrap().AddBackendRoute(jsonTransformRoute),
+	})
+	if err != nil {
+		t.Fatalf("failed to start data plane: %v", err)
+		return
+	}
+	defer dp.Stop()
+
+	tests := []struct {
+		name   string
+		config *filtermanager.FilterManagerConfig
+		run    func(t *testing.T)
+	}{
+		{
+			name: "request",
+			config: control_plane.NewSinglePluinConfig("jsonTransform", map[string]interface{}{
+				"request": map[string]interface{}{
+					"remove": []string{"$.internal"},
+					"rename": []interface{}{
+						map[string]interface{}{"from": "$.user.name", "to": "$.username"},
+					},
+					"set": []interface{}{
+						map[string]interface{}{"path": "$.source", "value": "gateway"},
+					},
+				},
+			}),
+			run: func(t *testing.T) {
+				hdr := http.Header{}
+				hdr.Set("Content-Type", "application/json")
+				resp, err := dp.Post("/echo", hdr, strings.NewReader(`{"internal":1,"user":{"name":"a"}}`))
+				require.Nil(t, err)
+				assert.Equal(t, 200, resp.StatusCode)
+				body, _ := io.ReadAll(resp.Body)
+				assert.Equal(t, `{"source":"gateway","user":{},"username":"a"}`, string(body))
+
+				// not JSON
+				hdr.Set("Content-Type", "text/plain")
+				resp, err = dp.Post("/echo", hdr, strings.NewReader(`{"internal":1}`))
+				require.Nil(t, err)
+				body, _ = io.ReadAll(resp.Body)
+				assert.Equal(t, `{"internal":1}`, string(body))
+			},
+		},
+		{
+			name: "response",
+			config: control_plane.NewSinglePluinConfig("jsonTransform", map[string]interface{}{
+				"response": map[string]interface{}{
+					"remove":   []string{"$.password"},
+					"template": `{"code":0,"data":{{ json .Body }}}`,
+				},
+			}),
+			run: func(t *testing.T) {
+				resp, err := dp.Get("/json", nil)
+				require.Nil(t, err)
+				assert.Equal(t, 200, resp.StatusCode)
+				body, _ := io.ReadAll(resp.Body)
+				var res map[string]interface{}
+				require.Nil(t, json.Unmarshal(body, &res))
+				assert.Equal(t, map[string]interface{}{
+					"code": float64(0),
+					"data": map[string]interface{}{"id": float64(1)},
+				}, res)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controlPlane.UseGoPluginConfig(t, tt.config, dp)
+			tt.run(t)
+		})
+	}
+}
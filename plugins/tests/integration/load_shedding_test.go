@@ -0,0 +1,82 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"mosn.io/htnn/api/pkg/filtermanager"
+	"mosn.io/htnn/api/pkg/filtermanager/model"
+	"mosn.io/htnn/api/plugins/tests/integration/control_plane"
+	"mosn.io/htnn/api/plugins/tests/integration/data_plane"
+)
+
+func TestLoadShedding(t *testing.T) {
+	dp, err := data_plane.StartDataPlane(t, &data_plane.Option{
+		NoErrorLogCheck: true,
+		ExpectLogPattern: []string{
+			`slow log report:.+"plugin_reports":\{"loadShedding":\{"limit":1,"inflight":1,"shed":false\}\}`,
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to start data plane: %v", err)
+		return
+	}
+	defer dp.Stop()
+
+	tests := []struct {
+		name   string
+		config *filtermanager.FilterManagerConfig
+		run    func(t *testing.T)
+	}{
+		{
+			name: "sanity",
+			config: control_plane.NewPluinConfig([]*model.FilterConfig{
+				{
+					Name: "debugMode",
+					Config: map[string]interface{}{
+						"slowLog": map[string]interface{}{
+							"threshold": "0.0001s",
+						},
+					},
+				},
+				{
+					Name: "loadShedding",
+					Config: map[string]interface{}{
+						"initialLimit": 1,
+						"maxLimit":     1,
+						"lowPriority":  `request.header("x-priority") == "low"`,
+					},
+				},
+			}),
+			run: func(t *testing.T) {
+				// the concurrency is released after each request
+				for i := 0; i < 3; i++ {
+					resp, _ := dp.Head("/echo", nil)
+					assert.Equal(t, 200, resp.StatusCode)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controlPlane.UseGoPluginConfig(t, tt.config, dp)
+			tt.run(t)
+		})
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mosn.io/htnn/api/pkg/filtermanager"
+	"mosn.io/htnn/api/plugins/tests/integration/control_plane"
+	"mosn.io/htnn/api/plugins/tests/integration/data_plane"
+)
+
+func TestMockResponse(t *testing.T) {
+	dp, err := data_plane.StartDataPlane(t, &data_plane.Option{})
+	if err != nil {
+		t.Fatalf("failed to start data plane: %v", err)
+		return
+	}
+	defer dp.Stop()
+
+	tests := []struct {
+		name   string
+		config *filtermanager.FilterManagerConfig
+		run    func(t *testing.T)
+	}{
+		{
+			name: "sanity",
+			config: control_plane.NewSinglePluinConfig("mockResponse", map[string]interface{}{
+				"rules": []interface{}{
+					map[string]interface{}{
+						"match": map[string]interface{}{
+							"methods": []string{"GET"},
+							"headers": []interface{}{
+								map[string]interface{}{"name": "x-mock"},
+							},
+						},
+						"response": map[string]interface{}{
+							"status": 201,
+							"headers": []interface{}{
+								map[string]interface{}{"key": "content-type", "value": "application/json"},
+							},
+							"template": `{"user":"{{.Header "x-user"}}"}`,
+							"delay":    "0.2s",
+						},
+					},
+				},
+			}),
+			run: func(t *testing.T) {
+				hdr := http.Header{}
+				hdr.Set("x-mock", "true")
+				hdr.Set("x-user", "alice")
+				start := time.Now()
+				resp, err := dp.Get("/echo", hdr)
+				require.Nil(t, err)
+				assert.GreaterOrEqual(t, time.Since(start), 200*time.Millisecond)
+				assert.Equal(t, 201, resp.StatusCode)
+				assert.Equal(t, "application/json", resp.Header.Get("content-type"))
+				body, _ := io.ReadAll(resp.Body)
+				assert.Equal(t, `{"user":"alice"}`, string(body))
+
+				// pass through
+				resp, err = dp.Get("/echo", nil)
+				require.Nil(t, err)
+				assert.Equal(t, 200, resp.StatusCode)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controlPlane.UseGoPluginConfig(t, tt.config, dp)
+			tt.run(t)
+		})
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mosn.io/htnn/api/pkg/filtermanager"
+	"mosn.io/htnn/api/pkg/filtermanager/model"
+	"mosn.io/htnn/api/plugins/tests/integration/control_plane"
+	"mosn.io/htnn/api/plugins/tests/integration/data_plane"
+	"mosn.io/htnn/api/plugins/tests/integration/helper"
+)
+
+func TestQuota(t *testing.T) {
+	dp, err := data_plane.StartDataPlane(t, &data_plane.Option{
+		Bootstrap: data_plane.Bootstrap().AddConsumer("tom", map[string]interface{}{
+			"auth": map[string]interface{}{
+				"keyAuth": `{"key":"tom"}`,
+			},
+			"filters": map[string]interface{}{
+				"quota": map[string]interface{}{
+					"config": `{"address":"redis:6379","prefix":"htnn_quota_test","quotas":[{"limit":2,"softLimit":1},{"limit":10,"period":"MONTH"}]}`,
+				},
+			},
+		}).AddConsumer("jerry", map[string]interface{}{
+			"auth": map[string]interface{}{
+				"keyAuth": `{"key":"jerry"}`,
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("failed to start data plane: %v", err)
+		return
+	}
+	defer dp.Stop()
+
+	helper.WaitServiceUp(t, ":6379", "redis")
+
+	// the counters live until the end of the calendar period, so clean them up before testing
+	ctx := context.Background()
+	client := redis.NewClient(&redis.Options{Addr: "localhost:6379"})
+	keys, err := client.Keys(ctx, "htnn_quota_test|*").Result()
+	require.NoError(t, err)
+	if len(keys) > 0 {
+		require.NoError(t, client.Del(ctx, keys...).Err())
+	}
+
+	tests := []struct {
+		name   string
+		config *filtermanager.FilterManagerConfig
+		run    func(t *testing.T)
+	}{
+		{
+			name: "quota from consumer",
+			config: control_plane.NewPluinConfig([]*model.FilterConfig{
+				{
+					Name: "keyAuth",
+					Config: map[string]interface{}{
+						"keys": []interface{}{
+							map[string]interface{}{
+								"name": "Authorization",
+							},
+						},
+					},
+				},
+			}),
+			run: func(t *testing.T) {
+				hdr := http.Header{"Authorization": []string{"tom"}}
+				resp, _ := dp.Get("/echo", hdr)
+				assert.Equal(t, 200, resp.StatusCode)
+				assert.Equal(t, "2", resp.Header.Get("X-Ratelimit-Limit"))
+				assert.Equal(t, "1", resp.Header.Get("X-Ratelimit-Remaining"))
+				assert.Equal(t, `2;w=86400;name="day", `, resp.Header.Get("Ratelimit-Policy")[:21])
+				assert.Equal(t, "", resp.Header.Get("X-Quota-Warning"))
+
+				resp, _ = dp.Get("/echo", hdr)
+				assert.Equal(t, 200, resp.StatusCode)
+				assert.Equal(t, "0", resp.Header.Get("X-Ratelimit-Remaining"))
+				assert.Equal(t, "day;soft=1", resp.Header.Get("X-Quota-Warning"))
+
+				resp, _ = dp.Get("/echo", hdr)
+				assert.Equal(t, 429, resp.StatusCode)
+				assert.Equal(t, "true", resp.Header.Get("X-Envoy-Ratelimited"))
+
+				// consumer without quota
+				resp, _ = dp.Get("/echo", http.Header{"Authorization": []string{"jerry"}})
+				assert.Equal(t, 200, resp.StatusCode)
+				assert.Equal(t, "", resp.Header.Get("X-Ratelimit-Limit"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controlPlane.UseGoPluginConfig(t, tt.config, dp)
+			tt.run(t)
+		})
+	}
+}
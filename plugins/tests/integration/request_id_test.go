@@ -0,0 +1,113 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mosn.io/htnn/api/pkg/filtermanager"
+	"mosn.io/htnn/api/pkg/filtermanager/model"
+	"mosn.io/htnn/api/plugins/tests/integration/control_plane"
+	"mosn.io/htnn/api/plugins/tests/integration/data_plane"
+)
+
+func TestRequestID(t *testing.T) {
+	dp, err := data_plane.StartDataPlane(t, &data_plane.Option{})
+	if err != nil {
+		t.Fatalf("failed to start data plane: %v", err)
+		return
+	}
+	defer dp.Stop()
+
+	tests := []struct {
+		name   string
+		config *filtermanager.FilterManagerConfig
+		run    func(t *testing.T)
+	}{
+		{
+			name: "honour incoming request ID",
+			config: control_plane.NewSinglePluinConfig("requestId", map[string]interface{}{
+				"header": "x-htnn-request-id",
+			}),
+			run: func(t *testing.T) {
+				hdr := http.Header{}
+				hdr.Set("x-htnn-request-id", "abc-123")
+				resp, err := dp.Get("/echo", hdr)
+				require.Nil(t, err)
+				assert.Equal(t, 200, resp.StatusCode)
+				assert.Equal(t, "abc-123", resp.Header.Get("Echo-X-Htnn-Request-Id"))
+				assert.Equal(t, "abc-123", resp.Header.Get("X-Htnn-Request-Id"))
+			},
+		},
+		{
+			name: "generate",
+			config: control_plane.NewSinglePluinConfig("requestId", map[string]interface{}{
+				"header":         "x-htnn-request-id",
+				"generator":      "ULID",
+				"responseHeader": "x-id",
+			}),
+			run: func(t *testing.T) {
+				hdr := http.Header{}
+				hdr.Set("x-htnn-request-id", "abc 123")
+				resp, err := dp.Get("/echo", hdr)
+				require.Nil(t, err)
+				assert.Equal(t, 200, resp.StatusCode)
+				id := resp.Header.Get("Echo-X-Htnn-Request-Id")
+				assert.Regexp(t, `^[0-9A-Z]{26}$`, id)
+				assert.Equal(t, id, resp.Header.Get("X-Id"))
+			},
+		},
+		{
+			name: "local reply",
+			config: control_plane.NewPluinConfig([]*model.FilterConfig{
+				{
+					Name: "requestId",
+					Config: map[string]interface{}{
+						"header": "x-htnn-request-id",
+					},
+				},
+				{
+					Name: "keyAuth",
+					Config: map[string]interface{}{
+						"keys": []interface{}{
+							map[string]interface{}{
+								"name": "Authorization",
+							},
+						},
+					},
+				},
+			}),
+			run: func(t *testing.T) {
+				hdr := http.Header{}
+				hdr.Set("x-htnn-request-id", "abc-123")
+				resp, err := dp.Get("/echo", hdr)
+				require.Nil(t, err)
+				assert.Equal(t, 401, resp.StatusCode)
+				assert.Equal(t, "abc-123", resp.Header.Get("X-Htnn-Request-Id"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controlPlane.UseGoPluginConfig(t, tt.config, dp)
+			tt.run(t)
+		})
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mosn.io/htnn/api/pkg/filtermanager"
+	"mosn.io/htnn/api/plugins/tests/integration/control_plane"
+	"mosn.io/htnn/api/plugins/tests/integration/data_plane"
+)
+
+const petstoreDoc = `
+openapi: 3.0.3
+info:
+  title: Petstore
+  version: 1.0.0
+paths:
+  /echo:
+    get:
+      parameters:
+        - name: limit
+          in: query
+          schema:
+            type: integer
+            maximum: 100
+      responses:
+        "200":
+          description: ok
+    post:
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [name]
+              properties:
+                name:
+                  type: string
+      responses:
+        "200":
+          description: ok
+`
+
+func TestRequestValidation(t *testing.T) {
+	dp, err := data_plane.StartDataPlane(t, &data_plane.Option{})
+	if err != nil {
+		t.Fatalf("failed to start data plane: %v", err)
+		return
+	}
+	defer dp.Stop()
+
+	tests := []struct {
+		name   string
+		config *filtermanager.FilterManagerConfig
+		run    func(t *testing.T)
+	}{
+		{
+			name: "openapi",
+			config: control_plane.NewSinglePluinConfig("requestValidation", map[string]interface{}{
+				"openapi": map[string]interface{}{
+					"inline":                 petstoreDoc,
+					"rejectUnknownOperation": true,
+				},
+			}),
+			run: func(t *testing.T) {
+				resp, _ := dp.Get("/echo?limit=10", nil)
+				assert.Equal(t, 200, resp.StatusCode)
+
+				resp, _ = dp.Get("/echo?limit=1000", nil)
+				assert.Equal(t, 400, resp.StatusCode)
+				assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+				body, _ := io.ReadAll(resp.Body)
+				var res map[string]interface{}
+				require.Nil(t, json.Unmarshal(body, &res))
+				assert.Equal(t, []interface{}{
+					map[string]interface{}{
+						"in":      "query",
+						"name":    "limit",
+						"message": "number must be at most 100",
+					},
+				}, res["violations"])
+
+				hdr := http.Header{}
+				hdr.Set("Content-Type", "application/json")
+				resp, _ = dp.Post("/echo", hdr, strings.NewReader(`{"name":"kitty"}`))
+				assert.Equal(t, 200, resp.StatusCode)
+				resp, _ = dp.Post("/echo", hdr, strings.NewReader(`{"age":1}`))
+				assert.Equal(t, 400, resp.StatusCode)
+
+				resp, _ = dp.Get("/unknown", nil)
+				assert.Equal(t, 404, resp.StatusCode)
+				resp, _ = dp.Delete("/echo", nil)
+				assert.Equal(t, 405, resp.StatusCode)
+			},
+		},
+		{
+			name: "json schema",
+			config: control_plane.NewSinglePluinConfig("requestValidation", map[string]interface{}{
+				"jsonSchema": map[string]interface{}{
+					"headers": `{"type":"object","required":["x-tenant"],"properties":{"x-tenant":{"type":"string"}}}`,
+				},
+			}),
+			run: func(t *testing.T) {
+				hdr := http.Header{}
+				hdr.Set("X-Tenant", "a")
+				resp, _ := dp.Get("/echo", hdr)
+				assert.Equal(t, 200, resp.StatusCode)
+				resp, _ = dp.Get("/echo", nil)
+				assert.Equal(t, 400, resp.StatusCode)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controlPlane.UseGoPluginConfig(t, tt.config, dp)
+			tt.run(t)
+		})
+	}
+}
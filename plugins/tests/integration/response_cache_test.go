@@ -0,0 +1,106 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mosn.io/htnn/api/pkg/filtermanager"
+	"mosn.io/htnn/api/plugins/tests/integration/control_plane"
+	"mosn.io/htnn/api/plugins/tests/integration/data_plane"
+	"mosn.io/htnn/api/plugins/tests/integration/helper"
+)
+
+func TestResponseCache(t *testing.T) {
+	dp, err := data_plane.StartDataPlane(t, &data_plane.Option{})
+	if err != nil {
+		t.Fatalf("failed to start data plane: %v", err)
+		return
+	}
+	defer dp.Stop()
+
+	helper.WaitServiceUp(t, ":6379", "redis")
+
+	ctx := context.Background()
+	client := redis.NewClient(&redis.Options{Addr: "localhost:6379"})
+	keys, err := client.Keys(ctx, "htnn_response_cache_test|*").Result()
+	require.NoError(t, err)
+	if len(keys) > 0 {
+		require.NoError(t, client.Del(ctx, keys...).Err())
+	}
+
+	run := func(t *testing.T, path string) {
+		resp, _ := dp.Get(path, http.Header{"X-Id": []string{"1"}})
+		assert.Equal(t, 200, resp.StatusCode)
+		assert.Equal(t, "MISS", resp.Header.Get("X-Cache"))
+		assert.Equal(t, "1", resp.Header.Get("Echo-X-Id"))
+
+		resp, _ = dp.Get(path, http.Header{"X-Id": []string{"2"}})
+		assert.Equal(t, 200, resp.StatusCode)
+		assert.Equal(t, "HIT", resp.Header.Get("X-Cache"))
+		// the cached response is returned
+		assert.Equal(t, "1", resp.Header.Get("Echo-X-Id"))
+
+		resp, _ = dp.Get(path, http.Header{"X-Id": []string{"3"}, "Cache-Control": []string{"no-cache"}})
+		assert.Equal(t, "MISS", resp.Header.Get("X-Cache"))
+		assert.Equal(t, "3", resp.Header.Get("Echo-X-Id"))
+
+		resp, _ = dp.Get(path, http.Header{"X-Id": []string{"4"}})
+		assert.Equal(t, "HIT", resp.Header.Get("X-Cache"))
+		assert.Equal(t, "3", resp.Header.Get("Echo-X-Id"))
+	}
+
+	tests := []struct {
+		name   string
+		config *filtermanager.FilterManagerConfig
+		run    func(t *testing.T)
+	}{
+		{
+			name: "memory",
+			config: control_plane.NewSinglePluinConfig("responseCache", map[string]interface{}{
+				"defaultTtl": "60s",
+			}),
+			run: func(t *testing.T) {
+				run(t, "/echo?cache=memory")
+			},
+		},
+		{
+			name: "redis",
+			config: control_plane.NewSinglePluinConfig("responseCache", map[string]interface{}{
+				"redis": map[string]interface{}{
+					"address": "redis:6379",
+					"prefix":  "htnn_response_cache_test",
+				},
+				"defaultTtl": "60s",
+			}),
+			run: func(t *testing.T) {
+				run(t, "/echo?cache=redis")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controlPlane.UseGoPluginConfig(t, tt.config, dp)
+			tt.run(t)
+		})
+	}
+}
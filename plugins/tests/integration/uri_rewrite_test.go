@@ -0,0 +1,95 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mosn.io/htnn/api/pkg/filtermanager"
+	"mosn.io/htnn/api/plugins/tests/integration/control_plane"
+	"mosn.io/htnn/api/plugins/tests/integration/data_plane"
+)
+
+func TestURIRewrite(t *testing.T) {
+	dp, err := data_plane.StartDataPlane(t, &data_plane.Option{})
+	if err != nil {
+		t.Fatalf("failed to start data plane: %v", err)
+		return
+	}
+	defer dp.Stop()
+
+	tests := []struct {
+		name   string
+		config *filtermanager.FilterManagerConfig
+		run    func(t *testing.T)
+	}{
+		{
+			name: "redirect",
+			config: control_plane.NewSinglePluinConfig("uriRewrite", map[string]interface{}{
+				"rules": []interface{}{
+					map[string]interface{}{
+						"match": map[string]interface{}{
+							"path": "/(e.+)",
+						},
+						"redirect": map[string]interface{}{
+							"status": 308,
+							"scheme": "https",
+							"path":   "/new/$1",
+						},
+					},
+				},
+			}),
+			run: func(t *testing.T) {
+				resp, err := dp.Get("/echo?a=1", nil)
+				require.Nil(t, err)
+				assert.Equal(t, 308, resp.StatusCode)
+				assert.Equal(t, "https://localhost:10000/new/echo?a=1", resp.Header.Get("location"))
+			},
+		},
+		{
+			name: "rewrite",
+			config: control_plane.NewSinglePluinConfig("uriRewrite", map[string]interface{}{
+				"rules": []interface{}{
+					map[string]interface{}{
+						"match": map[string]interface{}{
+							"path": "/echo",
+						},
+						"rewrite": map[string]interface{}{
+							"host": "rewritten.example.com",
+						},
+						"stripQuery": true,
+					},
+				},
+			}),
+			run: func(t *testing.T) {
+				resp, err := dp.Get("/echo?a=1", nil)
+				require.Nil(t, err)
+				assert.Equal(t, 200, resp.StatusCode)
+				assert.Equal(t, "/echo", resp.Header.Get("Echo-Path"))
+				assert.Equal(t, "rewritten.example.com", resp.Header.Get("Echo-Authority"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controlPlane.UseGoPluginConfig(t, tt.config, dp)
+			tt.run(t)
+		})
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mosn.io/htnn/api/pkg/filtermanager"
+	"mosn.io/htnn/api/plugins/tests/integration/control_plane"
+	"mosn.io/htnn/api/plugins/tests/integration/data_plane"
+)
+
+func TestWAF(t *testing.T) {
+	dp, err := data_plane.StartDataPlane(t, &data_plane.Option{
+		ExpectLogPattern: []string{
+			`waf: matched rules: .+"rule_ids":\[.*942100.*\]`,
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to start data plane: %v", err)
+		return
+	}
+	defer dp.Stop()
+
+	crs := []interface{}{
+		"Include @coraza.conf-recommended",
+		"Include @crs-setup.conf.example",
+		"Include @owasp_crs/*.conf",
+		"SecRuleEngine On",
+	}
+	tests := []struct {
+		name   string
+		config *filtermanager.FilterManagerConfig
+		run    func(t *testing.T)
+	}{
+		{
+			name: "crs",
+			config: control_plane.NewSinglePluinConfig("waf", map[string]interface{}{
+				"directives": crs,
+			}),
+			run: func(t *testing.T) {
+				resp, err := dp.Get("/echo?id=1", nil)
+				require.Nil(t, err)
+				assert.Equal(t, 200, resp.StatusCode)
+
+				resp, err = dp.Get("/echo?id=1%27%20OR%20%271%27=%271", nil)
+				require.Nil(t, err)
+				assert.Equal(t, 403, resp.StatusCode)
+
+				hdr := http.Header{}
+				hdr.Set("Content-Type", "application/x-www-form-urlencoded")
+				resp, err = dp.Post("/echo", hdr, strings.NewReader("q=<script>alert(1)</script>"))
+				require.Nil(t, err)
+				assert.Equal(t, 403, resp.StatusCode)
+			},
+		},
+		{
+			name: "detection only",
+			config: control_plane.NewSinglePluinConfig("waf", map[string]interface{}{
+				"directives":    crs,
+				"detectionOnly": true,
+			}),
+			run: func(t *testing.T) {
+				resp, err := dp.Get("/echo?id=1%27%20OR%20%271%27=%271", nil)
+				require.Nil(t, err)
+				assert.Equal(t, 200, resp.StatusCode)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controlPlane.UseGoPluginConfig(t, tt.config, dp)
+			tt.run(t)
+		})
+	}
+}
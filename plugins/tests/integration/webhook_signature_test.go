@@ -0,0 +1,113 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mosn.io/htnn/api/pkg/filtermanager"
+	"mosn.io/htnn/api/plugins/tests/integration/control_plane"
+	"mosn.io/htnn/api/plugins/tests/integration/data_plane"
+)
+
+func hmacSHA256Hex(secret string, payload string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(payload))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestWebhookSignature(t *testing.T) {
+	dp, err := data_plane.StartDataPlane(t, &data_plane.Option{
+		Envs: map[string]string{"WEBHOOK_SECRET": "from-env"},
+	})
+	if err != nil {
+		t.Fatalf("failed to start data plane: %v", err)
+		return
+	}
+	defer dp.Stop()
+
+	body := `{"action":"opened"}`
+	tests := []struct {
+		name   string
+		config *filtermanager.FilterManagerConfig
+		run    func(t *testing.T)
+	}{
+		{
+			name: "github",
+			config: control_plane.NewSinglePluinConfig("webhookSignature", map[string]interface{}{
+				"provider": "GITHUB",
+				"secrets": []interface{}{
+					map[string]interface{}{"env": "WEBHOOK_SECRET"},
+				},
+			}),
+			run: func(t *testing.T) {
+				hdr := http.Header{}
+				hdr.Set("X-Hub-Signature-256", "sha256="+hmacSHA256Hex("from-env", body))
+				resp, err := dp.Post("/echo", hdr, strings.NewReader(body))
+				require.Nil(t, err)
+				assert.Equal(t, 200, resp.StatusCode)
+
+				resp, err = dp.Post("/echo", hdr, strings.NewReader(body+" "))
+				require.Nil(t, err)
+				assert.Equal(t, 401, resp.StatusCode)
+
+				resp, err = dp.Post("/echo", nil, strings.NewReader(body))
+				require.Nil(t, err)
+				assert.Equal(t, 401, resp.StatusCode)
+			},
+		},
+		{
+			name: "stripe",
+			config: control_plane.NewSinglePluinConfig("webhookSignature", map[string]interface{}{
+				"provider": "STRIPE",
+				"secrets": []interface{}{
+					map[string]interface{}{"inline": "whsec_test"},
+				},
+				"tolerance": "60s",
+			}),
+			run: func(t *testing.T) {
+				now := strconv.FormatInt(time.Now().Unix(), 10)
+				hdr := http.Header{}
+				hdr.Set("Stripe-Signature", "t="+now+",v1="+hmacSHA256Hex("whsec_test", now+"."+body))
+				resp, err := dp.Post("/echo", hdr, strings.NewReader(body))
+				require.Nil(t, err)
+				assert.Equal(t, 200, resp.StatusCode)
+
+				old := strconv.FormatInt(time.Now().Add(-time.Hour).Unix(), 10)
+				hdr.Set("Stripe-Signature", "t="+old+",v1="+hmacSHA256Hex("whsec_test", old+"."+body))
+				resp, err = dp.Post("/echo", hdr, strings.NewReader(body))
+				require.Nil(t, err)
+				assert.Equal(t, 401, resp.StatusCode)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controlPlane.UseGoPluginConfig(t, tt.config, dp)
+			tt.run(t)
+		})
+	}
+}
@@ -0,0 +1,621 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0
+// 	protoc        v4.24.4
+// source: types/plugins/access_log/config.proto
+
+package access_log
+
+import (
+	reflect "reflect"
+	sync "sync"
+
+	_ "github.com/envoyproxy/protoc-gen-validate/validate"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+
+	v1 "mosn.io/htnn/types/plugins/api/v1"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Builtin int32
+
+const (
+	// The name of the authenticated consumer
+	Builtin_CONSUMER                  Builtin = 0
+	Builtin_RESPONSE_CODE             Builtin = 1
+	Builtin_RESPONSE_CODE_DETAILS     Builtin = 2
+	Builtin_ROUTE                     Builtin = 3
+	Builtin_METHOD                    Builtin = 4
+	Builtin_PATH                      Builtin = 5
+	Builtin_HOST                      Builtin = 6
+	Builtin_DOWNSTREAM_REMOTE_ADDRESS Builtin = 7
+	Builtin_UPSTREAM_REMOTE_ADDRESS   Builtin = 8
+	Builtin_UPSTREAM_CLUSTER          Builtin = 9
+	// The duration of the request, in seconds
+	Builtin_DURATION Builtin = 10
+	// All the request headers, as an object
+	Builtin_REQUEST_HEADERS Builtin = 11
+	// All the response headers, as an object
+	Builtin_RESPONSE_HEADERS Builtin = 12
+)
+
+// Enum value maps for Builtin.
+var (
+	Builtin_name = map[int32]string{
+		0:  "CONSUMER",
+		1:  "RESPONSE_CODE",
+		2:  "RESPONSE_CODE_DETAILS",
+		3:  "ROUTE",
+		4:  "METHOD",
+		5:  "PATH",
+		6:  "HOST",
+		7:  "DOWNSTREAM_REMOTE_ADDRESS",
+		8:  "UPSTREAM_REMOTE_ADDRESS",
+		9:  "UPSTREAM_CLUSTER",
+		10: "DURATION",
+		11: "REQUEST_HEADERS",
+		12: "RESPONSE_HEADERS",
+	}
+	Builtin_value = map[string]int32{
+		"CONSUMER":                  0,
+		"RESPONSE_CODE":             1,
+		"RESPONSE_CODE_DETAILS":     2,
+		"ROUTE":                     3,
+		"METHOD":                    4,
+		"PATH":                      5,
+		"HOST":                      6,
+		"DOWNSTREAM_REMOTE_ADDRESS": 7,
+		"UPSTREAM_REMOTE_ADDRESS":   8,
+		"UPSTREAM_CLUSTER":          9,
+		"DURATION":                  10,
+		"REQUEST_HEADERS":           11,
+		"RESPONSE_HEADERS":          12,
+	}
+)
+
+func (x Builtin) Enum() *Builtin {
+	p := new(Builtin)
+	*p = x
+	return p
+}
+
+func (x Builtin) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Builtin) Descriptor() protoreflect.EnumDescriptor {
+	return file_types_plugins_access_log_config_proto_enumTypes[0].Descriptor()
+}
+
+func (Builtin) Type() protoreflect.EnumType {
+	return &file_types_plugins_access_log_config_proto_enumTypes[0]
+}
+
+func (x Builtin) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Builtin.Descriptor instead.
+func (Builtin) EnumDescriptor() ([]byte, []int) {
+	return file_types_plugins_access_log_config_proto_rawDescGZIP(), []int{0}
+}
+
+type PluginState struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Namespace string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Key       string `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+}
+
+func (x *PluginState) Reset() {
+	*x = PluginState{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_types_plugins_access_log_config_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PluginState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PluginState) ProtoMessage() {}
+
+func (x *PluginState) ProtoReflect() protoreflect.Message {
+	mi := &file_types_plugins_access_log_config_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PluginState.ProtoReflect.Descriptor instead.
+func (*PluginState) Descriptor() ([]byte, []int) {
+	return file_types_plugins_access_log_config_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PluginState) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *PluginState) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+type Field struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The key of the field in the record
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Types that are assignable to Source:
+	//
+	//	*Field_RequestHeader
+	//	*Field_ResponseHeader
+	//	*Field_Property
+	//	*Field_Builtin
+	//	*Field_PluginState
+	Source isField_Source `protobuf_oneof:"source"`
+}
+
+func (x *Field) Reset() {
+	*x = Field{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_types_plugins_access_log_config_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Field) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Field) ProtoMessage() {}
+
+func (x *Field) ProtoReflect() protoreflect.Message {
+	mi := &file_types_plugins_access_log_config_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Field.ProtoReflect.Descriptor instead.
+func (*Field) Descriptor() ([]byte, []int) {
+	return file_types_plugins_access_log_config_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Field) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (m *Field) GetSource() isField_Source {
+	if m != nil {
+		return m.Source
+	}
+	return nil
+}
+
+func (x *Field) GetRequestHeader() string {
+	if x, ok := x.GetSource().(*Field_RequestHeader); ok {
+		return x.RequestHeader
+	}
+	return ""
+}
+
+func (x *Field) GetResponseHeader() string {
+	if x, ok := x.GetSource().(*Field_ResponseHeader); ok {
+		return x.ResponseHeader
+	}
+	return ""
+}
+
+func (x *Field) GetProperty() string {
+	if x, ok := x.GetSource().(*Field_Property); ok {
+		return x.Property
+	}
+	return ""
+}
+
+func (x *Field) GetBuiltin() Builtin {
+	if x, ok := x.GetSource().(*Field_Builtin); ok {
+		return x.Builtin
+	}
+	return Builtin_CONSUMER
+}
+
+func (x *Field) GetPluginState() *PluginState {
+	if x, ok := x.GetSource().(*Field_PluginState); ok {
+		return x.PluginState
+	}
+	return nil
+}
+
+type isField_Source interface {
+	isField_Source()
+}
+
+type Field_RequestHeader struct {
+	RequestHeader string `protobuf:"bytes,2,opt,name=request_header,json=requestHeader,proto3,oneof"`
+}
+
+type Field_ResponseHeader struct {
+	ResponseHeader string `protobuf:"bytes,3,opt,name=response_header,json=responseHeader,proto3,oneof"`
+}
+
+type Field_Property struct {
+	// The Envoy attribute, like `request.time`
+	Property string `protobuf:"bytes,4,opt,name=property,proto3,oneof"`
+}
+
+type Field_Builtin struct {
+	Builtin Builtin `protobuf:"varint,5,opt,name=builtin,proto3,enum=types.plugins.access_log.Builtin,oneof"`
+}
+
+type Field_PluginState struct {
+	// The value stored in the PluginState by the plugins
+	PluginState *PluginState `protobuf:"bytes,6,opt,name=plugin_state,json=pluginState,proto3,oneof"`
+}
+
+func (*Field_RequestHeader) isField_Source() {}
+
+func (*Field_ResponseHeader) isField_Source() {}
+
+func (*Field_Property) isField_Source() {}
+
+func (*Field_Builtin) isField_Source() {}
+
+func (*Field_PluginState) isField_Source() {}
+
+type Config struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Fields []*Field `protobuf:"bytes,1,rep,name=fields,proto3" json:"fields,omitempty"`
+	// The ratio of the requests to log. Default to 1.
+	SampleRate float64 `protobuf:"fixed64,2,opt,name=sample_rate,json=sampleRate,proto3" json:"sample_rate,omitempty"`
+	// The headers whose values are replaced with `***`, in addition to `authorization`,
+	// `proxy-authorization`, `cookie` and `set-cookie`.
+	RedactedHeaders []string `protobuf:"bytes,3,rep,name=redacted_headers,json=redactedHeaders,proto3" json:"redacted_headers,omitempty"`
+	// Types that are assignable to Sink:
+	//
+	//	*Config_File
+	//	*Config_Http
+	Sink  isConfig_Sink `protobuf_oneof:"sink"`
+	Batch *v1.Batch     `protobuf:"bytes,6,opt,name=batch,proto3" json:"batch,omitempty"`
+}
+
+func (x *Config) Reset() {
+	*x = Config{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_types_plugins_access_log_config_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Config) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Config) ProtoMessage() {}
+
+func (x *Config) ProtoReflect() protoreflect.Message {
+	mi := &file_types_plugins_access_log_config_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Config.ProtoReflect.Descriptor instead.
+func (*Config) Descriptor() ([]byte, []int) {
+	return file_types_plugins_access_log_config_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Config) GetFields() []*Field {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+func (x *Config) GetSampleRate() float64 {
+	if x != nil {
+		return x.SampleRate
+	}
+	return 0
+}
+
+func (x *Config) GetRedactedHeaders() []string {
+	if x != nil {
+		return x.RedactedHeaders
+	}
+	return nil
+}
+
+func (m *Config) GetSink() isConfig_Sink {
+	if m != nil {
+		return m.Sink
+	}
+	return nil
+}
+
+func (x *Config) GetFile() *v1.FileSink {
+	if x, ok := x.GetSink().(*Config_File); ok {
+		return x.File
+	}
+	return nil
+}
+
+func (x *Config) GetHttp() *v1.HttpSink {
+	if x, ok := x.GetSink().(*Config_Http); ok {
+		return x.Http
+	}
+	return nil
+}
+
+func (x *Config) GetBatch() *v1.Batch {
+	if x != nil {
+		return x.Batch
+	}
+	return nil
+}
+
+type isConfig_Sink interface {
+	isConfig_Sink()
+}
+
+type Config_File struct {
+	File *v1.FileSink `protobuf:"bytes,4,opt,name=file,proto3,oneof"`
+}
+
+type Config_Http struct {
+	Http *v1.HttpSink `protobuf:"bytes,5,opt,name=http,proto3,oneof"`
+}
+
+func (*Config_File) isConfig_Sink() {}
+
+func (*Config_Http) isConfig_Sink() {}
+
+var File_types_plugins_access_log_config_proto protoreflect.FileDescriptor
+
+var file_types_plugins_access_log_config_proto_rawDesc = []byte{
+	0x0a, 0x25, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2f, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x73, 0x2f,
+	0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x6c, 0x6f, 0x67, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x18, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x70,
+	0x6c, 0x75, 0x67, 0x69, 0x6e, 0x73, 0x2e, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x6c, 0x6f,
+	0x67, 0x1a, 0x1f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2f, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x73,
+	0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x73, 0x69, 0x6e, 0x6b, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x1a, 0x17, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x2f, 0x76, 0x61, 0x6c,
+	0x69, 0x64, 0x61, 0x74, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x4f, 0x0a, 0x0b, 0x50,
+	0x6c, 0x75, 0x67, 0x69, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x25, 0x0a, 0x09, 0x6e, 0x61,
+	0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x07, 0xfa,
+	0x42, 0x04, 0x72, 0x02, 0x10, 0x01, 0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63,
+	0x65, 0x12, 0x19, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x42, 0x07,
+	0xfa, 0x42, 0x04, 0x72, 0x02, 0x10, 0x01, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x22, 0xd5, 0x02, 0x0a,
+	0x05, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x1b, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x42, 0x07, 0xfa, 0x42, 0x04, 0x72, 0x02, 0x10, 0x01, 0x52, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x12, 0x30, 0x0a, 0x0e, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x68,
+	0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x42, 0x07, 0xfa, 0x42, 0x04,
+	0x72, 0x02, 0x10, 0x01, 0x48, 0x00, 0x52, 0x0d, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48,
+	0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x32, 0x0a, 0x0f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x42, 0x07,
+	0xfa, 0x42, 0x04, 0x72, 0x02, 0x10, 0x01, 0x48, 0x00, 0x52, 0x0e, 0x72, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x25, 0x0a, 0x08, 0x70, 0x72, 0x6f,
+	0x70, 0x65, 0x72, 0x74, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x42, 0x07, 0xfa, 0x42, 0x04,
+	0x72, 0x02, 0x10, 0x01, 0x48, 0x00, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x79,
+	0x12, 0x47, 0x0a, 0x07, 0x62, 0x75, 0x69, 0x6c, 0x74, 0x69, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x21, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e,
+	0x73, 0x2e, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x6c, 0x6f, 0x67, 0x2e, 0x42, 0x75, 0x69,
+	0x6c, 0x74, 0x69, 0x6e, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x82, 0x01, 0x02, 0x10, 0x01, 0x48, 0x00,
+	0x52, 0x07, 0x62, 0x75, 0x69, 0x6c, 0x74, 0x69, 0x6e, 0x12, 0x4a, 0x0a, 0x0c, 0x70, 0x6c, 0x75,
+	0x67, 0x69, 0x6e, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x25, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x73, 0x2e,
+	0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x6c, 0x6f, 0x67, 0x2e, 0x50, 0x6c, 0x75, 0x67, 0x69,
+	0x6e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x48, 0x00, 0x52, 0x0b, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e,
+	0x53, 0x74, 0x61, 0x74, 0x65, 0x42, 0x0d, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12,
+	0x03, 0xf8, 0x42, 0x01, 0x22, 0xec, 0x02, 0x0a, 0x06, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12,
+	0x43, 0x0a, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x1f, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x73, 0x2e,
+	0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x6c, 0x6f, 0x67, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64,
+	0x42, 0x0a, 0xfa, 0x42, 0x07, 0x92, 0x01, 0x04, 0x08, 0x01, 0x10, 0x40, 0x52, 0x06, 0x66, 0x69,
+	0x65, 0x6c, 0x64, 0x73, 0x12, 0x38, 0x0a, 0x0b, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x5f, 0x72,
+	0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x42, 0x17, 0xfa, 0x42, 0x14, 0x12, 0x12,
+	0x19, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0xf0, 0x3f, 0x29, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0x52, 0x0a, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x52, 0x61, 0x74, 0x65, 0x12, 0x37,
+	0x0a, 0x10, 0x72, 0x65, 0x64, 0x61, 0x63, 0x74, 0x65, 0x64, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x65,
+	0x72, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x42, 0x0c, 0xfa, 0x42, 0x09, 0x92, 0x01, 0x06,
+	0x22, 0x04, 0x72, 0x02, 0x10, 0x01, 0x52, 0x0f, 0x72, 0x65, 0x64, 0x61, 0x63, 0x74, 0x65, 0x64,
+	0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x12, 0x34, 0x0a, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x70, 0x6c,
+	0x75, 0x67, 0x69, 0x6e, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x69, 0x6c,
+	0x65, 0x53, 0x69, 0x6e, 0x6b, 0x48, 0x00, 0x52, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x34, 0x0a,
+	0x04, 0x68, 0x74, 0x74, 0x70, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x74, 0x79,
+	0x70, 0x65, 0x73, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e,
+	0x76, 0x31, 0x2e, 0x48, 0x74, 0x74, 0x70, 0x53, 0x69, 0x6e, 0x6b, 0x48, 0x00, 0x52, 0x04, 0x68,
+	0x74, 0x74, 0x70, 0x12, 0x31, 0x0a, 0x05, 0x62, 0x61, 0x74, 0x63, 0x68, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69,
+	0x6e, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52,
+	0x05, 0x62, 0x61, 0x74, 0x63, 0x68, 0x42, 0x0b, 0x0a, 0x04, 0x73, 0x69, 0x6e, 0x6b, 0x12, 0x03,
+	0xf8, 0x42, 0x01, 0x2a, 0xfb, 0x01, 0x0a, 0x07, 0x42, 0x75, 0x69, 0x6c, 0x74, 0x69, 0x6e, 0x12,
+	0x0c, 0x0a, 0x08, 0x43, 0x4f, 0x4e, 0x53, 0x55, 0x4d, 0x45, 0x52, 0x10, 0x00, 0x12, 0x11, 0x0a,
+	0x0d, 0x52, 0x45, 0x53, 0x50, 0x4f, 0x4e, 0x53, 0x45, 0x5f, 0x43, 0x4f, 0x44, 0x45, 0x10, 0x01,
+	0x12, 0x19, 0x0a, 0x15, 0x52, 0x45, 0x53, 0x50, 0x4f, 0x4e, 0x53, 0x45, 0x5f, 0x43, 0x4f, 0x44,
+	0x45, 0x5f, 0x44, 0x45, 0x54, 0x41, 0x49, 0x4c, 0x53, 0x10, 0x02, 0x12, 0x09, 0x0a, 0x05, 0x52,
+	0x4f, 0x55, 0x54, 0x45, 0x10, 0x03, 0x12, 0x0a, 0x0a, 0x06, 0x4d, 0x45, 0x54, 0x48, 0x4f, 0x44,
+	0x10, 0x04, 0x12, 0x08, 0x0a, 0x04, 0x50, 0x41, 0x54, 0x48, 0x10, 0x05, 0x12, 0x08, 0x0a, 0x04,
+	0x48, 0x4f, 0x53, 0x54, 0x10, 0x06, 0x12, 0x1d, 0x0a, 0x19, 0x44, 0x4f, 0x57, 0x4e, 0x53, 0x54,
+	0x52, 0x45, 0x41, 0x4d, 0x5f, 0x52, 0x45, 0x4d, 0x4f, 0x54, 0x45, 0x5f, 0x41, 0x44, 0x44, 0x52,
+	0x45, 0x53, 0x53, 0x10, 0x07, 0x12, 0x1b, 0x0a, 0x17, 0x55, 0x50, 0x53, 0x54, 0x52, 0x45, 0x41,
+	0x4d, 0x5f, 0x52, 0x45, 0x4d, 0x4f, 0x54, 0x45, 0x5f, 0x41, 0x44, 0x44, 0x52, 0x45, 0x53, 0x53,
+	0x10, 0x08, 0x12, 0x14, 0x0a, 0x10, 0x55, 0x50, 0x53, 0x54, 0x52, 0x45, 0x41, 0x4d, 0x5f, 0x43,
+	0x4c, 0x55, 0x53, 0x54, 0x45, 0x52, 0x10, 0x09, 0x12, 0x0c, 0x0a, 0x08, 0x44, 0x55, 0x52, 0x41,
+	0x54, 0x49, 0x4f, 0x4e, 0x10, 0x0a, 0x12, 0x13, 0x0a, 0x0f, 0x52, 0x45, 0x51, 0x55, 0x45, 0x53,
+	0x54, 0x5f, 0x48, 0x45, 0x41, 0x44, 0x45, 0x52, 0x53, 0x10, 0x0b, 0x12, 0x14, 0x0a, 0x10, 0x52,
+	0x45, 0x53, 0x50, 0x4f, 0x4e, 0x53, 0x45, 0x5f, 0x48, 0x45, 0x41, 0x44, 0x45, 0x52, 0x53, 0x10,
+	0x0c, 0x42, 0x27, 0x5a, 0x25, 0x6d, 0x6f, 0x73, 0x6e, 0x2e, 0x69, 0x6f, 0x2f, 0x68, 0x74, 0x6e,
+	0x6e, 0x2f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2f, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x73, 0x2f,
+	0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x6c, 0x6f, 0x67, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
+}
+
+var (
+	file_types_plugins_access_log_config_proto_rawDescOnce sync.Once
+	file_types_plugins_access_log_config_proto_rawDescData = file_types_plugins_access_log_config_proto_rawDesc
+)
+
+func file_types_plugins_access_log_config_proto_rawDescGZIP() []byte {
+	file_types_plugins_access_log_config_proto_rawDescOnce.Do(func() {
+		file_types_plugins_access_log_config_proto_rawDescData = protoimpl.X.CompressGZIP(file_types_plugins_access_log_config_proto_rawDescData)
+	})
+	return file_types_plugins_access_log_config_proto_rawDescData
+}
+
+var file_types_plugins_access_log_config_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_types_plugins_access_log_config_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_types_plugins_access_log_config_proto_goTypes = []interface{}{
+	(Builtin)(0),        // 0: types.plugins.access_log.Builtin
+	(*PluginState)(nil), // 1: types.plugins.access_log.PluginState
+	(*Field)(nil),       // 2: types.plugins.access_log.Field
+	(*Config)(nil),      // 3: types.plugins.access_log.Config
+	(*v1.FileSink)(nil), // 4: types.plugins.api.v1.FileSink
+	(*v1.HttpSink)(nil), // 5: types.plugins.api.v1.HttpSink
+	(*v1.Batch)(nil),    // 6: types.plugins.api.v1.Batch
+}
+var file_types_plugins_access_log_config_proto_depIdxs = []int32{
+	0, // 0: types.plugins.access_log.Field.builtin:type_name -> types.plugins.access_log.Builtin
+	1, // 1: types.plugins.access_log.Field.plugin_state:type_name -> types.plugins.access_log.PluginState
+	2, // 2: types.plugins.access_log.Config.fields:type_name -> types.plugins.access_log.Field
+	4, // 3: types.plugins.access_log.Config.file:type_name -> types.plugins.api.v1.FileSink
+	5, // 4: types.plugins.access_log.Config.http:type_name -> types.plugins.api.v1.HttpSink
+	6, // 5: types.plugins.access_log.Config.batch:type_name -> types.plugins.api.v1.Batch
+	6, // [6:6] is the sub-list for method output_type
+	6, // [6:6] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_types_plugins_access_log_config_proto_init() }
+func file_types_plugins_access_log_config_proto_init() {
+	if File_types_plugins_access_log_config_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_types_plugins_access_log_config_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PluginState); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_types_plugins_access_log_config_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Field); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_types_plugins_access_log_config_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Config); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_types_plugins_access_log_config_proto_msgTypes[1].OneofWrappers = []interface{}{
+		(*Field_RequestHeader)(nil),
+		(*Field_ResponseHeader)(nil),
+		(*Field_Property)(nil),
+		(*Field_Builtin)(nil),
+		(*Field_PluginState)(nil),
+	}
+	file_types_plugins_access_log_config_proto_msgTypes[2].OneofWrappers = []interface{}{
+		(*Config_File)(nil),
+		(*Config_Http)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_types_plugins_access_log_config_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_types_plugins_access_log_config_proto_goTypes,
+		DependencyIndexes: file_types_plugins_access_log_config_proto_depIdxs,
+		EnumInfos:         file_types_plugins_access_log_config_proto_enumTypes,
+		MessageInfos:      file_types_plugins_access_log_config_proto_msgTypes,
+	}.Build()
+	File_types_plugins_access_log_config_proto = out.File
+	file_types_plugins_access_log_config_proto_rawDesc = nil
+	file_types_plugins_access_log_config_proto_goTypes = nil
+	file_types_plugins_access_log_config_proto_depIdxs = nil
+}
@@ -0,0 +1,868 @@
+// Code generated by protoc-gen-validate. DO NOT EDIT.
+// source: types/plugins/api/v1/sink.proto
+
+package v1
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// ensure the imports are used
+var (
+	_ = bytes.MinRead
+	_ = errors.New("")
+	_ = fmt.Print
+	_ = utf8.UTFMax
+	_ = (*regexp.Regexp)(nil)
+	_ = (*strings.Reader)(nil)
+	_ = net.IPv4len
+	_ = time.Duration(0)
+	_ = (*url.URL)(nil)
+	_ = (*mail.Address)(nil)
+	_ = anypb.Any{}
+	_ = sort.Sort
+)
+
+// Validate checks the field values on FileSink with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *FileSink) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on FileSink with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in FileSinkMultiError, or nil
+// if none found.
+func (m *FileSink) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *FileSink) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetPath()) < 1 {
+		err := FileSinkValidationError{
+			field:  "Path",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for MaxSize
+
+	if m.GetMaxBackups() > 100 {
+		err := FileSinkValidationError{
+			field:  "MaxBackups",
+			reason: "value must be less than or equal to 100",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return FileSinkMultiError(errors)
+	}
+
+	return nil
+}
+
+// FileSinkMultiError is an error wrapping multiple validation errors returned
+// by FileSink.ValidateAll() if the designated constraints aren't met.
+type FileSinkMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m FileSinkMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m FileSinkMultiError) AllErrors() []error { return m }
+
+// FileSinkValidationError is the validation error returned by
+// FileSink.Validate if the designated constraints aren't met.
+type FileSinkValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e FileSinkValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e FileSinkValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e FileSinkValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e FileSinkValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e FileSinkValidationError) ErrorName() string { return "FileSinkValidationError" }
+
+// Error satisfies the builtin error interface
+func (e FileSinkValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sFileSink.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = FileSinkValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = FileSinkValidationError{}
+
+// Validate checks the field values on HttpSink with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *HttpSink) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on HttpSink with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in HttpSinkMultiError, or nil
+// if none found.
+func (m *HttpSink) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *HttpSink) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if uri, err := url.Parse(m.GetUrl()); err != nil {
+		err = HttpSinkValidationError{
+			field:  "Url",
+			reason: "value must be a valid URI",
+			cause:  err,
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	} else if !uri.IsAbs() {
+		err := HttpSinkValidationError{
+			field:  "Url",
+			reason: "value must be absolute",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for Headers
+
+	if d := m.GetTimeout(); d != nil {
+		dur, err := d.AsDuration(), d.CheckValid()
+		if err != nil {
+			err = HttpSinkValidationError{
+				field:  "Timeout",
+				reason: "value is not a valid duration",
+				cause:  err,
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		} else {
+
+			gt := time.Duration(0*time.Second + 0*time.Nanosecond)
+
+			if dur <= gt {
+				err := HttpSinkValidationError{
+					field:  "Timeout",
+					reason: "value must be greater than 0s",
+				}
+				if !all {
+					return err
+				}
+				errors = append(errors, err)
+			}
+
+		}
+	}
+
+	if len(errors) > 0 {
+		return HttpSinkMultiError(errors)
+	}
+
+	return nil
+}
+
+// HttpSinkMultiError is an error wrapping multiple validation errors returned
+// by HttpSink.ValidateAll() if the designated constraints aren't met.
+type HttpSinkMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m HttpSinkMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m HttpSinkMultiError) AllErrors() []error { return m }
+
+// HttpSinkValidationError is the validation error returned by
+// HttpSink.Validate if the designated constraints aren't met.
+type HttpSinkValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e HttpSinkValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e HttpSinkValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e HttpSinkValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e HttpSinkValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e HttpSinkValidationError) ErrorName() string { return "HttpSinkValidationError" }
+
+// Error satisfies the builtin error interface
+func (e HttpSinkValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sHttpSink.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = HttpSinkValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = HttpSinkValidationError{}
+
+// Validate checks the field values on KafkaSink with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *KafkaSink) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on KafkaSink with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in KafkaSinkMultiError, or nil
+// if none found.
+func (m *KafkaSink) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *KafkaSink) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(m.GetBrokers()) < 1 {
+		err := KafkaSinkValidationError{
+			field:  "Brokers",
+			reason: "value must contain at least 1 item(s)",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	for idx, item := range m.GetBrokers() {
+		_, _ = idx, item
+
+		if utf8.RuneCountInString(item) < 1 {
+			err := KafkaSinkValidationError{
+				field:  fmt.Sprintf("Brokers[%v]", idx),
+				reason: "value length must be at least 1 runes",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if utf8.RuneCountInString(m.GetTopic()) < 1 {
+		err := KafkaSinkValidationError{
+			field:  "Topic",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if d := m.GetTimeout(); d != nil {
+		dur, err := d.AsDuration(), d.CheckValid()
+		if err != nil {
+			err = KafkaSinkValidationError{
+				field:  "Timeout",
+				reason: "value is not a valid duration",
+				cause:  err,
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		} else {
+
+			gt := time.Duration(0*time.Second + 0*time.Nanosecond)
+
+			if dur <= gt {
+				err := KafkaSinkValidationError{
+					field:  "Timeout",
+					reason: "value must be greater than 0s",
+				}
+				if !all {
+					return err
+				}
+				errors = append(errors, err)
+			}
+
+		}
+	}
+
+	if len(errors) > 0 {
+		return KafkaSinkMultiError(errors)
+	}
+
+	return nil
+}
+
+// KafkaSinkMultiError is an error wrapping multiple validation errors returned
+// by KafkaSink.ValidateAll() if the designated constraints aren't met.
+type KafkaSinkMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m KafkaSinkMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m KafkaSinkMultiError) AllErrors() []error { return m }
+
+// KafkaSinkValidationError is the validation error returned by
+// KafkaSink.Validate if the designated constraints aren't met.
+type KafkaSinkValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e KafkaSinkValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e KafkaSinkValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e KafkaSinkValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e KafkaSinkValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e KafkaSinkValidationError) ErrorName() string { return "KafkaSinkValidationError" }
+
+// Error satisfies the builtin error interface
+func (e KafkaSinkValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sKafkaSink.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = KafkaSinkValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = KafkaSinkValidationError{}
+
+// Validate checks the field values on Sink with the rules defined in the proto
+// definition for this message. If any rules are violated, the first error
+// encountered is returned, or nil if there are no violations.
+func (m *Sink) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Sink with the rules defined in the
+// proto definition for this message. If any rules are violated, the result is
+// a list of violation errors wrapped in SinkMultiError, or nil if none found.
+func (m *Sink) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Sink) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	oneofSinkPresent := false
+	switch v := m.Sink.(type) {
+	case *Sink_File:
+		if v == nil {
+			err := SinkValidationError{
+				field:  "Sink",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+		oneofSinkPresent = true
+
+		if all {
+			switch v := interface{}(m.GetFile()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, SinkValidationError{
+						field:  "File",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, SinkValidationError{
+						field:  "File",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetFile()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return SinkValidationError{
+					field:  "File",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	case *Sink_Http:
+		if v == nil {
+			err := SinkValidationError{
+				field:  "Sink",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+		oneofSinkPresent = true
+
+		if all {
+			switch v := interface{}(m.GetHttp()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, SinkValidationError{
+						field:  "Http",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, SinkValidationError{
+						field:  "Http",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetHttp()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return SinkValidationError{
+					field:  "Http",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	case *Sink_Kafka:
+		if v == nil {
+			err := SinkValidationError{
+				field:  "Sink",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+		oneofSinkPresent = true
+
+		if all {
+			switch v := interface{}(m.GetKafka()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, SinkValidationError{
+						field:  "Kafka",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, SinkValidationError{
+						field:  "Kafka",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetKafka()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return SinkValidationError{
+					field:  "Kafka",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	default:
+		_ = v // ensures v is used
+	}
+	if !oneofSinkPresent {
+		err := SinkValidationError{
+			field:  "Sink",
+			reason: "value is required",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return SinkMultiError(errors)
+	}
+
+	return nil
+}
+
+// SinkMultiError is an error wrapping multiple validation errors returned by
+// Sink.ValidateAll() if the designated constraints aren't met.
+type SinkMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m SinkMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m SinkMultiError) AllErrors() []error { return m }
+
+// SinkValidationError is the validation error returned by Sink.Validate if the
+// designated constraints aren't met.
+type SinkValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e SinkValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e SinkValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e SinkValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e SinkValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e SinkValidationError) ErrorName() string { return "SinkValidationError" }
+
+// Error satisfies the builtin error interface
+func (e SinkValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sSink.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = SinkValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = SinkValidationError{}
+
+// Validate checks the field values on Batch with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *Batch) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Batch with the rules defined in the
+// proto definition for this message. If any rules are violated, the result is
+// a list of violation errors wrapped in BatchMultiError, or nil if none found.
+func (m *Batch) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Batch) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetMaxRecords() > 10000 {
+		err := BatchValidationError{
+			field:  "MaxRecords",
+			reason: "value must be less than or equal to 10000",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if d := m.GetFlushInterval(); d != nil {
+		dur, err := d.AsDuration(), d.CheckValid()
+		if err != nil {
+			err = BatchValidationError{
+				field:  "FlushInterval",
+				reason: "value is not a valid duration",
+				cause:  err,
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		} else {
+
+			gt := time.Duration(0*time.Second + 0*time.Nanosecond)
+
+			if dur <= gt {
+				err := BatchValidationError{
+					field:  "FlushInterval",
+					reason: "value must be greater than 0s",
+				}
+				if !all {
+					return err
+				}
+				errors = append(errors, err)
+			}
+
+		}
+	}
+
+	if m.GetQueueSize() > 1000000 {
+		err := BatchValidationError{
+			field:  "QueueSize",
+			reason: "value must be less than or equal to 1000000",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return BatchMultiError(errors)
+	}
+
+	return nil
+}
+
+// BatchMultiError is an error wrapping multiple validation errors returned by
+// Batch.ValidateAll() if the designated constraints aren't met.
+type BatchMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m BatchMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m BatchMultiError) AllErrors() []error { return m }
+
+// BatchValidationError is the validation error returned by Batch.Validate if
+// the designated constraints aren't met.
+type BatchValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e BatchValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e BatchValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e BatchValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e BatchValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e BatchValidationError) ErrorName() string { return "BatchValidationError" }
+
+// Error satisfies the builtin error interface
+func (e BatchValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sBatch.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = BatchValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = BatchValidationError{}
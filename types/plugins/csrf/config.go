@@ -0,0 +1,94 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package csrf
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/plugins"
+	"mosn.io/htnn/types/pkg/expr"
+)
+
+const (
+	Name = "csrf"
+)
+
+func init() {
+	plugins.RegisterHttpPluginType(Name, &Plugin{})
+}
+
+type Plugin struct {
+	plugins.PluginMethodDefaultImpl
+}
+
+func (p *Plugin) Type() plugins.PluginType {
+	return plugins.TypeSecurity
+}
+
+func (p *Plugin) Order() plugins.PluginOrder {
+	return plugins.PluginOrder{
+		Position: plugins.OrderPositionAccess,
+	}
+}
+
+func (p *Plugin) Config() api.PluginConfig {
+	return &CustomConfig{}
+}
+
+type CustomConfig struct {
+	Config
+}
+
+// ParseOrigin returns the origin in the form of `scheme://host[:port]`, in lower case.
+// The path, query and fragment are ignored, so it can be used to parse the `Referer` too.
+func ParseOrigin(s string) (string, error) {
+	u, err := url.Parse(s)
+	if err != nil {
+		return "", err
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return "", fmt.Errorf("invalid origin %q", s)
+	}
+	return strings.ToLower(u.Scheme + "://" + u.Host), nil
+}
+
+func (conf *CustomConfig) Validate() error {
+	err := conf.Config.Validate()
+	if err != nil {
+		return err
+	}
+
+	if conf.Cookie != nil && conf.Cookie.Name != "" {
+		c := http.Cookie{Name: conf.Cookie.Name, Value: "x"}
+		if c.String() == "" {
+			return fmt.Errorf("invalid cookie name %q", conf.Cookie.Name)
+		}
+	}
+	if strings.HasPrefix(conf.HeaderName, ":") {
+		return errors.New("pseudo header can't be used to carry the token")
+	}
+	for _, o := range conf.TrustedOrigins {
+		if _, err := ParseOrigin(o); err != nil {
+			return err
+		}
+	}
+	_, err = expr.BuildRepeatedStringMatcher(conf.ExemptPaths)
+	return err
+}
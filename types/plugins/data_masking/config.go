@@ -0,0 +1,94 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package data_masking
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/plugins"
+	"mosn.io/htnn/types/plugins/json_transform"
+)
+
+const (
+	Name = "dataMasking"
+)
+
+func init() {
+	plugins.RegisterHttpPluginType(Name, &Plugin{})
+}
+
+type Plugin struct {
+	plugins.PluginMethodDefaultImpl
+}
+
+func (p *Plugin) Type() plugins.PluginType {
+	return plugins.TypeSecurity
+}
+
+func (p *Plugin) Order() plugins.PluginOrder {
+	return plugins.PluginOrder{
+		Position: plugins.OrderPositionTransform,
+		// run at the beginning of the group, so the response is masked after other transforms
+		Operation: plugins.OrderOperationInsertFirst,
+	}
+}
+
+func (p *Plugin) Config() api.PluginConfig {
+	return &CustomConfig{}
+}
+
+type CustomConfig struct {
+	Config
+}
+
+func (conf *CustomConfig) Validate() error {
+	err := conf.Config.Validate()
+	if err != nil {
+		return err
+	}
+
+	if conf.Exempt {
+		return nil
+	}
+	if len(conf.Rules) == 0 {
+		return errors.New("rules are required unless exempt is true")
+	}
+	for i, rule := range conf.Rules {
+		if rule.Path == "" && rule.Regex == "" {
+			return fmt.Errorf("rule %d: at least one of path and regex is required", i)
+		}
+		if rule.Path != "" {
+			if conf.Streaming != nil {
+				return fmt.Errorf("rule %d: path is not supported in streaming mode", i)
+			}
+			segs, err := json_transform.ParsePath(rule.Path)
+			if err != nil {
+				return fmt.Errorf("rule %d: %w", i, err)
+			}
+			if len(segs) == 0 {
+				return fmt.Errorf("rule %d: can't mask the root", i)
+			}
+		}
+		if rule.Regex != "" {
+			if _, err := regexp.Compile(rule.Regex); err != nil {
+				return fmt.Errorf("rule %d: %w", i, err)
+			}
+		}
+	}
+	return nil
+}
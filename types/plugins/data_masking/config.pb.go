@@ -0,0 +1,435 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0
+// 	protoc        v4.24.4
+// source: types/plugins/data_masking/config.proto
+
+package data_masking
+
+import (
+	reflect "reflect"
+	sync "sync"
+
+	_ "github.com/envoyproxy/protoc-gen-validate/validate"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Action int32
+
+const (
+	Action_MASK   Action = 0
+	Action_HASH   Action = 1
+	Action_REMOVE Action = 2
+)
+
+// Enum value maps for Action.
+var (
+	Action_name = map[int32]string{
+		0: "MASK",
+		1: "HASH",
+		2: "REMOVE",
+	}
+	Action_value = map[string]int32{
+		"MASK":   0,
+		"HASH":   1,
+		"REMOVE": 2,
+	}
+)
+
+func (x Action) Enum() *Action {
+	p := new(Action)
+	*p = x
+	return p
+}
+
+func (x Action) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Action) Descriptor() protoreflect.EnumDescriptor {
+	return file_types_plugins_data_masking_config_proto_enumTypes[0].Descriptor()
+}
+
+func (Action) Type() protoreflect.EnumType {
+	return &file_types_plugins_data_masking_config_proto_enumTypes[0]
+}
+
+func (x Action) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Action.Descriptor instead.
+func (Action) EnumDescriptor() ([]byte, []int) {
+	return file_types_plugins_data_masking_config_proto_rawDescGZIP(), []int{0}
+}
+
+type Rule struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The JSONPath-style path of the fields to mask, like `$.user.phone` or `$.items[*].card`
+	Path string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	// The regex to match the sensitive data. If the path is also specified, only the matched
+	// part of the field value is masked.
+	Regex  string `protobuf:"bytes,2,opt,name=regex,proto3" json:"regex,omitempty"`
+	Action Action `protobuf:"varint,3,opt,name=action,proto3,enum=types.plugins.data_masking.Action" json:"action,omitempty"`
+	// The character used to mask. Default to `*`.
+	MaskChar string `protobuf:"bytes,4,opt,name=mask_char,json=maskChar,proto3" json:"mask_char,omitempty"`
+	// The number of characters kept at the beginning when masking
+	KeepPrefix uint32 `protobuf:"varint,5,opt,name=keep_prefix,json=keepPrefix,proto3" json:"keep_prefix,omitempty"`
+	// The number of characters kept at the end when masking
+	KeepSuffix uint32 `protobuf:"varint,6,opt,name=keep_suffix,json=keepSuffix,proto3" json:"keep_suffix,omitempty"`
+}
+
+func (x *Rule) Reset() {
+	*x = Rule{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_types_plugins_data_masking_config_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Rule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Rule) ProtoMessage() {}
+
+func (x *Rule) ProtoReflect() protoreflect.Message {
+	mi := &file_types_plugins_data_masking_config_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Rule.ProtoReflect.Descriptor instead.
+func (*Rule) Descriptor() ([]byte, []int) {
+	return file_types_plugins_data_masking_config_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Rule) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *Rule) GetRegex() string {
+	if x != nil {
+		return x.Regex
+	}
+	return ""
+}
+
+func (x *Rule) GetAction() Action {
+	if x != nil {
+		return x.Action
+	}
+	return Action_MASK
+}
+
+func (x *Rule) GetMaskChar() string {
+	if x != nil {
+		return x.MaskChar
+	}
+	return ""
+}
+
+func (x *Rule) GetKeepPrefix() uint32 {
+	if x != nil {
+		return x.KeepPrefix
+	}
+	return 0
+}
+
+func (x *Rule) GetKeepSuffix() uint32 {
+	if x != nil {
+		return x.KeepSuffix
+	}
+	return 0
+}
+
+type Streaming struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The max length of the text matched by the regex. The last bytes of each chunk are held back
+	// until the next chunk arrives, so the match across chunks can be found. Default to 256.
+	MaxMatchLength uint32 `protobuf:"varint,1,opt,name=max_match_length,json=maxMatchLength,proto3" json:"max_match_length,omitempty"`
+}
+
+func (x *Streaming) Reset() {
+	*x = Streaming{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_types_plugins_data_masking_config_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Streaming) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Streaming) ProtoMessage() {}
+
+func (x *Streaming) ProtoReflect() protoreflect.Message {
+	mi := &file_types_plugins_data_masking_config_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Streaming.ProtoReflect.Descriptor instead.
+func (*Streaming) Descriptor() ([]byte, []int) {
+	return file_types_plugins_data_masking_config_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Streaming) GetMaxMatchLength() uint32 {
+	if x != nil {
+		return x.MaxMatchLength
+	}
+	return 0
+}
+
+type Config struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Rules []*Rule `protobuf:"bytes,1,rep,name=rules,proto3" json:"rules,omitempty"`
+	// Mask the body chunk by chunk instead of buffering the whole body. Only the rules with regex
+	// and without path are supported in this mode.
+	Streaming *Streaming `protobuf:"bytes,2,opt,name=streaming,proto3" json:"streaming,omitempty"`
+	// Skip masking. It's used in the consumer's filters to exempt the consumer.
+	Exempt bool `protobuf:"varint,3,opt,name=exempt,proto3" json:"exempt,omitempty"`
+}
+
+func (x *Config) Reset() {
+	*x = Config{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_types_plugins_data_masking_config_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Config) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Config) ProtoMessage() {}
+
+func (x *Config) ProtoReflect() protoreflect.Message {
+	mi := &file_types_plugins_data_masking_config_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Config.ProtoReflect.Descriptor instead.
+func (*Config) Descriptor() ([]byte, []int) {
+	return file_types_plugins_data_masking_config_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Config) GetRules() []*Rule {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+func (x *Config) GetStreaming() *Streaming {
+	if x != nil {
+		return x.Streaming
+	}
+	return nil
+}
+
+func (x *Config) GetExempt() bool {
+	if x != nil {
+		return x.Exempt
+	}
+	return false
+}
+
+var File_types_plugins_data_masking_config_proto protoreflect.FileDescriptor
+
+var file_types_plugins_data_masking_config_proto_rawDesc = []byte{
+	0x0a, 0x27, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2f, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x73, 0x2f,
+	0x64, 0x61, 0x74, 0x61, 0x5f, 0x6d, 0x61, 0x73, 0x6b, 0x69, 0x6e, 0x67, 0x2f, 0x63, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x1a, 0x74, 0x79, 0x70, 0x65, 0x73,
+	0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x73, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x6d, 0x61,
+	0x73, 0x6b, 0x69, 0x6e, 0x67, 0x1a, 0x17, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x2f,
+	0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xde,
+	0x01, 0x0a, 0x04, 0x52, 0x75, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x14, 0x0a, 0x05, 0x72,
+	0x65, 0x67, 0x65, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x72, 0x65, 0x67, 0x65,
+	0x78, 0x12, 0x44, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x22, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e,
+	0x73, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x6d, 0x61, 0x73, 0x6b, 0x69, 0x6e, 0x67, 0x2e, 0x41,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x82, 0x01, 0x02, 0x10, 0x01, 0x52,
+	0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x24, 0x0a, 0x09, 0x6d, 0x61, 0x73, 0x6b, 0x5f,
+	0x63, 0x68, 0x61, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x42, 0x07, 0xfa, 0x42, 0x04, 0x72,
+	0x02, 0x18, 0x01, 0x52, 0x08, 0x6d, 0x61, 0x73, 0x6b, 0x43, 0x68, 0x61, 0x72, 0x12, 0x1f, 0x0a,
+	0x0b, 0x6b, 0x65, 0x65, 0x70, 0x5f, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x0a, 0x6b, 0x65, 0x65, 0x70, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x1f,
+	0x0a, 0x0b, 0x6b, 0x65, 0x65, 0x70, 0x5f, 0x73, 0x75, 0x66, 0x66, 0x69, 0x78, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x0a, 0x6b, 0x65, 0x65, 0x70, 0x53, 0x75, 0x66, 0x66, 0x69, 0x78, 0x22,
+	0x40, 0x0a, 0x09, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x69, 0x6e, 0x67, 0x12, 0x33, 0x0a, 0x10,
+	0x6d, 0x61, 0x78, 0x5f, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x42, 0x09, 0xfa, 0x42, 0x06, 0x2a, 0x04, 0x18, 0x80, 0x80,
+	0x04, 0x52, 0x0e, 0x6d, 0x61, 0x78, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x4c, 0x65, 0x6e, 0x67, 0x74,
+	0x68, 0x22, 0xa7, 0x01, 0x0a, 0x06, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x40, 0x0a, 0x05,
+	0x72, 0x75, 0x6c, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x74, 0x79,
+	0x70, 0x65, 0x73, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x73, 0x2e, 0x64, 0x61, 0x74, 0x61,
+	0x5f, 0x6d, 0x61, 0x73, 0x6b, 0x69, 0x6e, 0x67, 0x2e, 0x52, 0x75, 0x6c, 0x65, 0x42, 0x08, 0xfa,
+	0x42, 0x05, 0x92, 0x01, 0x02, 0x10, 0x40, 0x52, 0x05, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x12, 0x43,
+	0x0a, 0x09, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x69, 0x6e, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x25, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e,
+	0x73, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x6d, 0x61, 0x73, 0x6b, 0x69, 0x6e, 0x67, 0x2e, 0x53,
+	0x74, 0x72, 0x65, 0x61, 0x6d, 0x69, 0x6e, 0x67, 0x52, 0x09, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d,
+	0x69, 0x6e, 0x67, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x78, 0x65, 0x6d, 0x70, 0x74, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x06, 0x65, 0x78, 0x65, 0x6d, 0x70, 0x74, 0x2a, 0x28, 0x0a, 0x06, 0x41,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x08, 0x0a, 0x04, 0x4d, 0x41, 0x53, 0x4b, 0x10, 0x00, 0x12,
+	0x08, 0x0a, 0x04, 0x48, 0x41, 0x53, 0x48, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x52, 0x45, 0x4d,
+	0x4f, 0x56, 0x45, 0x10, 0x02, 0x42, 0x29, 0x5a, 0x27, 0x6d, 0x6f, 0x73, 0x6e, 0x2e, 0x69, 0x6f,
+	0x2f, 0x68, 0x74, 0x6e, 0x6e, 0x2f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2f, 0x70, 0x6c, 0x75, 0x67,
+	0x69, 0x6e, 0x73, 0x2f, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x6d, 0x61, 0x73, 0x6b, 0x69, 0x6e, 0x67,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_types_plugins_data_masking_config_proto_rawDescOnce sync.Once
+	file_types_plugins_data_masking_config_proto_rawDescData = file_types_plugins_data_masking_config_proto_rawDesc
+)
+
+func file_types_plugins_data_masking_config_proto_rawDescGZIP() []byte {
+	file_types_plugins_data_masking_config_proto_rawDescOnce.Do(func() {
+		file_types_plugins_data_masking_config_proto_rawDescData = protoimpl.X.CompressGZIP(file_types_plugins_data_masking_config_proto_rawDescData)
+	})
+	return file_types_plugins_data_masking_config_proto_rawDescData
+}
+
+var file_types_plugins_data_masking_config_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_types_plugins_data_masking_config_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_types_plugins_data_masking_config_proto_goTypes = []interface{}{
+	(Action)(0),       // 0: types.plugins.data_masking.Action
+	(*Rule)(nil),      // 1: types.plugins.data_masking.Rule
+	(*Streaming)(nil), // 2: types.plugins.data_masking.Streaming
+	(*Config)(nil),    // 3: types.plugins.data_masking.Config
+}
+var file_types_plugins_data_masking_config_proto_depIdxs = []int32{
+	0, // 0: types.plugins.data_masking.Rule.action:type_name -> types.plugins.data_masking.Action
+	1, // 1: types.plugins.data_masking.Config.rules:type_name -> types.plugins.data_masking.Rule
+	2, // 2: types.plugins.data_masking.Config.streaming:type_name -> types.plugins.data_masking.Streaming
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_types_plugins_data_masking_config_proto_init() }
+func file_types_plugins_data_masking_config_proto_init() {
+	if File_types_plugins_data_masking_config_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_types_plugins_data_masking_config_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Rule); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_types_plugins_data_masking_config_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Streaming); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_types_plugins_data_masking_config_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Config); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_types_plugins_data_masking_config_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_types_plugins_data_masking_config_proto_goTypes,
+		DependencyIndexes: file_types_plugins_data_masking_config_proto_depIdxs,
+		EnumInfos:         file_types_plugins_data_masking_config_proto_enumTypes,
+		MessageInfos:      file_types_plugins_data_masking_config_proto_msgTypes,
+	}.Build()
+	File_types_plugins_data_masking_config_proto = out.File
+	file_types_plugins_data_masking_config_proto_rawDesc = nil
+	file_types_plugins_data_masking_config_proto_goTypes = nil
+	file_types_plugins_data_masking_config_proto_depIdxs = nil
+}
@@ -15,8 +15,13 @@
 package debug_mode
 
 import (
+	"errors"
+
+	"github.com/google/cel-go/cel"
+
 	"mosn.io/htnn/api/pkg/filtermanager/api"
 	"mosn.io/htnn/api/pkg/plugins"
+	"mosn.io/htnn/types/pkg/expr"
 )
 
 const (
@@ -43,5 +48,35 @@ func (p *Plugin) Order() plugins.PluginOrder {
 }
 
 func (p *Plugin) Config() api.PluginConfig {
-	return &Config{}
+	return &CustomConfig{}
+}
+
+type CustomConfig struct {
+	Config
+}
+
+func (conf *CustomConfig) Validate() error {
+	err := conf.Config.Validate()
+	if err != nil {
+		return err
+	}
+
+	trigger := conf.Trigger
+	if trigger != nil {
+		if trigger.Hmac == nil && trigger.Expr == "" {
+			return errors.New("either hmac or expr is required in trigger")
+		}
+		if trigger.Expr != "" {
+			_, err = expr.CompileCel(trigger.Expr, cel.BoolType)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	// The report contains the internal information, so it should not be returned to everyone
+	if conf.Report != nil && trigger == nil {
+		return errors.New("report requires trigger")
+	}
+	return nil
 }
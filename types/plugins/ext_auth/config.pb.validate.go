@@ -334,6 +334,10 @@ func (m *HttpService) validate(all bool) error {
 
 	// no validation rules for WithRequestBody
 
+	// no validation rules for MaxRequestBytes
+
+	// no validation rules for AllowPartialMessage
+
 	if len(errors) > 0 {
 		return HttpServiceMultiError(errors)
 	}
@@ -0,0 +1,129 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package header_rewrite
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/plugins"
+	"mosn.io/htnn/types/pkg/expr"
+)
+
+const (
+	Name = "headerRewrite"
+)
+
+func init() {
+	plugins.RegisterHttpPluginType(Name, &Plugin{})
+}
+
+type Plugin struct {
+	plugins.PluginMethodDefaultImpl
+}
+
+func (p *Plugin) Type() plugins.PluginType {
+	return plugins.TypeTransform
+}
+
+func (p *Plugin) Order() plugins.PluginOrder {
+	return plugins.PluginOrder{
+		Position: plugins.OrderPositionTransform,
+	}
+}
+
+func (p *Plugin) Config() api.PluginConfig {
+	return &CustomConfig{}
+}
+
+type CustomConfig struct {
+	Config
+}
+
+// ParseTemplate parses the value as a template if it contains an action like `{{ .Consumer }}`.
+// A nil template is returned if the value is a plain string.
+func ParseTemplate(value string) (*template.Template, error) {
+	if !strings.Contains(value, "{{") {
+		return nil, nil
+	}
+	return template.New("").Option("missingkey=error").Parse(value)
+}
+
+func validateOperations(ops *Operations, isHeader bool) error {
+	if isHeader {
+		names := make([]string, 0, len(ops.Set)+len(ops.Add)+len(ops.Rename)*2)
+		for _, r := range ops.Rename {
+			names = append(names, r.From, r.To)
+		}
+		for _, hv := range ops.Set {
+			names = append(names, hv.Key)
+		}
+		for _, hv := range ops.Add {
+			names = append(names, hv.Key)
+		}
+		for _, name := range names {
+			if strings.HasPrefix(name, ":") {
+				return fmt.Errorf("pseudo header %s can't be rewritten", name)
+			}
+		}
+	}
+
+	for _, m := range ops.Remove {
+		if _, err := expr.BuildStringMatcher(m); err != nil {
+			return err
+		}
+	}
+	for _, hv := range ops.Set {
+		if _, err := ParseTemplate(hv.Value); err != nil {
+			return fmt.Errorf("invalid template of %s: %w", hv.Key, err)
+		}
+	}
+	for _, hv := range ops.Add {
+		if _, err := ParseTemplate(hv.Value); err != nil {
+			return fmt.Errorf("invalid template of %s: %w", hv.Key, err)
+		}
+	}
+	return nil
+}
+
+func (conf *CustomConfig) Validate() error {
+	err := conf.Config.Validate()
+	if err != nil {
+		return err
+	}
+
+	if conf.RequestHeaders == nil && conf.QueryParams == nil && conf.ResponseHeaders == nil {
+		return errors.New("at least one of requestHeaders, queryParams and responseHeaders is required")
+	}
+	if conf.RequestHeaders != nil {
+		if err := validateOperations(conf.RequestHeaders, true); err != nil {
+			return err
+		}
+	}
+	if conf.QueryParams != nil {
+		if err := validateOperations(conf.QueryParams, false); err != nil {
+			return err
+		}
+	}
+	if conf.ResponseHeaders != nil {
+		if err := validateOperations(conf.ResponseHeaders, true); err != nil {
+			return err
+		}
+	}
+	return nil
+}
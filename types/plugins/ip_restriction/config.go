@@ -0,0 +1,111 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ip_restriction
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"strings"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/plugins"
+)
+
+const (
+	Name = "ipRestriction"
+)
+
+func init() {
+	plugins.RegisterHttpPluginType(Name, &Plugin{})
+}
+
+type Plugin struct {
+	plugins.PluginMethodDefaultImpl
+}
+
+func (p *Plugin) Type() plugins.PluginType {
+	return plugins.TypeSecurity
+}
+
+func (p *Plugin) Order() plugins.PluginOrder {
+	return plugins.PluginOrder{
+		Position: plugins.OrderPositionAccess,
+	}
+}
+
+func (p *Plugin) Config() api.PluginConfig {
+	return &CustomConfig{}
+}
+
+type CustomConfig struct {
+	Config
+}
+
+// ParsePrefix parses an IP or a CIDR. An IP is treated as a single address prefix.
+func ParsePrefix(s string) (netip.Prefix, error) {
+	if strings.Contains(s, "/") {
+		prefix, err := netip.ParsePrefix(s)
+		if err != nil {
+			return netip.Prefix{}, err
+		}
+		return prefix.Masked(), nil
+	}
+
+	addr, err := netip.ParseAddr(s)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+	addr = addr.Unmap()
+	return netip.PrefixFrom(addr, addr.BitLen()), nil
+}
+
+func validatePrefixes(field string, prefixes []string) error {
+	for _, s := range prefixes {
+		if _, err := ParsePrefix(s); err != nil {
+			return fmt.Errorf("invalid %s %q: %w", field, s, err)
+		}
+	}
+	return nil
+}
+
+func (conf *CustomConfig) Validate() error {
+	err := conf.Config.Validate()
+	if err != nil {
+		return err
+	}
+
+	if len(conf.Allow) == 0 && len(conf.Deny) == 0 {
+		return errors.New("either allow or deny is required")
+	}
+	if err := validatePrefixes("allow", conf.Allow); err != nil {
+		return err
+	}
+	if err := validatePrefixes("deny", conf.Deny); err != nil {
+		return err
+	}
+
+	xff := conf.XForwardedFor
+	if xff != nil {
+		if (xff.TrustedProxyCount == 0) == (len(xff.TrustedProxies) == 0) {
+			return errors.New("exactly one of trustedProxyCount and trustedProxies is required in xForwardedFor")
+		}
+		if err := validatePrefixes("trustedProxies", xff.TrustedProxies); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,122 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package json_transform
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"text/template"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/plugins"
+)
+
+const (
+	Name = "jsonTransform"
+)
+
+func init() {
+	plugins.RegisterHttpPluginType(Name, &Plugin{})
+}
+
+type Plugin struct {
+	plugins.PluginMethodDefaultImpl
+}
+
+func (p *Plugin) Type() plugins.PluginType {
+	return plugins.TypeTransform
+}
+
+func (p *Plugin) Order() plugins.PluginOrder {
+	return plugins.PluginOrder{
+		Position: plugins.OrderPositionTransform,
+	}
+}
+
+func (p *Plugin) Config() api.PluginConfig {
+	return &CustomConfig{}
+}
+
+type CustomConfig struct {
+	Config
+}
+
+// TemplateFuncs are the functions which can be used in the template
+var TemplateFuncs = template.FuncMap{
+	"json": func(v interface{}) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+}
+
+func validateTransformation(t *Transformation) error {
+	for _, p := range t.Remove {
+		segs, err := ParsePath(p)
+		if err != nil {
+			return err
+		}
+		if len(segs) == 0 {
+			return errors.New("can't remove the root")
+		}
+	}
+	for _, r := range t.Rename {
+		for _, p := range []string{r.From, r.To} {
+			segs, err := ParsePath(p)
+			if err != nil {
+				return err
+			}
+			if len(segs) == 0 {
+				return errors.New("can't rename the root")
+			}
+			if HasWildcard(segs) {
+				return fmt.Errorf("wildcard is not allowed in rename: %s", p)
+			}
+		}
+	}
+	for _, s := range t.Set {
+		if _, err := ParsePath(s.Path); err != nil {
+			return err
+		}
+	}
+	if t.Template != "" {
+		if _, err := template.New("").Funcs(TemplateFuncs).Parse(t.Template); err != nil {
+			return fmt.Errorf("invalid template: %w", err)
+		}
+	}
+	return nil
+}
+
+func (conf *CustomConfig) Validate() error {
+	err := conf.Config.Validate()
+	if err != nil {
+		return err
+	}
+
+	if conf.Request == nil && conf.Response == nil {
+		return errors.New("at least one of request and response is required")
+	}
+	if conf.Request != nil {
+		if err := validateTransformation(conf.Request); err != nil {
+			return err
+		}
+	}
+	if conf.Response != nil {
+		if err := validateTransformation(conf.Response); err != nil {
+			return err
+		}
+	}
+	return nil
+}
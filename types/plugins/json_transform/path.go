@@ -0,0 +1,100 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package json_transform
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Segment is a part of the JSONPath-style path
+type Segment struct {
+	// Key is the field name of an object
+	Key string
+	// Index is the index of an array, it's -1 if the segment is a field name
+	Index int
+	// Wildcard matches all the fields of an object or all the elements of an array
+	Wildcard bool
+}
+
+// ParsePath parses the JSONPath-style path, like `$.a.b`, `a.b`, `$.items[0].id`, `$.items[*].id`
+// or `$['key.with.dot']`. An empty slice is returned for the root path `$`.
+func ParsePath(path string) ([]Segment, error) {
+	s := strings.TrimPrefix(path, "$")
+	if s != "" && s[0] != '.' && s[0] != '[' {
+		if len(s) == len(path) {
+			// allow the path without the leading `$.`
+			s = "." + s
+		} else {
+			return nil, fmt.Errorf("invalid path %q", path)
+		}
+	}
+
+	var segs []Segment
+	for len(s) > 0 {
+		switch s[0] {
+		case '.':
+			s = s[1:]
+			end := strings.IndexAny(s, ".[")
+			if end == -1 {
+				end = len(s)
+			}
+			key := s[:end]
+			if key == "" {
+				return nil, fmt.Errorf("invalid path %q: empty field name", path)
+			}
+			s = s[end:]
+			if key == "*" {
+				segs = append(segs, Segment{Index: -1, Wildcard: true})
+			} else {
+				segs = append(segs, Segment{Key: key, Index: -1})
+			}
+		case '[':
+			end := strings.IndexByte(s, ']')
+			if end == -1 {
+				return nil, fmt.Errorf("invalid path %q: unclosed bracket", path)
+			}
+			inner := s[1:end]
+			s = s[end+1:]
+			if inner == "*" {
+				segs = append(segs, Segment{Index: -1, Wildcard: true})
+				continue
+			}
+			if len(inner) >= 2 && (inner[0] == '\'' || inner[0] == '"') && inner[len(inner)-1] == inner[0] {
+				segs = append(segs, Segment{Key: inner[1 : len(inner)-1], Index: -1})
+				continue
+			}
+			idx, err := strconv.Atoi(inner)
+			if err != nil || idx < 0 {
+				return nil, fmt.Errorf("invalid path %q: bad index %q", path, inner)
+			}
+			segs = append(segs, Segment{Index: idx})
+		default:
+			return nil, fmt.Errorf("invalid path %q", path)
+		}
+	}
+	return segs, nil
+}
+
+// HasWildcard reports whether the path contains wildcard.
+func HasWildcard(segs []Segment) bool {
+	for _, seg := range segs {
+		if seg.Wildcard {
+			return true
+		}
+	}
+	return false
+}
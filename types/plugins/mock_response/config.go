@@ -0,0 +1,114 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mock_response
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/google/cel-go/cel"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/plugins"
+	"mosn.io/htnn/types/pkg/expr"
+)
+
+const (
+	Name = "mockResponse"
+)
+
+func init() {
+	plugins.RegisterHttpPluginType(Name, &Plugin{})
+}
+
+type Plugin struct {
+	plugins.PluginMethodDefaultImpl
+}
+
+func (p *Plugin) Type() plugins.PluginType {
+	return plugins.TypeGeneral
+}
+
+func (p *Plugin) Order() plugins.PluginOrder {
+	return plugins.PluginOrder{
+		Position: plugins.OrderPositionBeforeUpstream,
+	}
+}
+
+func (p *Plugin) Config() api.PluginConfig {
+	return &CustomConfig{}
+}
+
+type CustomConfig struct {
+	Config
+}
+
+func ParseTemplate(s string) (*template.Template, error) {
+	return template.New("").Option("missingkey=error").Parse(s)
+}
+
+func validateMatch(m *Match) error {
+	if m.Path != nil {
+		if _, err := expr.BuildStringMatcher(m.Path); err != nil {
+			return err
+		}
+	}
+	for _, h := range m.Headers {
+		if h.Value == nil {
+			continue
+		}
+		if _, err := expr.BuildStringMatcher(h.Value); err != nil {
+			return err
+		}
+	}
+	if m.Expr != "" {
+		if _, err := expr.CompileCel(m.Expr, cel.BoolType); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (conf *CustomConfig) Validate() error {
+	err := conf.Config.Validate()
+	if err != nil {
+		return err
+	}
+
+	for i, rule := range conf.Rules {
+		if rule.Match != nil {
+			if err := validateMatch(rule.Match); err != nil {
+				return fmt.Errorf("invalid match of rule %d: %w", i, err)
+			}
+		}
+
+		rsp := rule.Response
+		if rsp.Status != 0 && rsp.Status < 200 {
+			return fmt.Errorf("invalid status %d of rule %d", rsp.Status, i)
+		}
+		for _, hv := range rsp.Headers {
+			if strings.HasPrefix(hv.Key, ":") {
+				return fmt.Errorf("pseudo header %s can't be set in rule %d", hv.Key, i)
+			}
+		}
+		if rsp.GetTemplate() != "" {
+			if _, err := ParseTemplate(rsp.GetTemplate()); err != nil {
+				return fmt.Errorf("invalid template of rule %d: %w", i, err)
+			}
+		}
+	}
+	return nil
+}
@@ -15,23 +15,42 @@
 package plugins
 
 import (
+	_ "mosn.io/htnn/types/plugins/access_log"
 	_ "mosn.io/htnn/types/plugins/bandwidth_limit"
 	_ "mosn.io/htnn/types/plugins/buffer"
+	_ "mosn.io/htnn/types/plugins/buffer_limit"
 	_ "mosn.io/htnn/types/plugins/casbin"
 	_ "mosn.io/htnn/types/plugins/cel_script"
+	_ "mosn.io/htnn/types/plugins/circuit_breaker"
 	_ "mosn.io/htnn/types/plugins/consumer_restriction"
 	_ "mosn.io/htnn/types/plugins/cors"
+	_ "mosn.io/htnn/types/plugins/csrf"
+	_ "mosn.io/htnn/types/plugins/data_masking"
 	_ "mosn.io/htnn/types/plugins/debug_mode"
 	_ "mosn.io/htnn/types/plugins/demo"
 	_ "mosn.io/htnn/types/plugins/ext_auth"
 	_ "mosn.io/htnn/types/plugins/ext_proc"
 	_ "mosn.io/htnn/types/plugins/fault"
+	_ "mosn.io/htnn/types/plugins/header_rewrite"
 	_ "mosn.io/htnn/types/plugins/hmac_auth"
+	_ "mosn.io/htnn/types/plugins/ip_restriction"
+	_ "mosn.io/htnn/types/plugins/json_transform"
 	_ "mosn.io/htnn/types/plugins/key_auth"
 	_ "mosn.io/htnn/types/plugins/limit_count_redis"
 	_ "mosn.io/htnn/types/plugins/limit_req"
+	_ "mosn.io/htnn/types/plugins/load_shedding"
 	_ "mosn.io/htnn/types/plugins/local_ratelimit"
 	_ "mosn.io/htnn/types/plugins/lua"
+	_ "mosn.io/htnn/types/plugins/mock_response"
 	_ "mosn.io/htnn/types/plugins/oidc"
 	_ "mosn.io/htnn/types/plugins/opa"
+	_ "mosn.io/htnn/types/plugins/plugin_tracing"
+	_ "mosn.io/htnn/types/plugins/quota"
+	_ "mosn.io/htnn/types/plugins/request_id"
+	_ "mosn.io/htnn/types/plugins/request_metrics"
+	_ "mosn.io/htnn/types/plugins/request_validation"
+	_ "mosn.io/htnn/types/plugins/response_cache"
+	_ "mosn.io/htnn/types/plugins/uri_rewrite"
+	_ "mosn.io/htnn/types/plugins/waf"
+	_ "mosn.io/htnn/types/plugins/webhook_signature"
 )
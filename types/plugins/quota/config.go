@@ -0,0 +1,105 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package quota
+
+import (
+	"fmt"
+	"net"
+	"time"
+	// The Envoy image may not contain the zoneinfo database
+	_ "time/tzdata"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/plugins"
+)
+
+const (
+	Name = "quota"
+)
+
+func init() {
+	plugins.RegisterHttpPluginType(Name, &Plugin{})
+}
+
+type Plugin struct {
+	plugins.PluginMethodDefaultImpl
+}
+
+func (p *Plugin) Type() plugins.PluginType {
+	return plugins.TypeTraffic
+}
+
+func (p *Plugin) Order() plugins.PluginOrder {
+	return plugins.PluginOrder{
+		Position: plugins.OrderPositionTraffic,
+	}
+}
+
+func (p *Plugin) Config() api.PluginConfig {
+	return &CustomConfig{}
+}
+
+type CustomConfig struct {
+	Config
+}
+
+func (conf *CustomConfig) Validate() error {
+	err := conf.Config.Validate()
+	if err != nil {
+		return err
+	}
+
+	addr := conf.GetAddress()
+	if addr != "" {
+		_, _, err = net.SplitHostPort(addr)
+		if err != nil {
+			return fmt.Errorf("bad address %s: %w", addr, err)
+		}
+	}
+	cluster := conf.GetCluster()
+	if cluster != nil {
+		for _, addr := range cluster.Addresses {
+			_, _, err = net.SplitHostPort(addr)
+			if err != nil {
+				return fmt.Errorf("bad address %s: %w", addr, err)
+			}
+		}
+	}
+
+	if conf.TimeZone != "" {
+		_, err = time.LoadLocation(conf.TimeZone)
+		if err != nil {
+			return fmt.Errorf("bad time zone %s: %w", conf.TimeZone, err)
+		}
+	}
+
+	periods := make(map[Period]bool, len(conf.Quotas))
+	for i, quota := range conf.Quotas {
+		if periods[quota.Period] {
+			return fmt.Errorf("bad quota %d: duplicate period %s", i, quota.Period)
+		}
+		periods[quota.Period] = true
+
+		if quota.SoftLimit >= quota.Limit {
+			return fmt.Errorf("bad quota %d: soft limit should be less than limit", i)
+		}
+	}
+
+	if conf.Username != "" && conf.Password == "" {
+		return fmt.Errorf("password is required when username is set")
+	}
+
+	return nil
+}
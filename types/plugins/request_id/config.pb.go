@@ -0,0 +1,256 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0
+// 	protoc        v4.24.4
+// source: types/plugins/request_id/config.proto
+
+package request_id
+
+import (
+	reflect "reflect"
+	sync "sync"
+
+	_ "github.com/envoyproxy/protoc-gen-validate/validate"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Generator int32
+
+const (
+	// UUID version 4, like `2d1e8b5a-6c1f-4a57-9e47-2b1f0c9d8e3a`
+	Generator_UUID Generator = 0
+	// ULID, like `01J2Z3T7XKQ8Y4N5M6P7R8S9T0`
+	Generator_ULID Generator = 1
+)
+
+// Enum value maps for Generator.
+var (
+	Generator_name = map[int32]string{
+		0: "UUID",
+		1: "ULID",
+	}
+	Generator_value = map[string]int32{
+		"UUID": 0,
+		"ULID": 1,
+	}
+)
+
+func (x Generator) Enum() *Generator {
+	p := new(Generator)
+	*p = x
+	return p
+}
+
+func (x Generator) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Generator) Descriptor() protoreflect.EnumDescriptor {
+	return file_types_plugins_request_id_config_proto_enumTypes[0].Descriptor()
+}
+
+func (Generator) Type() protoreflect.EnumType {
+	return &file_types_plugins_request_id_config_proto_enumTypes[0]
+}
+
+func (x Generator) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Generator.Descriptor instead.
+func (Generator) EnumDescriptor() ([]byte, []int) {
+	return file_types_plugins_request_id_config_proto_rawDescGZIP(), []int{0}
+}
+
+type Config struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The request header which carries the request ID. Default to `x-request-id`.
+	Header string `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	// The RE2 regular expression which the incoming request ID should match. The request ID not
+	// matching it is replaced with a generated one. Default to `^[a-zA-Z0-9._-]{1,128}$`.
+	Pattern   string    `protobuf:"bytes,2,opt,name=pattern,proto3" json:"pattern,omitempty"`
+	Generator Generator `protobuf:"varint,3,opt,name=generator,proto3,enum=types.plugins.request_id.Generator" json:"generator,omitempty"`
+	// The response header which carries the request ID. Default to the same as `header`.
+	ResponseHeader string `protobuf:"bytes,4,opt,name=response_header,json=responseHeader,proto3" json:"response_header,omitempty"`
+}
+
+func (x *Config) Reset() {
+	*x = Config{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_types_plugins_request_id_config_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Config) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Config) ProtoMessage() {}
+
+func (x *Config) ProtoReflect() protoreflect.Message {
+	mi := &file_types_plugins_request_id_config_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Config.ProtoReflect.Descriptor instead.
+func (*Config) Descriptor() ([]byte, []int) {
+	return file_types_plugins_request_id_config_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Config) GetHeader() string {
+	if x != nil {
+		return x.Header
+	}
+	return ""
+}
+
+func (x *Config) GetPattern() string {
+	if x != nil {
+		return x.Pattern
+	}
+	return ""
+}
+
+func (x *Config) GetGenerator() Generator {
+	if x != nil {
+		return x.Generator
+	}
+	return Generator_UUID
+}
+
+func (x *Config) GetResponseHeader() string {
+	if x != nil {
+		return x.ResponseHeader
+	}
+	return ""
+}
+
+var File_types_plugins_request_id_config_proto protoreflect.FileDescriptor
+
+var file_types_plugins_request_id_config_proto_rawDesc = []byte{
+	0x0a, 0x25, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2f, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x73, 0x2f,
+	0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x69, 0x64, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x18, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x70,
+	0x6c, 0x75, 0x67, 0x69, 0x6e, 0x73, 0x2e, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x69,
+	0x64, 0x1a, 0x17, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x2f, 0x76, 0x61, 0x6c, 0x69,
+	0x64, 0x61, 0x74, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xca, 0x01, 0x0a, 0x06, 0x43,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x23, 0x0a, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x0b, 0xfa, 0x42, 0x08, 0x72, 0x06, 0xd0, 0x01, 0x01, 0xc0,
+	0x01, 0x01, 0x52, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x61,
+	0x74, 0x74, 0x65, 0x72, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x61, 0x74,
+	0x74, 0x65, 0x72, 0x6e, 0x12, 0x4b, 0x0a, 0x09, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x6f,
+	0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x23, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e,
+	0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x73, 0x2e, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f,
+	0x69, 0x64, 0x2e, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x42, 0x08, 0xfa, 0x42,
+	0x05, 0x82, 0x01, 0x02, 0x10, 0x01, 0x52, 0x09, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x6f,
+	0x72, 0x12, 0x34, 0x0a, 0x0f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f, 0x68, 0x65,
+	0x61, 0x64, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x42, 0x0b, 0xfa, 0x42, 0x08, 0x72,
+	0x06, 0xd0, 0x01, 0x01, 0xc0, 0x01, 0x01, 0x52, 0x0e, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x2a, 0x1f, 0x0a, 0x09, 0x47, 0x65, 0x6e, 0x65, 0x72,
+	0x61, 0x74, 0x6f, 0x72, 0x12, 0x08, 0x0a, 0x04, 0x55, 0x55, 0x49, 0x44, 0x10, 0x00, 0x12, 0x08,
+	0x0a, 0x04, 0x55, 0x4c, 0x49, 0x44, 0x10, 0x01, 0x42, 0x27, 0x5a, 0x25, 0x6d, 0x6f, 0x73, 0x6e,
+	0x2e, 0x69, 0x6f, 0x2f, 0x68, 0x74, 0x6e, 0x6e, 0x2f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2f, 0x70,
+	0x6c, 0x75, 0x67, 0x69, 0x6e, 0x73, 0x2f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x69,
+	0x64, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_types_plugins_request_id_config_proto_rawDescOnce sync.Once
+	file_types_plugins_request_id_config_proto_rawDescData = file_types_plugins_request_id_config_proto_rawDesc
+)
+
+func file_types_plugins_request_id_config_proto_rawDescGZIP() []byte {
+	file_types_plugins_request_id_config_proto_rawDescOnce.Do(func() {
+		file_types_plugins_request_id_config_proto_rawDescData = protoimpl.X.CompressGZIP(file_types_plugins_request_id_config_proto_rawDescData)
+	})
+	return file_types_plugins_request_id_config_proto_rawDescData
+}
+
+var file_types_plugins_request_id_config_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_types_plugins_request_id_config_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_types_plugins_request_id_config_proto_goTypes = []interface{}{
+	(Generator)(0), // 0: types.plugins.request_id.Generator
+	(*Config)(nil), // 1: types.plugins.request_id.Config
+}
+var file_types_plugins_request_id_config_proto_depIdxs = []int32{
+	0, // 0: types.plugins.request_id.Config.generator:type_name -> types.plugins.request_id.Generator
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_types_plugins_request_id_config_proto_init() }
+func file_types_plugins_request_id_config_proto_init() {
+	if File_types_plugins_request_id_config_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_types_plugins_request_id_config_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Config); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_types_plugins_request_id_config_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_types_plugins_request_id_config_proto_goTypes,
+		DependencyIndexes: file_types_plugins_request_id_config_proto_depIdxs,
+		EnumInfos:         file_types_plugins_request_id_config_proto_enumTypes,
+		MessageInfos:      file_types_plugins_request_id_config_proto_msgTypes,
+	}.Build()
+	File_types_plugins_request_id_config_proto = out.File
+	file_types_plugins_request_id_config_proto_rawDesc = nil
+	file_types_plugins_request_id_config_proto_goTypes = nil
+	file_types_plugins_request_id_config_proto_depIdxs = nil
+}
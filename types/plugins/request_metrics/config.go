@@ -0,0 +1,84 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request_metrics
+
+import (
+	"fmt"
+
+	"github.com/google/cel-go/cel"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/plugins"
+	"mosn.io/htnn/types/pkg/expr"
+)
+
+const (
+	Name = "requestMetrics"
+)
+
+var (
+	// ReservedLabels are the labels set by the plugin
+	ReservedLabels = []string{"route", "consumer", "code", "le"}
+)
+
+func init() {
+	plugins.RegisterHttpPluginType(Name, &Plugin{})
+}
+
+type Plugin struct {
+	plugins.PluginMethodDefaultImpl
+}
+
+func (p *Plugin) Type() plugins.PluginType {
+	return plugins.TypeObservability
+}
+
+func (p *Plugin) Order() plugins.PluginOrder {
+	return plugins.PluginOrder{
+		Position: plugins.OrderPositionStats,
+	}
+}
+
+func (p *Plugin) Config() api.PluginConfig {
+	return &CustomConfig{}
+}
+
+type CustomConfig struct {
+	Config
+}
+
+func (conf *CustomConfig) Validate() error {
+	err := conf.Config.Validate()
+	if err != nil {
+		return err
+	}
+
+	names := map[string]struct{}{}
+	for _, name := range ReservedLabels {
+		names[name] = struct{}{}
+	}
+	for _, label := range conf.Labels {
+		if _, ok := names[label.Name]; ok {
+			return fmt.Errorf("duplicate or reserved label name: %s", label.Name)
+		}
+		names[label.Name] = struct{}{}
+
+		_, err := expr.CompileCel(label.Expr, cel.StringType)
+		if err != nil {
+			return fmt.Errorf("bad label %s: %w", label.Name, err)
+		}
+	}
+	return nil
+}
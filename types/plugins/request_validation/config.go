@@ -0,0 +1,130 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request_validation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/getkin/kin-openapi/openapi3"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/plugins"
+)
+
+const (
+	Name = "requestValidation"
+)
+
+func init() {
+	plugins.RegisterHttpPluginType(Name, &Plugin{})
+}
+
+type Plugin struct {
+	plugins.PluginMethodDefaultImpl
+}
+
+func (p *Plugin) Type() plugins.PluginType {
+	return plugins.TypeSecurity
+}
+
+func (p *Plugin) Order() plugins.PluginOrder {
+	return plugins.PluginOrder{
+		Position: plugins.OrderPositionAccess,
+	}
+}
+
+func (p *Plugin) Config() api.PluginConfig {
+	return &CustomConfig{}
+}
+
+type CustomConfig struct {
+	Config
+}
+
+// LoadOpenAPI loads and validates the OpenAPI 3 document in YAML or JSON.
+func LoadOpenAPI(data []byte) (*openapi3.T, error) {
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromData(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load OpenAPI document: %w", err)
+	}
+	err = doc.Validate(loader.Context)
+	if err != nil {
+		return nil, fmt.Errorf("invalid OpenAPI document: %w", err)
+	}
+	return doc, nil
+}
+
+// ParseSchema parses and validates the JSON Schema.
+func ParseSchema(s string) (*openapi3.Schema, error) {
+	schema := &openapi3.Schema{}
+	err := schema.UnmarshalJSON([]byte(s))
+	if err != nil {
+		return nil, err
+	}
+	err = schema.Validate(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	return schema, nil
+}
+
+func (conf *CustomConfig) Validate() error {
+	err := conf.Config.Validate()
+	if err != nil {
+		return err
+	}
+
+	if oa := conf.GetOpenapi(); oa != nil {
+		if oa.GetInline() != "" {
+			_, err = LoadOpenAPI([]byte(oa.GetInline()))
+			return err
+		}
+		return nil
+	}
+
+	js := conf.GetJsonSchema()
+	schemas := []struct {
+		field  string
+		schema string
+	}{
+		{"body", js.Body},
+		{"query", js.Query},
+		{"headers", js.Headers},
+		{"responseBody", js.ResponseBody},
+	}
+	empty := true
+	for _, s := range schemas {
+		if s.schema == "" {
+			continue
+		}
+		empty = false
+		field := s.field
+		schema, err := ParseSchema(s.schema)
+		if err != nil {
+			return fmt.Errorf("invalid %s schema: %w", field, err)
+		}
+		if (field == "query" || field == "headers") && schema.Type != openapi3.TypeObject {
+			return fmt.Errorf("%s schema should be an object", field)
+		}
+	}
+	if empty {
+		return errors.New("at least one schema is required in jsonSchema")
+	}
+
+	return nil
+}
@@ -0,0 +1,98 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package response_cache
+
+import (
+	"errors"
+	"fmt"
+	"net"
+
+	"github.com/google/cel-go/cel"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/plugins"
+	"mosn.io/htnn/types/pkg/expr"
+)
+
+const (
+	Name = "responseCache"
+)
+
+func init() {
+	plugins.RegisterHttpPluginType(Name, &Plugin{})
+}
+
+type Plugin struct {
+	plugins.PluginMethodDefaultImpl
+}
+
+func (p *Plugin) Type() plugins.PluginType {
+	return plugins.TypeTraffic
+}
+
+func (p *Plugin) Order() plugins.PluginOrder {
+	return plugins.PluginOrder{
+		Position: plugins.OrderPositionTraffic,
+	}
+}
+
+func (p *Plugin) Config() api.PluginConfig {
+	return &CustomConfig{}
+}
+
+type CustomConfig struct {
+	Config
+}
+
+func (conf *CustomConfig) Validate() error {
+	err := conf.Config.Validate()
+	if err != nil {
+		return err
+	}
+
+	r := conf.GetRedis()
+	if r != nil {
+		addrs := []string{}
+		if r.GetAddress() != "" {
+			addrs = append(addrs, r.GetAddress())
+		} else {
+			addrs = append(addrs, r.GetCluster().Addresses...)
+		}
+		for _, addr := range addrs {
+			_, _, err = net.SplitHostPort(addr)
+			if err != nil {
+				return fmt.Errorf("bad address %s: %w", addr, err)
+			}
+		}
+
+		if r.Username != "" && r.Password == "" {
+			return errors.New("password is required when username is set")
+		}
+	}
+
+	if conf.Key != nil && conf.Key.Expr != "" {
+		_, err = expr.CompileCel(conf.Key.Expr, cel.StringType)
+		if err != nil {
+			return err
+		}
+	}
+
+	if conf.DefaultTtl != nil && conf.MaxTtl != nil &&
+		conf.DefaultTtl.AsDuration() > conf.MaxTtl.AsDuration() {
+		return errors.New("default_ttl should not be greater than max_ttl")
+	}
+
+	return nil
+}
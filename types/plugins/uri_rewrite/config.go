@@ -0,0 +1,120 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package uri_rewrite
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/google/cel-go/cel"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/plugins"
+	"mosn.io/htnn/types/pkg/expr"
+)
+
+const (
+	Name = "uriRewrite"
+)
+
+func init() {
+	plugins.RegisterHttpPluginType(Name, &Plugin{})
+}
+
+type Plugin struct {
+	plugins.PluginMethodDefaultImpl
+}
+
+func (p *Plugin) Type() plugins.PluginType {
+	return plugins.TypeTransform
+}
+
+func (p *Plugin) Order() plugins.PluginOrder {
+	return plugins.PluginOrder{
+		Position: plugins.OrderPositionAccess,
+	}
+}
+
+func (p *Plugin) Config() api.PluginConfig {
+	return &CustomConfig{}
+}
+
+type CustomConfig struct {
+	Config
+}
+
+// CompilePath compiles the path regex so that it matches the whole path
+func CompilePath(s string) (*regexp.Regexp, error) {
+	return regexp.Compile("^(?:" + s + ")$")
+}
+
+func validateMatch(m *Match) error {
+	if m.Path != "" {
+		if _, err := CompilePath(m.Path); err != nil {
+			return err
+		}
+	}
+	if m.Host != nil {
+		if _, err := expr.BuildStringMatcher(m.Host); err != nil {
+			return err
+		}
+	}
+	if m.Expr != "" {
+		if _, err := expr.CompileCel(m.Expr, cel.BoolType); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateRule(rule *Rule) error {
+	if err := validateMatch(rule.Match); err != nil {
+		return err
+	}
+
+	switch action := rule.Action.(type) {
+	case *Rule_Redirect:
+		r := action.Redirect
+		switch r.Status {
+		case 0, 301, 302, 307, 308:
+		default:
+			return fmt.Errorf("invalid redirect status %d", r.Status)
+		}
+		if r.Scheme == "" && r.Host == "" && r.Path == "" && !rule.StripQuery {
+			return errors.New("redirect to the same URL")
+		}
+	case *Rule_Rewrite:
+		r := action.Rewrite
+		if r.Host == "" && r.Path == "" && !rule.StripQuery {
+			return errors.New("nothing to rewrite")
+		}
+	}
+	return nil
+}
+
+func (conf *CustomConfig) Validate() error {
+	err := conf.Config.Validate()
+	if err != nil {
+		return err
+	}
+
+	for i, rule := range conf.Rules {
+		if err := validateRule(rule); err != nil {
+			return fmt.Errorf("invalid rule %d: %w", i, err)
+		}
+	}
+	return nil
+}